@@ -19,21 +19,32 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/infrastructure-io/topohub/pkg/bindingip"
+	"github.com/infrastructure-io/topohub/pkg/compliance"
 	"github.com/infrastructure-io/topohub/pkg/config"
 	"github.com/infrastructure-io/topohub/pkg/debug"
+	"github.com/infrastructure-io/topohub/pkg/dhcplease"
+	"github.com/infrastructure-io/topohub/pkg/host"
 	"github.com/infrastructure-io/topohub/pkg/hostendpoint"
+	"github.com/infrastructure-io/topohub/pkg/hostgroup"
 	"github.com/infrastructure-io/topohub/pkg/hostoperation"
 	"github.com/infrastructure-io/topohub/pkg/httpserver"
 	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
 	crdclientset "github.com/infrastructure-io/topohub/pkg/k8s/client/clientset/versioned/typed/topohub.infrastructure.io/v1beta1"
 	"github.com/infrastructure-io/topohub/pkg/log"
+	"github.com/infrastructure-io/topohub/pkg/node"
+	"github.com/infrastructure-io/topohub/pkg/provision"
+	"github.com/infrastructure-io/topohub/pkg/provisionprofile"
 	"github.com/infrastructure-io/topohub/pkg/redfishstatus"
 	"github.com/infrastructure-io/topohub/pkg/secret"
 	"github.com/infrastructure-io/topohub/pkg/sshstatus"
 	"github.com/infrastructure-io/topohub/pkg/subnet"
+	"github.com/infrastructure-io/topohub/pkg/switchstatus"
+	"github.com/infrastructure-io/topohub/pkg/switchztp"
+	"github.com/infrastructure-io/topohub/pkg/tftp"
 	bindingipwebhook "github.com/infrastructure-io/topohub/pkg/webhook/bindingip"
 	hostendpointwebhook "github.com/infrastructure-io/topohub/pkg/webhook/hostendpoint"
 	hostoperationwebhook "github.com/infrastructure-io/topohub/pkg/webhook/hostoperation"
+	provisionprofilewebhook "github.com/infrastructure-io/topohub/pkg/webhook/provisionprofile"
 	redfishstatuswebhook "github.com/infrastructure-io/topohub/pkg/webhook/redfishstatus"
 	sshstatuswebhook "github.com/infrastructure-io/topohub/pkg/webhook/sshstatus"
 	subnetwebhook "github.com/infrastructure-io/topohub/pkg/webhook/subnet"
@@ -126,7 +137,7 @@ func main() {
 	}
 
 	// Setup HostOperation webhook
-	if err = (&hostoperationwebhook.HostOperationWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+	if err = (&hostoperationwebhook.HostOperationWebhook{}).SetupWebhookWithManager(mgr, *agentConfig); err != nil {
 		log.Logger.Errorf("unable to create webhook %s: %v", "HostOperation", err)
 		os.Exit(1)
 	}
@@ -155,8 +166,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Setup ProvisionProfile webhook
+	if err = (&provisionprofilewebhook.ProvisionProfileWebhook{}).SetupWebhookWithManager(mgr); err != nil {
+		log.Logger.Errorf("unable to create webhook %s: %v", "ProvisionProfile", err)
+		os.Exit(1)
+	}
+
 	// todo: subnet manager
-	subnetMgr := subnet.NewSubnetReconciler(*agentConfig, k8sClient)
+	provisionTracker := provision.NewProvisionTracker(k8sClient, agentConfig, mgr)
+	if err = provisionTracker.SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("Unable to create provision tracker: %v", err)
+		os.Exit(1)
+	}
+	provisionEvents := provisionTracker.GetEventChan()
+
+	// the files generated in memory by the provisionprofile controller, and served by the built-in tftp servers
+	tftpFiles := tftp.NewMemoryFiles()
+
+	subnetMgr := subnet.NewSubnetReconciler(*agentConfig, k8sClient, provisionEvents, tftpFiles)
 	if err = subnetMgr.SetupWithManager(mgr); err != nil {
 		log.Logger.Errorf("Failed to setup subnet manager: %v", err)
 		os.Exit(1)
@@ -195,7 +222,7 @@ func main() {
 	}
 
 	// Initialize hostoperation controller
-	hostOperationCtrl, err := hostoperation.NewHostOperationController(mgr, agentConfig)
+	hostOperationCtrl, err := hostoperation.NewHostOperationController(mgr, k8sClient, agentConfig, provisionEvents)
 	if err != nil {
 		log.Logger.Errorf("Failed to create hostoperation controller: %v", err)
 		os.Exit(1)
@@ -207,12 +234,19 @@ func main() {
 	}
 
 	// Initialize sshstatus controller
-	sshStatusCtrl := sshstatus.NewSSHStatusController(k8sClient, agentConfig, mgr)
+	sshStatusCtrl := sshstatus.NewSSHStatusController(k8sClient, agentConfig, mgr, provisionEvents)
 	if err = sshStatusCtrl.SetupWithManager(mgr); err != nil {
 		log.Logger.Errorf("Unable to create sshstatus controller: %v", err)
 		os.Exit(1)
 	}
 
+	// Initialize switchstatus controller, it collects the lldp neighbors of the switches
+	switchStatusCtrl := switchstatus.NewSwitchStatusController(k8sClient, agentConfig, mgr)
+	if err = switchStatusCtrl.SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("Unable to create switchstatus controller: %v", err)
+		os.Exit(1)
+	}
+
 	// Initialize bindingIP controller
 	bindingIPCtrl := bindingip.NewBindingIPController(mgr, agentConfig, addBindingIpChan, deleteBindingIpChan)
 	if err != nil {
@@ -224,10 +258,62 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Initialize dhcplease controller, it converts the annotated lease to bindingIp
+	dhcpLeaseCtrl := dhcplease.NewDhcpLeaseController(mgr, agentConfig)
+	if err = dhcpLeaseCtrl.SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("Unable to create dhcplease controller: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize provisionprofile controller, it renders the pxe boot configs for the hosts
+	provisionProfileCtrl := provisionprofile.NewProvisionProfileController(mgr, agentConfig, tftpFiles)
+	if err = provisionProfileCtrl.SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("Unable to create provisionprofile controller: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize switchztp controller, it renders the ztp files for the switches
+	switchZtpCtrl := switchztp.NewSwitchZtpController(mgr, agentConfig)
+	if err = switchZtpCtrl.SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("Unable to create switchztp controller: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize compliancepolicy controller, it evaluates the facts of the hosts by the policies
+	compliancePolicyCtrl := compliance.NewCompliancePolicyController(mgr)
+	if err = compliancePolicyCtrl.SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("Unable to create compliancepolicy controller: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize hostgroup controller, it summarizes the hosts of each cluster
+	hostGroupCtrl := hostgroup.NewHostGroupController(mgr)
+	if err = hostGroupCtrl.SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("Unable to create hostgroup controller: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize host controller, it links the records of each physical server by the system uuid or the serial number
+	hostCtrl := host.NewHostController(mgr)
+	if err = hostCtrl.SetupWithManager(mgr); err != nil {
+		log.Logger.Errorf("Unable to create host controller: %v", err)
+		os.Exit(1)
+	}
+
+	// Initialize node controller, it maps the redfishstatus to the kubernetes nodes when they are the nodes of this cluster
+	if agentConfig.NodeIntegrationEnabled {
+		log.Logger.Info("Node integration is enabled")
+		nodeCtrl := node.NewNodeController(mgr, k8sClient, agentConfig)
+		if err = nodeCtrl.SetupWithManager(mgr); err != nil {
+			log.Logger.Errorf("Unable to create node controller: %v", err)
+			os.Exit(1)
+		}
+	}
+
 	// start http server for pxe and ztp
 	if agentConfig.HttpEnabled {
 		log.Logger.Info("Http server is enabled for pxe and ztp")
-		httpServer := httpserver.NewHttpServer(*agentConfig)
+		httpServer := httpserver.NewHttpServer(*agentConfig, mgr.GetClient(), mgr.Elected(), provisionEvents, switchZtpCtrl.GetFetchEventChan(), tftpFiles)
 		httpServer.Run()
 	} else {
 		log.Logger.Info("Http server is disabled for pxe and ztp")
@@ -292,6 +378,11 @@ func main() {
 			// Stop sshstatus controller
 			sshStatusCtrl.Stop()
 
+			// Stop switchstatus controller
+			switchStatusCtrl.Stop()
+
+			provisionTracker.Stop()
+
 			// Cancel context to stop manager
 			cancel()
 
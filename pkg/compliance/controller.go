@@ -0,0 +1,233 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+)
+
+// all the policies are evaluated together, so the events are merged into a single request
+var evaluateRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "compliancepolicies"}}
+
+// the max length of the message of the Compliant condition of the host
+const maxConditionMessage = 4096
+
+// CompliancePolicyController evaluates the hosts by the CompliancePolicies, it reports the Compliant condition
+// of each host, and the summary of each cluster in the status of the policy
+type CompliancePolicyController struct {
+	client client.Client
+	log    *zap.SugaredLogger
+}
+
+func NewCompliancePolicyController(mgr ctrl.Manager) *CompliancePolicyController {
+	return &CompliancePolicyController{
+		client: mgr.GetClient(),
+		log:    log.Logger.Named("compliancepolicyReconcile"),
+	}
+}
+
+// 只有 leader 才会执行 Reconcile
+func (r *CompliancePolicyController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if err := r.evaluateAll(ctx); err != nil {
+		r.log.Errorf("failed to evaluate compliancepolicies: %v", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// hostCompliance collects the results of the policies which select the host
+type hostCompliance struct {
+	policies []string
+	healthy  bool
+	messages []string
+}
+
+// evaluateAll evaluates all the policies, and updates the Compliant condition of all the hosts
+func (r *CompliancePolicyController) evaluateAll(ctx context.Context) error {
+	policyList := &topohubv1beta1.CompliancePolicyList{}
+	if err := r.client.List(ctx, policyList); err != nil {
+		return fmt.Errorf("failed to list compliancepolicies: %v", err)
+	}
+	redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+	if err := r.client.List(ctx, redfishStatusList); err != nil {
+		return fmt.Errorf("failed to list redfishstatus: %v", err)
+	}
+	sshStatusList := &topohubv1beta1.SSHStatusList{}
+	if err := r.client.List(ctx, sshStatusList); err != nil {
+		return fmt.Errorf("failed to list sshstatus: %v", err)
+	}
+
+	hosts := make([]host, 0, len(redfishStatusList.Items)+len(sshStatusList.Items))
+	for i := range redfishStatusList.Items {
+		hosts = append(hosts, newRedfishHost(&redfishStatusList.Items[i]))
+	}
+	for i := range sshStatusList.Items {
+		hosts = append(hosts, newSSHHost(&sshStatusList.Items[i]))
+	}
+
+	sort.Slice(policyList.Items, func(i, j int) bool {
+		return policyList.Items[i].Name < policyList.Items[j].Name
+	})
+	compliance := map[string]*hostCompliance{}
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		rules, msgs := compileRules(policy.Spec.Rules)
+		selected, err := selectHosts(policy, hosts)
+		if err != nil {
+			msgs = append(msgs, err.Error())
+		}
+		results := evaluatePolicy(selected, rules)
+		for _, result := range results {
+			item, ok := compliance[result.host.key()]
+			if !ok {
+				item = &hostCompliance{}
+				compliance[result.host.key()] = item
+			}
+			item.policies = append(item.policies, policy.Name)
+			item.healthy = result.healthy
+			for _, v := range result.violations {
+				item.messages = append(item.messages, formatViolation(policy.Name, v))
+			}
+		}
+		if err := r.updatePolicyStatus(ctx, policy, buildReport(results), msgs); err != nil {
+			r.log.Errorf("failed to update status of compliancepolicy %s: %v", policy.Name, err)
+		}
+	}
+
+	var errs []string
+	for i := range redfishStatusList.Items {
+		item := &redfishStatusList.Items[i]
+		updated := item.DeepCopy()
+		if !setHostCondition(&updated.Status.Conditions, compliantCondition(compliance[newRedfishHost(item).key()])) {
+			continue
+		}
+		if err := r.client.Status().Update(ctx, updated); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to update redfishstatus %s: %v", item.Name, err))
+			continue
+		}
+		r.log.Debugf("updated the compliant condition of redfishstatus %s", item.Name)
+	}
+	for i := range sshStatusList.Items {
+		item := &sshStatusList.Items[i]
+		updated := item.DeepCopy()
+		if !setHostCondition(&updated.Status.Conditions, compliantCondition(compliance[newSSHHost(item).key()])) {
+			continue
+		}
+		if err := r.client.Status().Update(ctx, updated); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to update sshstatus %s: %v", item.Name, err))
+			continue
+		}
+		r.log.Debugf("updated the compliant condition of sshstatus %s", item.Name)
+	}
+	if len(errs) > 0 {
+		// the conflicts with the status controllers are retried
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func formatViolation(policy string, v topohubv1beta1.ComplianceViolation) string {
+	if v.Actual == "" {
+		return fmt.Sprintf("%s/%s: %s is empty or not found, expected %s", policy, v.Rule, v.Fact, v.Expected)
+	}
+	return fmt.Sprintf("%s/%s: %s is %q, expected %s", policy, v.Rule, v.Fact, v.Actual, v.Expected)
+}
+
+// compliantCondition returns the Compliant condition of the host, or nil when no policy selects the host
+func compliantCondition(item *hostCompliance) *metav1.Condition {
+	if item == nil {
+		return nil
+	}
+	condition := &metav1.Condition{
+		Type:    topohubv1beta1.HostConditionCompliant,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Compliant",
+		Message: fmt.Sprintf("the host complies with the compliancepolicies %s", strings.Join(item.policies, ",")),
+	}
+	switch {
+	case !item.healthy:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "HostUnhealthy"
+		condition.Message = "the facts of the unhealthy host are not evaluated"
+	case len(item.messages) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NonCompliant"
+		condition.Message = strings.Join(item.messages, "; ")
+		if len(condition.Message) > maxConditionMessage {
+			condition.Message = condition.Message[:maxConditionMessage-3] + "..."
+		}
+	}
+	return condition
+}
+
+// setHostCondition sets or removes the Compliant condition, and returns whether the conditions are changed
+func setHostCondition(conditions *[]metav1.Condition, condition *metav1.Condition) bool {
+	if condition == nil {
+		return meta.RemoveStatusCondition(conditions, topohubv1beta1.HostConditionCompliant)
+	}
+	old := meta.FindStatusCondition(*conditions, condition.Type)
+	if old != nil && old.Status == condition.Status && old.Reason == condition.Reason && old.Message == condition.Message {
+		return false
+	}
+	meta.SetStatusCondition(conditions, *condition)
+	return true
+}
+
+// updatePolicyStatus updates the report and the Evaluated condition of the policy
+func (r *CompliancePolicyController) updatePolicyStatus(ctx context.Context, policy *topohubv1beta1.CompliancePolicy, report topohubv1beta1.CompliancePolicyStatus, msgs []string) error {
+	updated := policy.DeepCopy()
+	report.Conditions = updated.Status.Conditions
+	updated.Status = report
+
+	condition := metav1.Condition{
+		Type:    topohubv1beta1.CompliancePolicyConditionEvaluated,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Evaluated",
+		Message: fmt.Sprintf("%d hosts are evaluated, %d hosts are non-compliant", report.HostAmount, report.NonCompliantAmount),
+	}
+	if len(msgs) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidRules"
+		condition.Message = strings.Join(msgs, "; ")
+	}
+	meta.SetStatusCondition(&updated.Status.Conditions, condition)
+
+	if reflect.DeepEqual(policy.Status, updated.Status) {
+		return nil
+	}
+	if err := r.client.Status().Update(ctx, updated); err != nil {
+		return err
+	}
+	r.log.Infof("updated status of compliancepolicy %s: %d hosts, %d non-compliant", policy.Name, report.HostAmount, report.NonCompliantAmount)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *CompliancePolicyController) SetupWithManager(mgr ctrl.Manager) error {
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{evaluateRequest}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("compliancepolicy").
+		// the status updates of the policies are ignored
+		Watches(&topohubv1beta1.CompliancePolicy{}, enqueue, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&topohubv1beta1.RedfishStatus{}, enqueue).
+		Watches(&topohubv1beta1.SSHStatus{}, enqueue).
+		Complete(r)
+}
@@ -0,0 +1,337 @@
+package compliance
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// the max number of the non-compliant hosts reported in the status of the policy
+const maxReportedHosts = 100
+
+// the kinds of the hosts in the report
+const (
+	kindRedfishStatus = "RedfishStatus"
+	kindSSHStatus     = "SSHStatus"
+)
+
+// host is a RedfishStatus or SSHStatus evaluated by the policies
+type host struct {
+	kind        string
+	name        string
+	source      string
+	clusterName string
+	labels      labels.Set
+	healthy     bool
+	facts       map[string]string
+}
+
+func (h host) key() string {
+	return h.kind + "/" + h.name
+}
+
+func newRedfishHost(item *topohubv1beta1.RedfishStatus) host {
+	facts := make(map[string]string, len(item.Status.Info))
+	for k, v := range item.Status.Info {
+		facts[k] = v
+	}
+	return host{
+		kind:        kindRedfishStatus,
+		name:        item.Name,
+		source:      topohubv1beta1.ComplianceSourceRedfish,
+		clusterName: item.Status.Basic.ClusterName,
+		labels:      labels.Set(item.Labels),
+		healthy:     item.Status.Healthy,
+		facts:       facts,
+	}
+}
+
+func newSSHHost(item *topohubv1beta1.SSHStatus) host {
+	facts := make(map[string]string, len(item.Status.Info))
+	for k, v := range item.Status.Info {
+		facts[k] = v
+	}
+	addInventoryFacts(item.Status.Inventory, facts)
+	return host{
+		kind:        kindSSHStatus,
+		name:        item.Name,
+		source:      topohubv1beta1.ComplianceSourceSSH,
+		clusterName: item.Status.Basic.ClusterName,
+		labels:      labels.Set(item.Labels),
+		healthy:     item.Status.Healthy,
+		facts:       facts,
+	}
+}
+
+// addInventoryFacts flattens the inventory into the facts, the items of the lists are keyed by their names
+func addInventoryFacts(inv *topohubv1beta1.SSHInventory, facts map[string]string) {
+	if inv == nil {
+		return
+	}
+	set := func(key, value string) {
+		if value != "" {
+			facts["inventory."+key] = value
+		}
+	}
+	set("systemVendor", inv.SystemVendor)
+	set("systemProduct", inv.SystemProduct)
+	for _, nic := range inv.Nics {
+		set("nics."+nic.Name+".driver", nic.Driver)
+		set("nics."+nic.Name+".firmware", nic.Firmware)
+		if nic.SpeedMbps > 0 {
+			set("nics."+nic.Name+".speedMbps", strconv.Itoa(int(nic.SpeedMbps)))
+		}
+	}
+	for _, dev := range inv.RdmaDevices {
+		set("rdmaDevices."+dev.Name+".linkLayer", dev.LinkLayer)
+		set("rdmaDevices."+dev.Name+".nic", dev.Nic)
+	}
+	for _, gpu := range inv.Gpus {
+		set("gpus."+gpu.PciAddr+".model", gpu.Model)
+		set("gpus."+gpu.PciAddr+".driverVersion", gpu.DriverVersion)
+	}
+}
+
+// rule is the validated rule with the compiled regex
+type rule struct {
+	topohubv1beta1.ComplianceRule
+	regex *regexp.Regexp
+}
+
+// compileRules validates the rules of the policy, the invalid rules are skipped and reported
+func compileRules(rules []topohubv1beta1.ComplianceRule) ([]rule, []string) {
+	var result []rule
+	var msgs []string
+	for _, r := range rules {
+		item := rule{ComplianceRule: r}
+		if item.Operator == "" {
+			item.Operator = topohubv1beta1.ComplianceOperatorEqual
+		}
+		if _, err := path.Match(item.Fact, ""); err != nil {
+			msgs = append(msgs, fmt.Sprintf("rule %s: invalid fact pattern %q", r.Name, r.Fact))
+			continue
+		}
+		if item.Operator != topohubv1beta1.ComplianceOperatorExists && len(item.Values) == 0 {
+			msgs = append(msgs, fmt.Sprintf("rule %s: values are required by the operator %s", r.Name, item.Operator))
+			continue
+		}
+		if item.Operator == topohubv1beta1.ComplianceOperatorRegex {
+			regex, err := regexp.Compile(item.Values[0])
+			if err != nil {
+				msgs = append(msgs, fmt.Sprintf("rule %s: invalid regex: %v", r.Name, err))
+				continue
+			}
+			item.regex = regex
+		}
+		result = append(result, item)
+	}
+	return result, msgs
+}
+
+// compareVersions compares the numeric parts of the versions in order, the non-numeric parts are compared as strings
+func compareVersions(a, b string) int {
+	split := func(v string) []string {
+		return strings.FieldsFunc(v, func(r rune) bool {
+			return r == '.' || r == '-' || r == '_' || r == '+' || r == ' '
+		})
+	}
+	pa, pb := split(a), split(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		if i >= len(pa) {
+			return -1
+		}
+		if i >= len(pb) {
+			return 1
+		}
+		na, errA := strconv.ParseInt(pa[i], 10, 64)
+		nb, errB := strconv.ParseInt(pb[i], 10, 64)
+		if errA == nil && errB == nil {
+			if na != nb {
+				if na < nb {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+		if c := strings.Compare(pa[i], pb[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// satisfy checks the value of the fact against the rule
+func (r rule) satisfy(actual string) bool {
+	switch r.Operator {
+	case topohubv1beta1.ComplianceOperatorExists:
+		return true
+	case topohubv1beta1.ComplianceOperatorNotEqual:
+		return actual != r.Values[0]
+	case topohubv1beta1.ComplianceOperatorIn:
+		for _, v := range r.Values {
+			if actual == v {
+				return true
+			}
+		}
+		return false
+	case topohubv1beta1.ComplianceOperatorRegex:
+		return r.regex.MatchString(actual)
+	case topohubv1beta1.ComplianceOperatorVersionAtLeast:
+		return compareVersions(actual, r.Values[0]) >= 0
+	default:
+		return actual == r.Values[0]
+	}
+}
+
+func (r rule) expected() string {
+	switch r.Operator {
+	case topohubv1beta1.ComplianceOperatorExists:
+		return r.Operator
+	case topohubv1beta1.ComplianceOperatorIn:
+		return fmt.Sprintf("%s [%s]", r.Operator, strings.Join(r.Values, ","))
+	default:
+		return fmt.Sprintf("%s %s", r.Operator, r.Values[0])
+	}
+}
+
+// evaluate returns the violations of the host, all the facts matched by the rule must satisfy it
+func (r rule) evaluate(facts map[string]string) []topohubv1beta1.ComplianceViolation {
+	var keys []string
+	for key := range facts {
+		if ok, _ := path.Match(r.Fact, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		if r.Operator == topohubv1beta1.ComplianceOperatorNotEqual {
+			return nil
+		}
+		return []topohubv1beta1.ComplianceViolation{{Rule: r.Name, Fact: r.Fact, Expected: r.expected()}}
+	}
+	sort.Strings(keys)
+
+	var violations []topohubv1beta1.ComplianceViolation
+	for _, key := range keys {
+		if !r.satisfy(facts[key]) {
+			violations = append(violations, topohubv1beta1.ComplianceViolation{
+				Rule:     r.Name,
+				Fact:     key,
+				Expected: r.expected(),
+				Actual:   facts[key],
+			})
+		}
+	}
+	return violations
+}
+
+// hostResult is the result of the host evaluated by a policy
+type hostResult struct {
+	host       host
+	healthy    bool
+	violations []topohubv1beta1.ComplianceViolation
+}
+
+// selectHosts returns the hosts of the policy, the hosts are selected by the cluster name and the selector,
+// and only the hosts of the sources which the rules apply to are selected
+func selectHosts(policy *topohubv1beta1.CompliancePolicy, hosts []host) ([]host, error) {
+	selector := labels.Everything()
+	if policy.Spec.Selector != nil {
+		s, err := metav1.LabelSelectorAsSelector(policy.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %v", err)
+		}
+		selector = s
+	}
+	sources := map[string]bool{}
+	for _, r := range policy.Spec.Rules {
+		sources[r.Source] = true
+	}
+
+	var result []host
+	for _, h := range hosts {
+		if !sources[h.source] {
+			continue
+		}
+		if policy.Spec.ClusterName != "" && h.clusterName != policy.Spec.ClusterName {
+			continue
+		}
+		if !selector.Matches(h.labels) {
+			continue
+		}
+		result = append(result, h)
+	}
+	return result, nil
+}
+
+// evaluatePolicy evaluates the selected hosts by the rules of the policy
+func evaluatePolicy(hosts []host, rules []rule) []hostResult {
+	results := make([]hostResult, 0, len(hosts))
+	for _, h := range hosts {
+		result := hostResult{host: h, healthy: h.healthy}
+		// the facts of the unhealthy hosts are not collected or stale
+		if h.healthy {
+			for _, r := range rules {
+				if r.Source == h.source {
+					result.violations = append(result.violations, r.evaluate(h.facts)...)
+				}
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// buildReport summarizes the results by the cluster names
+func buildReport(results []hostResult) topohubv1beta1.CompliancePolicyStatus {
+	status := topohubv1beta1.CompliancePolicyStatus{HostAmount: int32(len(results))}
+	clusters := map[string]*topohubv1beta1.ComplianceClusterReport{}
+	for _, result := range results {
+		report, ok := clusters[result.host.clusterName]
+		if !ok {
+			report = &topohubv1beta1.ComplianceClusterReport{ClusterName: result.host.clusterName}
+			clusters[result.host.clusterName] = report
+		}
+		report.HostAmount++
+		switch {
+		case !result.healthy:
+			report.UnknownAmount++
+			status.UnknownAmount++
+		case len(result.violations) > 0:
+			report.NonCompliantAmount++
+			report.NonCompliantHosts = append(report.NonCompliantHosts, result.host.name)
+			status.NonCompliantAmount++
+			status.NonCompliantHosts = append(status.NonCompliantHosts, topohubv1beta1.ComplianceHostReport{
+				Name:        result.host.name,
+				Kind:        result.host.kind,
+				ClusterName: result.host.clusterName,
+				Violations:  result.violations,
+			})
+		default:
+			report.CompliantAmount++
+		}
+	}
+
+	for _, report := range clusters {
+		sort.Strings(report.NonCompliantHosts)
+		status.Clusters = append(status.Clusters, *report)
+	}
+	sort.Slice(status.Clusters, func(i, j int) bool {
+		return status.Clusters[i].ClusterName < status.Clusters[j].ClusterName
+	})
+	sort.Slice(status.NonCompliantHosts, func(i, j int) bool {
+		return status.NonCompliantHosts[i].Kind+"/"+status.NonCompliantHosts[i].Name < status.NonCompliantHosts[j].Kind+"/"+status.NonCompliantHosts[j].Name
+	})
+	if len(status.NonCompliantHosts) > maxReportedHosts {
+		status.NonCompliantHosts = status.NonCompliantHosts[:maxReportedHosts]
+	}
+	return status
+}
@@ -0,0 +1,119 @@
+package compliance
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestCompareVersions tests the numeric parts of the versions are compared in order
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b   string
+		expect int
+	}{
+		{"5.15.0-91-generic", "5.15.0-76-generic", 1},
+		{"5.15.0", "5.15.0", 0},
+		{"2.10", "2.9", 1},
+		{"1.2", "1.2.1", -1},
+		{"U30 v2.40", "U30 v2.40", 0},
+	}
+	for _, c := range cases {
+		if got := compareVersions(c.a, c.b); got != c.expect {
+			t.Errorf("compareVersions(%q, %q) = %d, expected %d", c.a, c.b, got, c.expect)
+		}
+	}
+}
+
+// TestEvaluatePolicy tests the rules are evaluated by the facts of the selected hosts and summarized by the clusters
+func TestEvaluatePolicy(t *testing.T) {
+	policy := &topohubv1beta1.CompliancePolicy{
+		Spec: topohubv1beta1.CompliancePolicySpec{
+			Rules: []topohubv1beta1.ComplianceRule{
+				{Name: "kernel", Source: "ssh", Fact: "Kernel", Operator: "VersionAtLeast", Values: []string{"5.15.0-76"}},
+				{Name: "rdma-mode", Source: "ssh", Fact: "inventory.rdmaDevices.*.linkLayer", Values: []string{"Ethernet"}},
+				{Name: "trust", Source: "ssh", Fact: "rdma-qos/*.trust", Operator: "In", Values: []string{"dscp"}},
+				{Name: "bios", Source: "redfish", Fact: "BiosVerison", Operator: "Regex", Values: []string{"^U30 "}},
+			},
+		},
+	}
+	rules, msgs := compileRules(policy.Spec.Rules)
+	if len(msgs) != 0 || len(rules) != 4 {
+		t.Fatalf("unexpected rules: %v", msgs)
+	}
+
+	good := &topohubv1beta1.SSHStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "good"},
+		Status: topohubv1beta1.SSHStatusStatus{
+			Healthy: true,
+			Basic:   topohubv1beta1.SSHBasicInfo{ClusterName: "gpu"},
+			Info:    map[string]string{"Kernel": "5.15.0-91-generic", "rdma-qos/eth0.trust": "dscp"},
+			Inventory: &topohubv1beta1.SSHInventory{
+				RdmaDevices: []topohubv1beta1.SSHRdmaDevice{{Name: "mlx5_0", LinkLayer: "Ethernet"}},
+			},
+		},
+	}
+	bad := good.DeepCopy()
+	bad.Name = "bad"
+	bad.Status.Info["Kernel"] = "5.4.0-100"
+	bad.Status.Inventory.RdmaDevices = append(bad.Status.Inventory.RdmaDevices, topohubv1beta1.SSHRdmaDevice{Name: "mlx5_1", LinkLayer: "InfiniBand"})
+	delete(bad.Status.Info, "rdma-qos/eth0.trust")
+	down := good.DeepCopy()
+	down.Name = "down"
+	down.Status.Healthy = false
+	bmc := &topohubv1beta1.RedfishStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc"},
+		Status: topohubv1beta1.RedfishStatusStatus{
+			Healthy: true,
+			Basic:   topohubv1beta1.BasicInfo{ClusterName: "storage"},
+			Info:    map[string]string{"BiosVerison": "U30 v2.40"},
+		},
+	}
+
+	hosts := []host{newSSHHost(good), newSSHHost(bad), newSSHHost(down), newRedfishHost(bmc)}
+	selected, err := selectHosts(policy, hosts)
+	if err != nil || len(selected) != 4 {
+		t.Fatalf("expected 4 hosts, got %d, %v", len(selected), err)
+	}
+	policy.Spec.ClusterName = "gpu"
+	if selected, _ := selectHosts(policy, hosts); len(selected) != 3 {
+		t.Errorf("expected 3 hosts of the cluster gpu, got %d", len(selected))
+	}
+
+	results := evaluatePolicy(hosts, rules)
+	if len(results[0].violations) != 0 || len(results[3].violations) != 0 {
+		t.Errorf("unexpected violations: %+v %+v", results[0].violations, results[3].violations)
+	}
+	if v := results[1].violations; len(v) != 3 || v[0].Rule != "kernel" || v[1].Fact != "inventory.rdmaDevices.mlx5_1.linkLayer" || v[2].Actual != "" {
+		t.Errorf("unexpected violations of the bad host: %+v", v)
+	}
+
+	report := buildReport(results)
+	if report.HostAmount != 4 || report.NonCompliantAmount != 1 || report.UnknownAmount != 1 || len(report.Clusters) != 2 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if c := report.Clusters[0]; c.ClusterName != "gpu" || c.CompliantAmount != 1 || len(c.NonCompliantHosts) != 1 || c.NonCompliantHosts[0] != "bad" {
+		t.Errorf("unexpected report of the cluster gpu: %+v", c)
+	}
+
+	if _, msgs := compileRules([]topohubv1beta1.ComplianceRule{{Name: "r", Source: "ssh", Fact: "Kernel", Operator: "Regex", Values: []string{"("}}}); len(msgs) != 1 {
+		t.Errorf("expected the invalid regex to be reported")
+	}
+}
+
+// TestSetHostCondition tests the Compliant condition is only updated when it changes
+func TestSetHostCondition(t *testing.T) {
+	var conditions []metav1.Condition
+	condition := compliantCondition(&hostCompliance{policies: []string{"p"}, healthy: true, messages: []string{"p/kernel: Kernel is \"5.4\", expected VersionAtLeast 5.15"}})
+	if !setHostCondition(&conditions, condition) || conditions[0].Status != metav1.ConditionFalse {
+		t.Fatalf("expected the NonCompliant condition, got %+v", conditions)
+	}
+	if setHostCondition(&conditions, condition) {
+		t.Errorf("expected no change")
+	}
+	if !setHostCondition(&conditions, nil) || len(conditions) != 0 {
+		t.Errorf("expected the condition to be removed when no policy selects the host")
+	}
+}
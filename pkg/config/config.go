@@ -10,8 +10,12 @@ import (
 	"github.com/infrastructure-io/topohub/pkg/log"
 	"github.com/infrastructure-io/topohub/pkg/tools"
 	"gopkg.in/yaml.v2"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// the netboot directory in the image, which holds the boot loaders of the legacy BIOS
+const netbootSourceDir = "/files/netboot"
+
 // AgentConfig represents the agent configuration
 type AgentConfig struct {
 
@@ -21,6 +25,10 @@ type AgentConfig struct {
 	// node name
 	NodeName string
 
+	// the service account of the pod, which is the only user allowed to update the HostEndpoints registered by
+	// the phone-home and to move the HostOperations of the renamed RedfishStatus
+	ServiceAccountName string
+
 	// webhook cert dir
 	WebhookCertDir string
 
@@ -33,7 +41,9 @@ type AgentConfig struct {
 	StoragePathHttpZtp                  string
 	StoragePathHttpIso                  string
 	StoragePathHttpTools                string
+	StoragePathHttpPxe                  string
 	StoragePathTftp                     string
+	StoragePathTftpGrub                 string
 	StoragePathTftpRelativeDirForPxeEfi string
 	StoragePathTftpAbsoluteDirForPxeEfi string
 
@@ -48,13 +58,56 @@ type AgentConfig struct {
 	RedfishSecretName           string
 	RedfishSecretNamespace      string
 	RedfishStatusUpdateInterval int
-	SSHStatusUpdateInterval     int
+	// the secret of the discovered and the phone-home registered ssh hosts, it is empty when no default ssh credentials
+	// are configured. It is separated from the redfish secret, so the credentials of the BMC are not used to login
+	// the operating system
+	SSHSecretName           string
+	SSHSecretNamespace      string
+	SSHStatusUpdateInterval int
+	// the interval to collect the lldp neighbors of the switches
+	SwitchStatusUpdateInterval int
 	// DHCP server configuration
 	DhcpServerInterface string
 	HttpEnabled         bool
 	HttpPort            string
+	// the bandwidth of the files served to each client in bytes per second, 0 means no limit
+	HttpClientBandwidth int64
+	// the secret holds the token of the phone-home endpoint, the phone-home endpoint is disabled when it is empty.
+	// The registered hosts use the ssh secret, so a leaked installer token does not expose the ssh credentials
+	PhoneHomeSecretName      string
+	PhoneHomeSecretNamespace string
+	// the secret holds the token of the file api, the file api is disabled when it is empty
+	FileApiSecretName      string
+	FileApiSecretNamespace string
+	// the service accounts which are allowed to call the file api by the TokenReview,
+	// like system:serviceaccount:default:uploader
+	FileApiServiceAccounts []string
+	// the quota of the files in the http directory in bytes, 0 means no limit
+	FileApiQuota int64
+
+	// the partial files of the chunked uploads, which are not served by the http server
+	StoragePathUploads string
+
+	// the integration with the kubernetes nodes, when the managed hosts are the nodes of this cluster
+	NodeIntegrationEnabled bool
+	// the ways to find the node of the redfishstatus, which are tried in order
+	NodeMatchBy []string
+	// the seconds to wait for the pods of the node to be evicted before the disruptive actions
+	NodeDrainTimeout int
 }
 
+// the ways to find the node of the redfishstatus
+const (
+	// the hostname reported by the redfish or the ssh is the name of the node
+	NodeMatchByHostname = "hostname"
+	// the spec.providerID of the node contains the system uuid or the serial number
+	NodeMatchByProviderID = "providerID"
+	// the ip addresses of the node are leased or bound to the mac addresses of the host
+	NodeMatchByMac = "mac"
+)
+
+const defaultNodeDrainTimeout = 600
+
 // FeatureConfig represents the feature configuration loaded from YAML
 type FeatureConfig struct {
 	RedfishPort                 int    `yaml:"redfishPort"`
@@ -62,10 +115,30 @@ type FeatureConfig struct {
 	RedfishSecretname           string `yaml:"redfishSecretname"`
 	RedfishSecretNamespace      string `yaml:"redfishSecretNamespace"`
 	RedfishStatusUpdateInterval int    `yaml:"redfishStatusUpdateInterval"`
+	SSHSecretName               string `yaml:"sshSecretName"`
+	SSHSecretNamespace          string `yaml:"sshSecretNamespace"`
 	SSHStatusUpdateInterval     int    `yaml:"sshStatusUpdateInterval"`
+	SwitchStatusUpdateInterval  int    `yaml:"switchStatusUpdateInterval"`
 	DhcpServerInterface         string `yaml:"dhcpServerInterface"`
 	HttpServerPort              string `yaml:"httpServerPort"`
 	HttpServerEnabled           bool   `yaml:"httpServerEnabled"`
+	PhoneHomeSecretName         string `yaml:"phoneHomeSecretName"`
+	PhoneHomeSecretNamespace    string `yaml:"phoneHomeSecretNamespace"`
+
+	// the bandwidth of each client of the http server, the quantity like 100Mi
+	HttpClientBandwidth string `yaml:"httpClientBandwidth"`
+
+	// the file api of the http server
+	FileApiSecretName      string   `yaml:"fileApiSecretName"`
+	FileApiSecretNamespace string   `yaml:"fileApiSecretNamespace"`
+	FileApiServiceAccounts []string `yaml:"fileApiServiceAccounts"`
+	// the quantity like 200Gi
+	FileApiQuota string `yaml:"fileApiQuota"`
+
+	// the integration with the kubernetes nodes
+	NodeIntegrationEnabled bool     `yaml:"nodeIntegrationEnabled"`
+	NodeMatchBy            []string `yaml:"nodeMatchBy"`
+	NodeDrainTimeout       int      `yaml:"nodeDrainTimeout"`
 }
 
 // LoadFeatureConfig loads feature configuration from the config file
@@ -88,10 +161,46 @@ func (c *AgentConfig) loadFeatureConfig() error {
 	c.RedfishSecretName = featureConfig.RedfishSecretname
 	c.RedfishSecretNamespace = featureConfig.RedfishSecretNamespace
 	c.RedfishStatusUpdateInterval = featureConfig.RedfishStatusUpdateInterval
+	c.SSHSecretName = featureConfig.SSHSecretName
+	c.SSHSecretNamespace = featureConfig.SSHSecretNamespace
 	c.SSHStatusUpdateInterval = featureConfig.SSHStatusUpdateInterval
+	c.SwitchStatusUpdateInterval = featureConfig.SwitchStatusUpdateInterval
 	c.DhcpServerInterface = featureConfig.DhcpServerInterface
 	c.HttpPort = featureConfig.HttpServerPort
 	c.HttpEnabled = featureConfig.HttpServerEnabled
+	if featureConfig.HttpClientBandwidth != "" {
+		bandwidth, err := resource.ParseQuantity(featureConfig.HttpClientBandwidth)
+		if err != nil {
+			return fmt.Errorf("invalid httpClientBandwidth %s: %v", featureConfig.HttpClientBandwidth, err)
+		}
+		c.HttpClientBandwidth = bandwidth.Value()
+	}
+	c.PhoneHomeSecretName = featureConfig.PhoneHomeSecretName
+	c.PhoneHomeSecretNamespace = featureConfig.PhoneHomeSecretNamespace
+	c.FileApiSecretName = featureConfig.FileApiSecretName
+	c.FileApiSecretNamespace = featureConfig.FileApiSecretNamespace
+	c.FileApiServiceAccounts = featureConfig.FileApiServiceAccounts
+	if featureConfig.FileApiQuota != "" {
+		quota, err := resource.ParseQuantity(featureConfig.FileApiQuota)
+		if err != nil {
+			return fmt.Errorf("invalid fileApiQuota %s: %v", featureConfig.FileApiQuota, err)
+		}
+		c.FileApiQuota = quota.Value()
+	}
+	c.NodeIntegrationEnabled = featureConfig.NodeIntegrationEnabled
+	c.NodeMatchBy = featureConfig.NodeMatchBy
+	if len(c.NodeMatchBy) == 0 {
+		c.NodeMatchBy = []string{NodeMatchByHostname, NodeMatchByProviderID, NodeMatchByMac}
+	}
+	for _, item := range c.NodeMatchBy {
+		if item != NodeMatchByHostname && item != NodeMatchByProviderID && item != NodeMatchByMac {
+			return fmt.Errorf("invalid nodeMatchBy %s, it should be one of %s, %s and %s", item, NodeMatchByHostname, NodeMatchByProviderID, NodeMatchByMac)
+		}
+	}
+	c.NodeDrainTimeout = featureConfig.NodeDrainTimeout
+	if c.NodeDrainTimeout <= 0 {
+		c.NodeDrainTimeout = defaultNodeDrainTimeout
+	}
 
 	// 验证必要的字段
 	if len(c.DhcpServerInterface) == 0 {
@@ -106,6 +215,14 @@ func (c *AgentConfig) loadFeatureConfig() error {
 	return nil
 }
 
+// ControllerUsername returns the username of the service account of topohub in the admission requests
+func (c *AgentConfig) ControllerUsername() string {
+	if c.ServiceAccountName == "" {
+		return ""
+	}
+	return fmt.Sprintf("system:serviceaccount:%s:%s", c.PodNamespace, c.ServiceAccountName)
+}
+
 // verifyWebhookCertDir verifies that the webhook certificate directory exists and contains required files
 func (c *AgentConfig) verifyWebhookCertDir() error {
 	requiredFiles := []string{"tls.crt", "tls.key", "ca.crt"}
@@ -128,12 +245,15 @@ func (c *AgentConfig) initStorageDirectory() error {
 	c.StoragePathDhcpConfig = filepath.Join(c.StoragePath, "dhcp/config")
 	c.StoragePathDhcpLog = filepath.Join(c.StoragePath, "dhcp/log")
 	c.StoragePathTftp = filepath.Join(c.StoragePath, "tftp")
+	c.StoragePathTftpGrub = filepath.Join(c.StoragePathTftp, "boot/grub")
 	c.StoragePathTftpRelativeDirForPxeEfi = "boot/grub/x86_64-efi"
 	c.StoragePathTftpAbsoluteDirForPxeEfi = filepath.Join(c.StoragePathTftp, c.StoragePathTftpRelativeDirForPxeEfi)
 	c.StoragePathHttp = filepath.Join(c.StoragePath, "http")
 	c.StoragePathHttpZtp = filepath.Join(c.StoragePathHttp, "ztp")
 	c.StoragePathHttpIso = filepath.Join(c.StoragePathHttp, "iso")
 	c.StoragePathHttpTools = filepath.Join(c.StoragePathHttp, "tools")
+	c.StoragePathHttpPxe = filepath.Join(c.StoragePathHttp, "pxe")
+	c.StoragePathUploads = filepath.Join(c.StoragePath, "uploads")
 
 	// List of required subdirectories
 	subdirs := []string{
@@ -145,6 +265,9 @@ func (c *AgentConfig) initStorageDirectory() error {
 		c.StoragePathHttp,
 		c.StoragePathHttpIso,
 		c.StoragePathHttpZtp,
+		c.StoragePathHttpPxe,
+		c.StoragePathHttpTools,
+		c.StoragePathUploads,
 	}
 
 	// Check and create each subdirectory if it doesn't exist
@@ -183,6 +306,16 @@ func (c *AgentConfig) initStorageDirectory() error {
 		}
 	}
 
+	// Copy the netboot directory of the other architectures, which is generated by grub-mknetdir in the image.
+	// The existing files are not overwritten, so the loaders uploaded by the user are kept
+	if _, err := os.Stat(netbootSourceDir); err == nil {
+		cmd := exec.Command("cp", "-rn", netbootSourceDir+"/.", c.StoragePathTftp)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to copy netboot files: %v, output: %s", err, string(output))
+		}
+		log.Logger.Infof("Successfully copied netboot files to %s", c.StoragePathTftp)
+	}
+
 	// Delete and recreate tools directory
 	if err := os.RemoveAll(c.StoragePathHttpTools); err != nil {
 		return fmt.Errorf("failed to delete tools directory %s: %v", c.StoragePathHttpTools, err)
@@ -214,6 +347,11 @@ func LoadAgentConfig() (*AgentConfig, error) {
 		return nil, fmt.Errorf("NODE_NAME environment variable not set")
 	}
 
+	agentConfig.ServiceAccountName = os.Getenv("POD_SERVICE_ACCOUNT")
+	if agentConfig.ServiceAccountName == "" {
+		return nil, fmt.Errorf("POD_SERVICE_ACCOUNT environment variable not set")
+	}
+
 	agentConfig.WebhookCertDir = os.Getenv("WEBHOOK_CERT_DIR")
 	if agentConfig.WebhookCertDir == "" {
 		return nil, fmt.Errorf("WEBHOOK_CERT_DIR environment variable not set")
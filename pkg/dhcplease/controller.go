@@ -0,0 +1,105 @@
+package dhcplease
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+)
+
+// DhcpLeaseController converts the DhcpLease into a BindingIp when it is annotated
+type DhcpLeaseController struct {
+	client      client.Client
+	agentConfig *config.AgentConfig
+	log         *zap.SugaredLogger
+}
+
+func NewDhcpLeaseController(mgr ctrl.Manager, agentConfig *config.AgentConfig) *DhcpLeaseController {
+	return &DhcpLeaseController{
+		client:      mgr.GetClient(),
+		agentConfig: agentConfig,
+		log:         log.Logger.Named("dhcpleaseReconcile"),
+	}
+}
+
+// formatBindingIpName returns the name of the BindingIp, which is the same as the one created for redfishstatus
+func formatBindingIpName(ip string) string {
+	return strings.ReplaceAll(ip, ".", "-")
+}
+
+// 只有 leader 才会执行 Reconcile
+func (r *DhcpLeaseController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.log.With("dhcplease", req.Name)
+
+	lease := &topohubv1beta1.DhcpLease{}
+	if err := r.client.Get(ctx, req.NamespacedName, lease); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Errorf("failed to get DhcpLease: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	if lease.Annotations[topohubv1beta1.AnnotationDhcpLeaseBindIp] != "true" {
+		return ctrl.Result{}, nil
+	}
+
+	// check whether the ip has been bound
+	bindingIPList := &topohubv1beta1.BindingIpList{}
+	if err := r.client.List(ctx, bindingIPList); err != nil {
+		logger.Errorf("failed to list BindingIPs: %v", err)
+		return ctrl.Result{}, err
+	}
+	for _, item := range bindingIPList.Items {
+		if item.Spec.IpAddr != lease.Spec.IpAddr {
+			continue
+		}
+		if strings.EqualFold(item.Spec.MacAddr, lease.Spec.MacAddr) {
+			logger.Debugf("bindingip %s already exists for the lease: %+v", item.Name, item.Spec)
+		} else {
+			logger.Errorf("ip %s has been bound to mac %s by bindingip %s, ignore the lease with mac %s", lease.Spec.IpAddr, item.Spec.MacAddr, item.Name, lease.Spec.MacAddr)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	bindingIP := &topohubv1beta1.BindingIp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: formatBindingIpName(lease.Spec.IpAddr),
+			Labels: map[string]string{
+				topohubv1beta1.LabelDhcpLease:  lease.Name,
+				topohubv1beta1.LabelSubnetName: lease.Spec.Subnet,
+			},
+		},
+		Spec: topohubv1beta1.BindingIpSpec{
+			Subnet:  lease.Spec.Subnet,
+			IpAddr:  lease.Spec.IpAddr,
+			MacAddr: lease.Spec.MacAddr,
+		},
+	}
+	if err := r.client.Create(ctx, bindingIP); err != nil {
+		if errors.IsAlreadyExists(err) {
+			logger.Errorf("a conflicted bindingip %s already exists", bindingIP.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Errorf("failed to create BindingIP: %v", err)
+		return ctrl.Result{}, err
+	}
+	logger.Infof("converted DhcpLease %s to bindingip %s: %+v", lease.Name, bindingIP.Name, bindingIP.Spec)
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *DhcpLeaseController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&topohubv1beta1.DhcpLease{}).
+		Complete(r)
+}
@@ -0,0 +1,160 @@
+package host
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+)
+
+// all the records are resolved together, so the events are merged into a single request
+var linkRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "hosts"}}
+
+// HostController creates a Host for each physical server found by the system uuid or the serial number, and
+// links its RedfishStatus, SSHStatus, DhcpLeases and BindingIps in the status
+type HostController struct {
+	client client.Client
+	log    *zap.SugaredLogger
+}
+
+func NewHostController(mgr ctrl.Manager) *HostController {
+	return &HostController{
+		client: mgr.GetClient(),
+		log:    log.Logger.Named("hostReconcile"),
+	}
+}
+
+// 只有 leader 才会执行 Reconcile
+func (r *HostController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if err := r.linkAll(ctx); err != nil {
+		r.log.Errorf("failed to link hosts: %v", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *HostController) listRecords(ctx context.Context) (*records, error) {
+	redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+	if err := r.client.List(ctx, redfishStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list redfishstatus: %v", err)
+	}
+	sshStatusList := &topohubv1beta1.SSHStatusList{}
+	if err := r.client.List(ctx, sshStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list sshstatus: %v", err)
+	}
+	leaseList := &topohubv1beta1.DhcpLeaseList{}
+	if err := r.client.List(ctx, leaseList); err != nil {
+		return nil, fmt.Errorf("failed to list dhcpleases: %v", err)
+	}
+	bindingIpList := &topohubv1beta1.BindingIpList{}
+	if err := r.client.List(ctx, bindingIpList); err != nil {
+		return nil, fmt.Errorf("failed to list bindingips: %v", err)
+	}
+	return &records{
+		redfishStatus: redfishStatusList.Items,
+		sshStatus:     sshStatusList.Items,
+		dhcpLeases:    leaseList.Items,
+		bindingIps:    bindingIpList.Items,
+	}, nil
+}
+
+// linkAll resolves all the records into the hosts, and updates the hosts
+func (r *HostController) linkAll(ctx context.Context) error {
+	rec, err := r.listRecords(ctx)
+	if err != nil {
+		return err
+	}
+	hostList := &topohubv1beta1.HostList{}
+	if err := r.client.List(ctx, hostList); err != nil {
+		return fmt.Errorf("failed to list hosts: %v", err)
+	}
+	existing := map[string]*topohubv1beta1.Host{}
+	for i := range hostList.Items {
+		existing[hostList.Items[i].Name] = hostList.Items[i].DeepCopy()
+	}
+
+	result := link(hostList.Items, rec)
+	var errs []string
+	for _, h := range result.hosts {
+		if err := r.updateHost(ctx, existing[h.Name], h, result.specChanged[h.Name]); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// updateHost creates the new host or updates the identity, and then updates the status when the links change
+func (r *HostController) updateHost(ctx context.Context, old, desired *topohubv1beta1.Host, specChanged bool) error {
+	status := desired.Status
+	current := old
+	switch {
+	case old == nil:
+		created := &topohubv1beta1.Host{}
+		created.Name = desired.Name
+		created.Spec = desired.Spec
+		if err := r.client.Create(ctx, created); err != nil && !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create host %s: %v", desired.Name, err)
+		} else if err != nil {
+			// the host is updated with the watch event
+			return nil
+		}
+		r.log.Infof("created host %s, uuid %q, serial number %q", desired.Name, desired.Spec.SystemUUID, desired.Spec.SerialNumber)
+		current = created
+	case specChanged:
+		updated := old.DeepCopy()
+		updated.Spec = desired.Spec
+		if err := r.client.Update(ctx, updated); err != nil {
+			return fmt.Errorf("failed to update host %s: %v", desired.Name, err)
+		}
+		r.log.Infof("updated the identity of host %s, uuid %q, serial number %q", desired.Name, desired.Spec.SystemUUID, desired.Spec.SerialNumber)
+		current = updated
+	}
+
+	if reflect.DeepEqual(current.Status, status) {
+		return nil
+	}
+	updated := current.DeepCopy()
+	updated.Status = status
+	updated.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	if err := r.client.Status().Update(ctx, updated); err != nil {
+		return fmt.Errorf("failed to update status of host %s: %v", desired.Name, err)
+	}
+	if old != nil && old.Status.BMC != nil && (status.BMC == nil || status.BMC.IpAddr != old.Status.BMC.IpAddr) {
+		r.log.Infof("the BMC IP of host %s changes from %s", desired.Name, old.Status.BMC.IpAddr)
+	}
+	r.log.Debugf("updated status of host %s", desired.Name)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *HostController) SetupWithManager(mgr ctrl.Manager) error {
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{linkRequest}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("host").
+		// the status updates of the hosts are ignored
+		Watches(&topohubv1beta1.Host{}, enqueue, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&topohubv1beta1.RedfishStatus{}, enqueue).
+		Watches(&topohubv1beta1.SSHStatus{}, enqueue).
+		Watches(&topohubv1beta1.DhcpLease{}, enqueue).
+		Watches(&topohubv1beta1.BindingIp{}, enqueue).
+		Complete(r)
+}
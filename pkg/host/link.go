@@ -0,0 +1,270 @@
+package host
+
+import (
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+// the max number of the previous BMC IPs recorded in the status of the host
+const maxPreviousBmcIPs = 10
+
+// identity is the system uuid and the serial number of a RedfishStatus or SSHStatus
+type identity struct {
+	uuid   string
+	serial string
+}
+
+func redfishIdentity(item *topohubv1beta1.RedfishStatus) identity {
+	serial := tools.NormalizeSerialNumber(item.Status.Info["SystemSerialNumber"])
+	if serial == "" {
+		serial = tools.NormalizeSerialNumber(item.Status.Info["ChassisSerialNumber"])
+	}
+	return identity{uuid: tools.NormalizeSystemUUID(item.Status.Info["SystemUUID"]), serial: serial}
+}
+
+func sshIdentity(item *topohubv1beta1.SSHStatus) identity {
+	if item.Status.Inventory == nil {
+		return identity{}
+	}
+	return identity{
+		uuid:   tools.NormalizeSystemUUID(item.Status.Inventory.SystemUUID),
+		serial: tools.NormalizeSerialNumber(item.Status.Inventory.SystemSerial),
+	}
+}
+
+func (id identity) empty() bool {
+	return id.uuid == "" && id.serial == ""
+}
+
+// matches checks whether the record belongs to the host, the uuid takes precedence over the serial number
+// because the systems in the same chassis may share the chassis serial number
+func (id identity) matches(spec topohubv1beta1.HostSpec) bool {
+	if id.uuid != "" && spec.SystemUUID != "" {
+		return id.uuid == tools.NormalizeSystemUUID(spec.SystemUUID)
+	}
+	return id.serial != "" && strings.EqualFold(id.serial, spec.SerialNumber)
+}
+
+// hostName returns the name of the host, which is the uuid, or the serial number prefixed by sn-
+func hostName(id identity) string {
+	if id.uuid != "" && len(validation.IsDNS1123Subdomain(id.uuid)) == 0 {
+		return id.uuid
+	}
+	if id.serial == "" {
+		return ""
+	}
+	return tools.SanitizeObjectName("sn-" + id.serial)
+}
+
+// records are the objects linked to the hosts
+type records struct {
+	redfishStatus []topohubv1beta1.RedfishStatus
+	sshStatus     []topohubv1beta1.SSHStatus
+	dhcpLeases    []topohubv1beta1.DhcpLease
+	bindingIps    []topohubv1beta1.BindingIp
+}
+
+// linkResult is the hosts with the updated spec and status
+type linkResult struct {
+	hosts []*topohubv1beta1.Host
+	// created are the names of the new hosts
+	created map[string]bool
+	// specChanged are the names of the existing hosts whose identity is completed by the records
+	specChanged map[string]bool
+}
+
+// linker resolves the records into the hosts
+type linker struct {
+	linkResult
+	redfish map[string][]*topohubv1beta1.RedfishStatus
+	ssh     map[string][]*topohubv1beta1.SSHStatus
+}
+
+// resolve returns the host of the identity, the host is created when it does not exist
+func (l *linker) resolve(id identity) *topohubv1beta1.Host {
+	if id.empty() {
+		return nil
+	}
+	var found *topohubv1beta1.Host
+	for _, h := range l.hosts {
+		if id.matches(h.Spec) {
+			found = h
+			break
+		}
+	}
+	if found == nil {
+		name := hostName(id)
+		if name == "" {
+			return nil
+		}
+		for _, h := range l.hosts {
+			if h.Name == name {
+				// the name is taken by a host of another identity
+				return nil
+			}
+		}
+		found = &topohubv1beta1.Host{}
+		found.Name = name
+		l.hosts = append(l.hosts, found)
+		l.created[name] = true
+	}
+	changed := false
+	if found.Spec.SystemUUID == "" && id.uuid != "" {
+		found.Spec.SystemUUID = id.uuid
+		changed = true
+	}
+	if found.Spec.SerialNumber == "" && id.serial != "" {
+		found.Spec.SerialNumber = id.serial
+		changed = true
+	}
+	if changed && !l.created[found.Name] {
+		l.specChanged[found.Name] = true
+	}
+	return found
+}
+
+// link resolves the RedfishStatus and SSHStatus into the hosts by the identity, and links the DhcpLeases and
+// BindingIps by the mac addresses of the BMC and the nics. The hosts are never deleted, so the identity and
+// the previous BMC IPs are kept when the BMC IP changes
+func link(hosts []topohubv1beta1.Host, rec *records) linkResult {
+	l := &linker{
+		linkResult: linkResult{created: map[string]bool{}, specChanged: map[string]bool{}},
+		redfish:    map[string][]*topohubv1beta1.RedfishStatus{},
+		ssh:        map[string][]*topohubv1beta1.SSHStatus{},
+	}
+	sort.Slice(hosts, func(i, j int) bool { return hosts[i].Name < hosts[j].Name })
+	for i := range hosts {
+		l.hosts = append(l.hosts, hosts[i].DeepCopy())
+	}
+
+	for i := range rec.redfishStatus {
+		item := &rec.redfishStatus[i]
+		if h := l.resolve(redfishIdentity(item)); h != nil {
+			l.redfish[h.Name] = append(l.redfish[h.Name], item)
+		}
+	}
+	for i := range rec.sshStatus {
+		item := &rec.sshStatus[i]
+		if h := l.resolve(sshIdentity(item)); h != nil {
+			l.ssh[h.Name] = append(l.ssh[h.Name], item)
+		}
+	}
+
+	for _, h := range l.hosts {
+		h.Status = buildStatus(h.Status, latestRedfishStatus(l.redfish[h.Name]), latestSSHStatus(l.ssh[h.Name]), rec)
+	}
+	return l.linkResult
+}
+
+// preferred compares the records of the same host, the healthy and latest one is preferred, such as the
+// RedfishStatus of the new BMC IP
+func preferred(healthyA, healthyB bool, timeA, timeB, nameA, nameB string) bool {
+	if healthyA != healthyB {
+		return healthyA
+	}
+	if timeA != timeB {
+		return timeA > timeB
+	}
+	return nameA < nameB
+}
+
+func latestRedfishStatus(items []*topohubv1beta1.RedfishStatus) *topohubv1beta1.RedfishStatus {
+	var result *topohubv1beta1.RedfishStatus
+	for _, item := range items {
+		if result == nil || preferred(item.Status.Healthy, result.Status.Healthy, item.Status.LastUpdateTime, result.Status.LastUpdateTime, item.Name, result.Name) {
+			result = item
+		}
+	}
+	return result
+}
+
+func latestSSHStatus(items []*topohubv1beta1.SSHStatus) *topohubv1beta1.SSHStatus {
+	var result *topohubv1beta1.SSHStatus
+	for _, item := range items {
+		if result == nil || preferred(item.Status.Healthy, result.Status.Healthy, item.Status.LastUpdateTime, result.Status.LastUpdateTime, item.Name, result.Name) {
+			result = item
+		}
+	}
+	return result
+}
+
+// buildStatus builds the links of the host, the LastUpdateTime is kept
+func buildStatus(old topohubv1beta1.HostStatus, bmc *topohubv1beta1.RedfishStatus, ssh *topohubv1beta1.SSHStatus, rec *records) topohubv1beta1.HostStatus {
+	status := topohubv1beta1.HostStatus{LastUpdateTime: old.LastUpdateTime}
+	macs := map[string]bool{}
+	if ssh != nil {
+		status.ClusterName = ssh.Status.Basic.ClusterName
+		status.SSH = &topohubv1beta1.HostRecordRef{Name: ssh.Name, IpAddr: ssh.Status.Basic.IpAddr}
+		if ssh.Status.Inventory != nil {
+			for _, nic := range ssh.Status.Inventory.Nics {
+				if nic.MacAddr != "" {
+					macs[strings.ToLower(nic.MacAddr)] = true
+				}
+			}
+		}
+	}
+	if bmc != nil {
+		if bmc.Status.Basic.ClusterName != "" {
+			status.ClusterName = bmc.Status.Basic.ClusterName
+		}
+		status.BMC = &topohubv1beta1.HostRecordRef{Name: bmc.Name, IpAddr: bmc.Status.Basic.IpAddr, MacAddr: bmc.Status.Basic.Mac}
+		if bmc.Status.Basic.Mac != "" {
+			macs[strings.ToLower(bmc.Status.Basic.Mac)] = true
+		}
+	}
+
+	for _, lease := range rec.dhcpLeases {
+		if macs[strings.ToLower(lease.Spec.MacAddr)] {
+			status.DhcpLeases = append(status.DhcpLeases, topohubv1beta1.HostAddressRef{
+				Name: lease.Name, Subnet: lease.Spec.Subnet, IpAddr: lease.Spec.IpAddr, MacAddr: lease.Spec.MacAddr,
+			})
+		}
+	}
+	for _, binding := range rec.bindingIps {
+		if macs[strings.ToLower(binding.Spec.MacAddr)] {
+			status.BindingIps = append(status.BindingIps, topohubv1beta1.HostAddressRef{
+				Name: binding.Name, Subnet: binding.Spec.Subnet, IpAddr: binding.Spec.IpAddr, MacAddr: binding.Spec.MacAddr,
+			})
+		}
+	}
+	sort.Slice(status.DhcpLeases, func(i, j int) bool { return status.DhcpLeases[i].Name < status.DhcpLeases[j].Name })
+	sort.Slice(status.BindingIps, func(i, j int) bool { return status.BindingIps[i].Name < status.BindingIps[j].Name })
+
+	// the cluster name of the host is kept when its records are deleted
+	if status.ClusterName == "" {
+		status.ClusterName = old.ClusterName
+	}
+
+	currentIP := ""
+	if status.BMC != nil {
+		currentIP = status.BMC.IpAddr
+	}
+	var previous []string
+	seen := map[string]bool{currentIP: true}
+	if old.BMC != nil {
+		previous = append(previous, old.BMC.IpAddr)
+	}
+	previous = append(previous, old.PreviousBmcIPs...)
+	n := 0
+	for _, ip := range previous {
+		if !seen[ip] {
+			seen[ip] = true
+			previous[n] = ip
+			n++
+		}
+	}
+	previous = previous[:n]
+	if len(previous) == 0 {
+		previous = nil
+	}
+	if len(previous) > maxPreviousBmcIPs {
+		previous = previous[:maxPreviousBmcIPs]
+	}
+	status.PreviousBmcIPs = previous
+	return status
+}
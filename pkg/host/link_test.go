@@ -0,0 +1,98 @@
+package host
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+// TestHostName tests the names of the hosts are derived from the uuid or the serial number
+func TestHostName(t *testing.T) {
+	cases := []struct {
+		id     identity
+		expect string
+	}{
+		{identity{uuid: tools.NormalizeSystemUUID("4C4C4544-0042-3610-8057-B4C04F4B4D32"), serial: "CN7016"}, "4c4c4544-0042-3610-8057-b4c04f4b4d32"},
+		{identity{uuid: tools.NormalizeSystemUUID("00000000-0000-0000-0000-000000000000"), serial: "CN70_16 "}, "sn-cn70-16"},
+		{identity{serial: tools.NormalizeSerialNumber("To Be Filled By O.E.M.")}, ""},
+	}
+	for _, c := range cases {
+		if got := hostName(c.id); got != c.expect {
+			t.Errorf("hostName(%+v) = %q, expected %q", c.id, got, c.expect)
+		}
+	}
+}
+
+// TestLink tests the records of the same server are linked to one host, which is kept when the BMC IP changes
+func TestLink(t *testing.T) {
+	bmc := topohubv1beta1.RedfishStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "10-0-0-10"},
+		Status: topohubv1beta1.RedfishStatusStatus{
+			Healthy:        true,
+			LastUpdateTime: "2025-01-01T00:00:00Z",
+			Basic:          topohubv1beta1.BasicInfo{ClusterName: "gpu", IpAddr: "10.0.0.10", Mac: "B8:3F:D2:00:00:0A"},
+			Info:           map[string]string{"SystemUUID": "4C4C4544-0042-3610-8057-B4C04F4B4D32", "SystemSerialNumber": "Not Specified", "ChassisSerialNumber": "CN7016"},
+		},
+	}
+	ssh := topohubv1beta1.SSHStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: topohubv1beta1.SSHStatusStatus{
+			Basic: topohubv1beta1.SSHBasicInfo{IpAddr: "192.168.0.10"},
+			Inventory: &topohubv1beta1.SSHInventory{
+				SystemSerial: "cn7016",
+				Nics:         []topohubv1beta1.SSHNicInfo{{Name: "eth0", MacAddr: "b8:3f:d2:00:00:01"}},
+			},
+		},
+	}
+	rec := &records{
+		redfishStatus: []topohubv1beta1.RedfishStatus{bmc},
+		sshStatus:     []topohubv1beta1.SSHStatus{ssh},
+		dhcpLeases: []topohubv1beta1.DhcpLease{
+			{ObjectMeta: metav1.ObjectMeta{Name: "lease-bmc"}, Spec: topohubv1beta1.DhcpLeaseSpec{Subnet: "bmc", IpAddr: "10.0.0.10", MacAddr: "b8:3f:d2:00:00:0a"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "lease-other"}, Spec: topohubv1beta1.DhcpLeaseSpec{Subnet: "bmc", IpAddr: "10.0.0.11", MacAddr: "b8:3f:d2:00:00:0b"}},
+		},
+		bindingIps: []topohubv1beta1.BindingIp{
+			{ObjectMeta: metav1.ObjectMeta{Name: "node1"}, Spec: topohubv1beta1.BindingIpSpec{Subnet: "pxe", IpAddr: "192.168.0.10", MacAddr: "B8:3F:D2:00:00:01"}},
+		},
+	}
+
+	result := link(nil, rec)
+	if len(result.hosts) != 1 || !result.created["4c4c4544-0042-3610-8057-b4c04f4b4d32"] {
+		t.Fatalf("expected one new host, got %+v", result.hosts)
+	}
+	h := result.hosts[0]
+	if h.Spec.SerialNumber != "CN7016" || h.Status.ClusterName != "gpu" || h.Status.SSH == nil || h.Status.SSH.Name != "node1" {
+		t.Errorf("unexpected host: %+v", h)
+	}
+	if len(h.Status.DhcpLeases) != 1 || h.Status.DhcpLeases[0].Name != "lease-bmc" || len(h.Status.BindingIps) != 1 {
+		t.Errorf("unexpected leases and bindings: %+v %+v", h.Status.DhcpLeases, h.Status.BindingIps)
+	}
+
+	// the BMC gets a new IP, and the RedfishStatus of the old IP becomes unhealthy
+	moved := bmc.DeepCopy()
+	moved.Name = "10-0-0-20"
+	moved.Status.Basic.IpAddr = "10.0.0.20"
+	moved.Status.LastUpdateTime = "2025-01-02T00:00:00Z"
+	rec.redfishStatus[0].Status.Healthy = false
+	rec.redfishStatus = append(rec.redfishStatus, *moved)
+	result = link([]topohubv1beta1.Host{*h}, rec)
+	if len(result.hosts) != 1 || len(result.created) != 0 || len(result.specChanged) != 0 {
+		t.Fatalf("expected the host to be kept, got %+v", result)
+	}
+	h = result.hosts[0]
+	if h.Status.BMC == nil || h.Status.BMC.Name != "10-0-0-20" || !reflect.DeepEqual(h.Status.PreviousBmcIPs, []string{"10.0.0.10"}) {
+		t.Errorf("unexpected BMC: %+v, previous %v", h.Status.BMC, h.Status.PreviousBmcIPs)
+	}
+
+	// the previous IPs are not duplicated when the BMC returns to an old IP
+	rec.redfishStatus = rec.redfishStatus[:1]
+	rec.redfishStatus[0].Status.Healthy = true
+	h = link([]topohubv1beta1.Host{*h}, rec).hosts[0]
+	if !reflect.DeepEqual(h.Status.PreviousBmcIPs, []string{"10.0.0.20"}) {
+		t.Errorf("unexpected previous BMC IPs: %v", h.Status.PreviousBmcIPs)
+	}
+}
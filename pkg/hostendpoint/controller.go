@@ -2,6 +2,7 @@ package hostendpoint
 
 import (
 	"context"
+	"reflect"
 	"time"
 
 	"go.uber.org/zap"
@@ -77,6 +78,8 @@ func (r *HostEndpointReconciler) handleHostEndpoint(ctx context.Context, hostEnd
 	switch endpointType {
 	case topohubv1beta1.EndpointTypeSSH:
 		return r.handleSSHEndpoint(ctx, hostEndpoint, logger)
+	case topohubv1beta1.EndpointTypeSwitch:
+		return r.handleSwitchEndpoint(ctx, hostEndpoint, logger)
 	case topohubv1beta1.EndpointTypeRedfish:
 		return r.handleRedfishEndpoint(ctx, hostEndpoint, logger)
 	default:
@@ -325,6 +328,10 @@ func (r *HostEndpointReconciler) handleSSHEndpoint(ctx context.Context, hostEndp
 		if hostEndpoint.Spec.ClusterName != nil {
 			updated.Status.Basic.ClusterName = *hostEndpoint.Spec.ClusterName
 		}
+		if hostEndpoint.Spec.SSHHostKey != nil {
+			updated.Status.Basic.SSHHostKey = *hostEndpoint.Spec.SSHHostKey
+		}
+		updated.Status.Basic.JumpHost = hostEndpoint.Spec.SSHJumpHost.DeepCopy()
 
 		// Output detailed information before update
 		logger.Debugf("Updating SSHStatus with details - IP: %s, Secret: %s/%s, Port: %d, ClusterName: %s",
@@ -360,10 +367,7 @@ func (r *HostEndpointReconciler) handleSSHEndpoint(ctx context.Context, hostEndp
 	sshStatus := &topohubv1beta1.SSHStatus{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: name,
-			Labels: map[string]string{
-				topohubv1beta1.LabelIPAddr:     hostEndpoint.Spec.IPAddr,
-				topohubv1beta1.LabelClientMode: topohubv1beta1.HostTypeSSH,
-			},
+			Labels: sshStatusLabels(hostEndpoint),
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion:         topohubv1beta1.APIVersion,
@@ -385,6 +389,99 @@ func (r *HostEndpointReconciler) handleSSHEndpoint(ctx context.Context, hostEndp
 	return nil
 }
 
+// sshStatusLabels returns the labels of the SSHStatus, the redfishstatus of the same host is linked by the label
+func sshStatusLabels(hostEndpoint *topohubv1beta1.HostEndpoint) map[string]string {
+	labels := map[string]string{
+		topohubv1beta1.LabelIPAddr:     hostEndpoint.Spec.IPAddr,
+		topohubv1beta1.LabelClientMode: topohubv1beta1.HostTypeSSH,
+	}
+	if name, ok := hostEndpoint.Labels[topohubv1beta1.LabelRedfishStatus]; ok && name != "" {
+		labels[topohubv1beta1.LabelRedfishStatus] = name
+	}
+	return labels
+}
+
+// Handle switch type HostEndpoint
+// 根据 HostEndpoint ，同步更新对应的 SwitchStatus
+func (r *HostEndpointReconciler) handleSwitchEndpoint(ctx context.Context, hostEndpoint *topohubv1beta1.HostEndpoint, logger *zap.SugaredLogger) error {
+	name := hostEndpoint.Name
+	logger.Debugf("Processing switch HostEndpoint %s (IP: %s)", name, hostEndpoint.Spec.IPAddr)
+
+	basic := topohubv1beta1.SSHBasicInfo{
+		Type:   topohubv1beta1.HostTypeEndpoint,
+		IpAddr: hostEndpoint.Spec.IPAddr,
+	}
+	if hostEndpoint.Spec.Port != nil {
+		basic.Port = *hostEndpoint.Spec.Port
+	}
+	if hostEndpoint.Spec.SecretName != nil {
+		basic.SecretName = *hostEndpoint.Spec.SecretName
+	}
+	if hostEndpoint.Spec.SecretNamespace != nil {
+		basic.SecretNamespace = *hostEndpoint.Spec.SecretNamespace
+	}
+	if hostEndpoint.Spec.ClusterName != nil {
+		basic.ClusterName = *hostEndpoint.Spec.ClusterName
+	}
+	if hostEndpoint.Spec.SSHHostKey != nil {
+		basic.SSHHostKey = *hostEndpoint.Spec.SSHHostKey
+	}
+	basic.JumpHost = hostEndpoint.Spec.SSHJumpHost.DeepCopy()
+
+	existing := &topohubv1beta1.SwitchStatus{}
+	err := r.client.Get(ctx, client.ObjectKey{Name: name}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Errorf("Failed to get SwitchStatus %s: %v", name, err)
+		return err
+	}
+
+	if errors.IsNotFound(err) {
+		// the status is set after the creation, the same as the RedfishStatus
+		switchStatus := &topohubv1beta1.SwitchStatus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					topohubv1beta1.LabelIPAddr:     hostEndpoint.Spec.IPAddr,
+					topohubv1beta1.LabelClientMode: topohubv1beta1.HostTypeSwitch,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion:         topohubv1beta1.APIVersion,
+						Kind:               topohubv1beta1.KindHostEndpoint,
+						Name:               hostEndpoint.Name,
+						UID:                hostEndpoint.UID,
+						Controller:         &[]bool{true}[0],
+						BlockOwnerDeletion: &[]bool{true}[0],
+					},
+				},
+			},
+		}
+		logger.Debugf("Creating new SwitchStatus %s", name)
+		if err := r.client.Create(ctx, switchStatus); err != nil {
+			logger.Errorf("Failed to create SwitchStatus %s: %v", name, err)
+			return err
+		}
+		existing = switchStatus
+	} else if specEqualSSH(existing.Status.Basic, hostEndpoint.Spec) {
+		logger.Debugf("SwitchStatus %s exists with same spec, no update needed", name)
+		return nil
+	}
+
+	updated := existing.DeepCopy()
+	updated.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	updated.Status.Basic = basic
+	if err := r.client.Status().Update(ctx, updated); err != nil {
+		if errors.IsConflict(err) {
+			logger.Debugf("Conflict updating SwitchStatus %s, will retry", name)
+			return err
+		}
+		logger.Errorf("Failed to update SwitchStatus %s: %v", name, err)
+		return err
+	}
+	logger.Infof("Successfully updated SwitchStatus %s", name)
+	return nil
+}
+
 // Check if the SSH status basic info matches the host endpoint spec
 func specEqualSSH(basic topohubv1beta1.SSHBasicInfo, spec topohubv1beta1.HostEndpointSpec) bool {
 	if basic.IpAddr != spec.IPAddr {
@@ -406,6 +503,20 @@ func specEqualSSH(basic topohubv1beta1.SSHBasicInfo, spec topohubv1beta1.HostEnd
 		return false
 	}
 
+	// Check host key
+	hostKey := ""
+	if spec.SSHHostKey != nil {
+		hostKey = *spec.SSHHostKey
+	}
+	if basic.SSHHostKey != hostKey {
+		return false
+	}
+
+	// Check jump host
+	if !reflect.DeepEqual(basic.JumpHost, spec.SSHJumpHost) {
+		return false
+	}
+
 	// Check cluster name
 	clusterName := ""
 	if spec.ClusterName != nil {
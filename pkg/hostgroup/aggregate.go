@@ -0,0 +1,185 @@
+package hostgroup
+
+import (
+	"sort"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// the power state of the redfish hosts which have not reported it
+const powerStateUnknown = "Unknown"
+
+// inventory is the objects which the HostGroups are summarized from
+type inventory struct {
+	redfishStatus []topohubv1beta1.RedfishStatus
+	sshStatus     []topohubv1beta1.SSHStatus
+	switchStatus  []topohubv1beta1.SwitchStatus
+	subnets       []topohubv1beta1.Subnet
+}
+
+// clusterName returns the cluster name summarized by the HostGroup
+func clusterName(group *topohubv1beta1.HostGroup) string {
+	if group.Spec.ClusterName != "" {
+		return group.Spec.ClusterName
+	}
+	return group.Name
+}
+
+// clusterNames returns the cluster names of all the hosts and switches
+func (inv *inventory) clusterNames() []string {
+	names := map[string]bool{}
+	for i := range inv.redfishStatus {
+		names[inv.redfishStatus[i].Status.Basic.ClusterName] = true
+	}
+	for i := range inv.sshStatus {
+		names[inv.sshStatus[i].Status.Basic.ClusterName] = true
+	}
+	for i := range inv.switchStatus {
+		names[inv.switchStatus[i].Status.Basic.ClusterName] = true
+	}
+	delete(names, "")
+
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// subnetClusterNames returns the default cluster names of the subnet for the dhcp clients and the discovered hosts
+func subnetClusterNames(subnet *topohubv1beta1.Subnet) []string {
+	var result []string
+	if name, ok := subnet.Labels[topohubv1beta1.LabelClusterName]; ok && name != "" {
+		result = append(result, name)
+	}
+	if subnet.Spec.Feature != nil && subnet.Spec.Feature.Discovery != nil && subnet.Spec.Feature.Discovery.DefaultClusterName != nil {
+		result = append(result, *subnet.Spec.Feature.Discovery.DefaultClusterName)
+	}
+	return result
+}
+
+// firmwareCounter counts the hosts by the firmware versions, a host is counted once for each version
+type firmwareCounter map[topohubv1beta1.HostGroupFirmwareVersion]int32
+
+func (c firmwareCounter) add(component string, versions ...string) {
+	seen := map[string]bool{}
+	for _, v := range versions {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		c[topohubv1beta1.HostGroupFirmwareVersion{Component: component, Version: v}]++
+	}
+}
+
+func (c firmwareCounter) list() []topohubv1beta1.HostGroupFirmwareVersion {
+	result := make([]topohubv1beta1.HostGroupFirmwareVersion, 0, len(c))
+	for key, amount := range c {
+		key.Amount = amount
+		result = append(result, key)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Component != result[j].Component {
+			return result[i].Component < result[j].Component
+		}
+		return result[i].Version < result[j].Version
+	})
+	return result
+}
+
+// aggregate summarizes the hosts, switches and subnets of the cluster, the LastUpdateTime is left empty
+func aggregate(cluster string, inv *inventory) topohubv1beta1.HostGroupStatus {
+	status := topohubv1beta1.HostGroupStatus{}
+	subnets := map[string]bool{}
+	addSubnet := func(name *string) {
+		if name != nil && *name != "" {
+			subnets[*name] = true
+		}
+	}
+	countHealthy := func(healthy bool) {
+		status.HostAmount++
+		if healthy {
+			status.HealthyAmount++
+		} else {
+			status.UnhealthyAmount++
+		}
+	}
+	firmwares := firmwareCounter{}
+
+	for i := range inv.redfishStatus {
+		item := &inv.redfishStatus[i]
+		if item.Status.Basic.ClusterName != cluster {
+			continue
+		}
+		status.RedfishHostAmount++
+		countHealthy(item.Status.Healthy)
+		status.WarningLogAmount += item.Status.Log.WarningLogAccount
+		addSubnet(item.Status.Basic.SubnetName)
+
+		powerState := item.Status.Info["PowerState"]
+		if powerState == "" {
+			powerState = powerStateUnknown
+		}
+		if status.PowerStates == nil {
+			status.PowerStates = map[string]int32{}
+		}
+		status.PowerStates[powerState]++
+
+		firmwares.add(topohubv1beta1.FirmwareComponentBIOS, item.Status.Info["BiosVerison"])
+		firmwares.add(topohubv1beta1.FirmwareComponentBMC, item.Status.Info["BmcFirmwareVersion"])
+	}
+
+	for i := range inv.sshStatus {
+		item := &inv.sshStatus[i]
+		if item.Status.Basic.ClusterName != cluster {
+			continue
+		}
+		status.SSHHostAmount++
+		countHealthy(item.Status.Healthy)
+		status.WarningLogAmount += item.Status.Log.WarningLogAccount
+		addSubnet(item.Status.Basic.SubnetName)
+
+		if item.Status.Inventory != nil {
+			nics := map[string][]string{}
+			for _, nic := range item.Status.Inventory.Nics {
+				if nic.Driver != "" {
+					nics[nic.Driver] = append(nics[nic.Driver], nic.Firmware)
+				}
+			}
+			for driver, versions := range nics {
+				firmwares.add(topohubv1beta1.FirmwareComponentNIC+"/"+driver, versions...)
+			}
+		}
+	}
+
+	for i := range inv.switchStatus {
+		item := &inv.switchStatus[i]
+		if item.Status.Basic.ClusterName != cluster {
+			continue
+		}
+		status.SwitchAmount++
+		if item.Status.Healthy {
+			status.HealthySwitchAmount++
+		}
+		addSubnet(item.Status.Basic.SubnetName)
+	}
+
+	for i := range inv.subnets {
+		for _, name := range subnetClusterNames(&inv.subnets[i]) {
+			if name == cluster {
+				subnets[inv.subnets[i].Name] = true
+			}
+		}
+	}
+
+	status.FirmwareVersions = firmwares.list()
+	if len(status.FirmwareVersions) == 0 {
+		status.FirmwareVersions = nil
+	}
+	for name := range subnets {
+		status.Subnets = append(status.Subnets, name)
+	}
+	sort.Strings(status.Subnets)
+	return status
+}
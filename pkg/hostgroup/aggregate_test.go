@@ -0,0 +1,97 @@
+package hostgroup
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestAggregate tests the hosts, switches and subnets are summarized by the cluster name
+func TestAggregate(t *testing.T) {
+	subnetA, subnetB := "subnet-a", "subnet-b"
+	defaultName := "gpu"
+	inv := &inventory{
+		redfishStatus: []topohubv1beta1.RedfishStatus{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "bmc1"},
+				Status: topohubv1beta1.RedfishStatusStatus{
+					Healthy: true,
+					Basic:   topohubv1beta1.BasicInfo{ClusterName: "gpu", SubnetName: &subnetA},
+					Info:    map[string]string{"PowerState": "On", "BiosVerison": "2.1", "BmcFirmwareVersion": "5.0"},
+					Log:     topohubv1beta1.LogStruct{WarningLogAccount: 2},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "bmc2"},
+				Status: topohubv1beta1.RedfishStatusStatus{
+					Basic: topohubv1beta1.BasicInfo{ClusterName: "gpu"},
+					Info:  map[string]string{"BiosVerison": "2.1"},
+					Log:   topohubv1beta1.LogStruct{WarningLogAccount: 1},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "bmc3"},
+				Status:     topohubv1beta1.RedfishStatusStatus{Basic: topohubv1beta1.BasicInfo{ClusterName: "storage"}},
+			},
+		},
+		sshStatus: []topohubv1beta1.SSHStatus{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "host1"},
+				Status: topohubv1beta1.SSHStatusStatus{
+					Healthy: true,
+					Basic:   topohubv1beta1.SSHBasicInfo{ClusterName: "gpu"},
+					Inventory: &topohubv1beta1.SSHInventory{
+						Nics: []topohubv1beta1.SSHNicInfo{
+							{Name: "eth0", Driver: "mlx5_core", Firmware: "28.39"},
+							{Name: "eth1", Driver: "mlx5_core", Firmware: "28.39"},
+						},
+					},
+				},
+			},
+		},
+		switchStatus: []topohubv1beta1.SwitchStatus{
+			{Status: topohubv1beta1.SwitchStatusStatus{Healthy: true, Basic: topohubv1beta1.SSHBasicInfo{ClusterName: "gpu", SubnetName: &subnetB}}},
+		},
+		subnets: []topohubv1beta1.Subnet{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "subnet-c"},
+				Spec: topohubv1beta1.SubnetSpec{Feature: &topohubv1beta1.FeatureSpec{
+					Discovery: &topohubv1beta1.DiscoverySpec{DefaultClusterName: &defaultName},
+				}},
+			},
+		},
+	}
+
+	if names := inv.clusterNames(); !reflect.DeepEqual(names, []string{"gpu", "storage"}) {
+		t.Errorf("unexpected cluster names: %v", names)
+	}
+
+	status := aggregate("gpu", inv)
+	expected := topohubv1beta1.HostGroupStatus{
+		HostAmount:          3,
+		RedfishHostAmount:   2,
+		SSHHostAmount:       1,
+		HealthyAmount:       2,
+		UnhealthyAmount:     1,
+		SwitchAmount:        1,
+		HealthySwitchAmount: 1,
+		PowerStates:         map[string]int32{"On": 1, powerStateUnknown: 1},
+		WarningLogAmount:    3,
+		FirmwareVersions: []topohubv1beta1.HostGroupFirmwareVersion{
+			{Component: "BIOS", Version: "2.1", Amount: 2},
+			{Component: "BMC", Version: "5.0", Amount: 1},
+			{Component: "NIC/mlx5_core", Version: "28.39", Amount: 1},
+		},
+		Subnets: []string{"subnet-a", "subnet-b", "subnet-c"},
+	}
+	if !reflect.DeepEqual(status, expected) {
+		t.Errorf("unexpected status:\n%+v\nexpected:\n%+v", status, expected)
+	}
+
+	if status := aggregate("none", inv); status.HostAmount != 0 || status.PowerStates != nil || status.FirmwareVersions != nil {
+		t.Errorf("expected an empty summary, got %+v", status)
+	}
+}
@@ -0,0 +1,177 @@
+package hostgroup
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+)
+
+// all the HostGroups are summarized together, so the events are merged into a single request
+var summarizeRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "hostgroups"}}
+
+// HostGroupController creates a HostGroup for each cluster name found on the hosts, and summarizes the hosts,
+// switches and subnets of the cluster in its status
+type HostGroupController struct {
+	client client.Client
+	log    *zap.SugaredLogger
+}
+
+func NewHostGroupController(mgr ctrl.Manager) *HostGroupController {
+	return &HostGroupController{
+		client: mgr.GetClient(),
+		log:    log.Logger.Named("hostgroupReconcile"),
+	}
+}
+
+// 只有 leader 才会执行 Reconcile
+func (r *HostGroupController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if err := r.summarizeAll(ctx); err != nil {
+		r.log.Errorf("failed to summarize hostgroups: %v", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *HostGroupController) listInventory(ctx context.Context) (*inventory, error) {
+	redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+	if err := r.client.List(ctx, redfishStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list redfishstatus: %v", err)
+	}
+	sshStatusList := &topohubv1beta1.SSHStatusList{}
+	if err := r.client.List(ctx, sshStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list sshstatus: %v", err)
+	}
+	switchStatusList := &topohubv1beta1.SwitchStatusList{}
+	if err := r.client.List(ctx, switchStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list switchstatus: %v", err)
+	}
+	subnetList := &topohubv1beta1.SubnetList{}
+	if err := r.client.List(ctx, subnetList); err != nil {
+		return nil, fmt.Errorf("failed to list subnets: %v", err)
+	}
+	return &inventory{
+		redfishStatus: redfishStatusList.Items,
+		sshStatus:     sshStatusList.Items,
+		switchStatus:  switchStatusList.Items,
+		subnets:       subnetList.Items,
+	}, nil
+}
+
+// summarizeAll creates the HostGroups of the new cluster names, deletes the auto-created HostGroups without
+// any host, and updates the status of all the HostGroups
+func (r *HostGroupController) summarizeAll(ctx context.Context) error {
+	inv, err := r.listInventory(ctx)
+	if err != nil {
+		return err
+	}
+	groupList := &topohubv1beta1.HostGroupList{}
+	if err := r.client.List(ctx, groupList); err != nil {
+		return fmt.Errorf("failed to list hostgroups: %v", err)
+	}
+
+	existing := map[string]bool{}
+	covered := map[string]bool{}
+	for i := range groupList.Items {
+		existing[groupList.Items[i].Name] = true
+		covered[clusterName(&groupList.Items[i])] = true
+	}
+	clusters := map[string]bool{}
+	for _, name := range inv.clusterNames() {
+		clusters[name] = true
+		if covered[name] {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			r.log.Debugf("skip creating hostgroup for the cluster name %q: %s", name, strings.Join(errs, ", "))
+			continue
+		}
+		if existing[name] {
+			// the name is taken by a HostGroup of another cluster name
+			r.log.Warnf("skip creating hostgroup for the cluster name %q, the hostgroup %s exists for another cluster name", name, name)
+			continue
+		}
+		group := &topohubv1beta1.HostGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{topohubv1beta1.LabelHostGroupAutoCreated: "true"},
+			},
+			Spec: topohubv1beta1.HostGroupSpec{ClusterName: name},
+		}
+		if err := r.client.Create(ctx, group); err != nil && !errors.IsAlreadyExists(err) {
+			r.log.Errorf("failed to create hostgroup %s: %v", name, err)
+			continue
+		}
+		r.log.Infof("created hostgroup %s for the cluster", name)
+		// the status is updated with the watch event of the new HostGroup
+	}
+
+	var errs []string
+	for i := range groupList.Items {
+		group := &groupList.Items[i]
+		cluster := clusterName(group)
+		if group.Labels[topohubv1beta1.LabelHostGroupAutoCreated] == "true" && !clusters[cluster] {
+			if err := r.client.Delete(ctx, group); err != nil && !errors.IsNotFound(err) {
+				errs = append(errs, fmt.Sprintf("failed to delete hostgroup %s: %v", group.Name, err))
+				continue
+			}
+			r.log.Infof("deleted hostgroup %s, the cluster has no host", group.Name)
+			continue
+		}
+		if err := r.updateStatus(ctx, group, aggregate(cluster, inv)); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to update status of hostgroup %s: %v", group.Name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// updateStatus updates the summary of the HostGroup, the LastUpdateTime only changes with the summary
+func (r *HostGroupController) updateStatus(ctx context.Context, group *topohubv1beta1.HostGroup, status topohubv1beta1.HostGroupStatus) error {
+	status.LastUpdateTime = group.Status.LastUpdateTime
+	if reflect.DeepEqual(group.Status, status) {
+		return nil
+	}
+	updated := group.DeepCopy()
+	updated.Status = status
+	updated.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	if err := r.client.Status().Update(ctx, updated); err != nil {
+		return err
+	}
+	r.log.Debugf("updated status of hostgroup %s: %d hosts, %d unhealthy", group.Name, status.HostAmount, status.UnhealthyAmount)
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *HostGroupController) SetupWithManager(mgr ctrl.Manager) error {
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{summarizeRequest}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("hostgroup").
+		// the status updates of the HostGroups are ignored
+		Watches(&topohubv1beta1.HostGroup{}, enqueue, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&topohubv1beta1.RedfishStatus{}, enqueue).
+		Watches(&topohubv1beta1.SSHStatus{}, enqueue).
+		Watches(&topohubv1beta1.SwitchStatus{}, enqueue).
+		Watches(&topohubv1beta1.Subnet{}, enqueue).
+		Complete(r)
+}
@@ -7,32 +7,55 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 
 	"github.com/infrastructure-io/topohub/pkg/config"
 	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/lock"
 	"github.com/infrastructure-io/topohub/pkg/log"
+	"github.com/infrastructure-io/topohub/pkg/node"
+	"github.com/infrastructure-io/topohub/pkg/provision"
 	"github.com/infrastructure-io/topohub/pkg/redfish"
 	redfishstatusData "github.com/infrastructure-io/topohub/pkg/redfishstatus/data"
+	"github.com/infrastructure-io/topohub/pkg/tools"
 	"go.uber.org/zap"
 )
 
+const (
+	// the field index of the HostOperations by the key of their RedfishStatus or SSHStatus
+	hostOperationTargetIndex = "hostOperationTarget"
+
+	// the interval to retry the operation of the busy host
+	busyInterval = 5 * time.Second
+)
+
 // HostOperationController reconciles a HostOperation object
 type HostOperationController struct {
 	client.Client
 	Scheme      *runtime.Scheme
 	agentConfig *config.AgentConfig
 	log         *zap.SugaredLogger
+	// report the pxe reboot to track the provisioning
+	provisionEvents chan provision.Event
+	// drain the kubernetes nodes before the disruptive actions, it is nil when the node integration is disabled
+	drainer *node.Drainer
 }
 
-func NewHostOperationController(mgr ctrl.Manager, agentConfig *config.AgentConfig) (*HostOperationController, error) {
-	return &HostOperationController{
-		Client:      mgr.GetClient(),
-		Scheme:      mgr.GetScheme(),
-		agentConfig: agentConfig,
-		log:         log.Logger.Named("HostOperationController"),
-	}, nil
+func NewHostOperationController(mgr ctrl.Manager, kubeClient kubernetes.Interface, agentConfig *config.AgentConfig, provisionEvents chan provision.Event) (*HostOperationController, error) {
+	r := &HostOperationController{
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		agentConfig:     agentConfig,
+		log:             log.Logger.Named("HostOperationController"),
+		provisionEvents: provisionEvents,
+	}
+	if agentConfig.NodeIntegrationEnabled {
+		r.drainer = node.NewDrainer(kubeClient)
+	}
+	return r, nil
 }
 
 // 只有 leader 才会执行 Reconcile
@@ -51,6 +74,53 @@ func (r *HostOperationController) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	// the operations of the same host run one after another
+	if hostOp.Status.Status == "" || hostOp.Status.Status == topohubv1beta1.HostOperationStatusPending {
+		hostList := &topohubv1beta1.HostList{}
+		if err := r.List(ctx, hostList); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to list hosts: %v", err)
+		}
+		host := tools.FindHostOfOperation(hostList.Items, &hostOp.Spec)
+		key := tools.HostOperationLockKey(host, &hostOp.Spec)
+		// the reconcile does not wait for the busy host, so its operations do not occupy the workers of the other hosts
+		l := lock.LockManagerInstance.GetLock(key)
+		if !l.TryLock() {
+			logger.Debugf("%s is busy, HostOperation %s retries later", key, hostOp.Name)
+			return ctrl.Result{RequeueAfter: busyInterval}, nil
+		}
+		defer l.Unlock()
+		logger.Debugf("Locked %s for HostOperation %s", key, hostOp.Name)
+
+		// the lock is released between the reconciles of a drain, the draining and running operations are persisted
+		if other, err := r.activeOperation(ctx, hostOp, tools.HostTargets(host, &hostOp.Spec)); err != nil {
+			return ctrl.Result{}, err
+		} else if other != "" {
+			logger.Debugf("HostOperation %s waits for HostOperation %s of %s", hostOp.Name, other, key)
+			return ctrl.Result{RequeueAfter: busyInterval}, nil
+		}
+
+		// the node of the host is drained before the disruptive action
+		drained, err := r.drainNode(ctx, hostOp)
+		if err != nil {
+			logger.Errorf("Failed to operate %s%s: %v", hostOp.Spec.RedfishStatusName, hostOp.Spec.SSHStatusName, err)
+			hostOp.Status.Status = topohubv1beta1.HostOperationStatusFailed
+			hostOp.Status.Message = err.Error()
+			hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+			if err := r.Status().Update(ctx, hostOp); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to update HostOperation status: %v", err)
+			}
+			return ctrl.Result{}, nil
+		}
+		if !drained {
+			return ctrl.Result{RequeueAfter: drainInterval}, nil
+		}
+	}
+
+	// the ssh hosts are operated by the ssh
+	if hostOp.Spec.SSHStatusName != "" {
+		return r.reconcileSSH(ctx, hostOp)
+	}
+
 	// 获取关联的 RedfishStatus
 	redfishStatus := &topohubv1beta1.RedfishStatus{}
 	if err := r.Get(ctx, client.ObjectKey{Name: hostOp.Spec.RedfishStatusName}, redfishStatus); err != nil {
@@ -111,9 +181,18 @@ func (r *HostOperationController) Reconcile(ctx context.Context, req ctrl.Reques
 			logger.Errorf("Failed to operate %s: %v", hostOp.Spec.RedfishStatusName, err)
 			hostOp.Status.Status = topohubv1beta1.HostOperationStatusFailed
 			hostOp.Status.Message = err.Error()
+			r.releaseNode(ctx, hostOp)
 		} else {
 			logger.Infof("Succeeded to operate %s", hostOp.Spec.RedfishStatusName)
 			hostOp.Status.Status = topohubv1beta1.HostOperationStatusSuccess
+			if hostOp.Spec.Action == topohubv1beta1.BootCmdResetPxeOnce {
+				provision.SendEvent(r.provisionEvents, provision.Event{
+					Phase:             topohubv1beta1.ProvisionPhasePowerCycled,
+					RedfishStatusName: hostOp.Spec.RedfishStatusName,
+					HostOperation:     hostOp.Name,
+					Message:           "the host is rebooted from pxe by the bmc",
+				})
+			}
 		}
 
 		// 更新
@@ -131,9 +210,55 @@ func (r *HostOperationController) Reconcile(ctx context.Context, req ctrl.Reques
 	return ctrl.Result{}, nil
 }
 
+// activeOperation returns another operation of the targets which is running or draining the node. When two
+// operations are draining, such as after they start by the stale cache, the older one goes on
+func (r *HostOperationController) activeOperation(ctx context.Context, hostOp *topohubv1beta1.HostOperation, targets []string) (string, error) {
+	for _, target := range targets {
+		hostOpList := &topohubv1beta1.HostOperationList{}
+		if err := r.List(ctx, hostOpList, client.MatchingFields{hostOperationTargetIndex: target}); err != nil {
+			return "", fmt.Errorf("failed to list HostOperations of %s: %v", target, err)
+		}
+		for i := range hostOpList.Items {
+			item := &hostOpList.Items[i]
+			if item.Name == hostOp.Name {
+				continue
+			}
+			if item.Status.Status == topohubv1beta1.HostOperationStatusRunning {
+				return item.Name, nil
+			}
+			if item.Status.DrainStartTime == "" || !tools.IsUnfinishedOperation(item) {
+				continue
+			}
+			if hostOp.Status.DrainStartTime == "" || olderOperation(item, hostOp) {
+				return item.Name, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// olderOperation checks if the operation a is created before b
+func olderOperation(a, b *topohubv1beta1.HostOperation) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
+
 // SetupWithManager sets up the controller with the Manager
 func (r *HostOperationController) SetupWithManager(mgr ctrl.Manager) error {
+	// the operations are looked up by their targets rather than listing all of them
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &topohubv1beta1.HostOperation{}, hostOperationTargetIndex, func(obj client.Object) []string {
+		return []string{tools.HostOperationTarget(&obj.(*topohubv1beta1.HostOperation).Spec)}
+	}); err != nil {
+		return fmt.Errorf("failed to index HostOperations by the target: %v", err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&topohubv1beta1.HostOperation{}).
+		WithOptions(controller.Options{
+			// the operations of different hosts run in parallel
+			MaxConcurrentReconciles: 10,
+		}).
 		Complete(r)
 }
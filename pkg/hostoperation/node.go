@@ -0,0 +1,102 @@
+package hostoperation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+// drainInterval is the interval to check the pods of the draining node
+const drainInterval = 5 * time.Second
+
+// nodeName returns the kubernetes node of the host found by the node integration, or empty when it is not a node
+func (r *HostOperationController) nodeName(ctx context.Context, hostOp *topohubv1beta1.HostOperation) (string, error) {
+	name := hostOp.Spec.RedfishStatusName
+	if name == "" {
+		// the ssh host is mapped to the node by the RedfishStatus of its Host
+		hostList := &topohubv1beta1.HostList{}
+		if err := r.List(ctx, hostList); err != nil {
+			return "", fmt.Errorf("failed to list hosts: %v", err)
+		}
+		host := tools.FindHostOfOperation(hostList.Items, &hostOp.Spec)
+		if host == nil || host.Status.BMC == nil {
+			return "", nil
+		}
+		name = host.Status.BMC.Name
+	}
+
+	redfishStatus := &topohubv1beta1.RedfishStatus{}
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, redfishStatus); err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get RedfishStatus %s: %v", name, err)
+	}
+	return redfishStatus.Annotations[topohubv1beta1.AnnotationNodeName], nil
+}
+
+// drainNode cordons the node of the host and evicts its pods before the disruptive action. It does not wait for the
+// pods to be gone, but returns false so the next reconcile checks them again, until the drain timeout counted from
+// the start time in the status. The node is uncordoned by the node controller after it reboots and returns Ready
+func (r *HostOperationController) drainNode(ctx context.Context, hostOp *topohubv1beta1.HostOperation) (bool, error) {
+	if r.drainer == nil || !tools.IsDisruptiveAction(hostOp.Spec.Action) {
+		return true, nil
+	}
+	nodeName, err := r.nodeName(ctx, hostOp)
+	if err != nil || nodeName == "" {
+		return err == nil, err
+	}
+
+	if _, err := r.drainer.Cordon(ctx, nodeName, hostOp.Name); err != nil {
+		return false, fmt.Errorf("failed to cordon node %s: %v", nodeName, err)
+	}
+	if hostOp.Status.DrainStartTime == "" {
+		// the start time is persisted, so the timeout is kept after the controller restarts
+		now := time.Now().UTC().Format(time.RFC3339)
+		hostOp.Status.Status = topohubv1beta1.HostOperationStatusPending
+		hostOp.Status.DrainStartTime = now
+		hostOp.Status.LastUpdateTime = now
+		hostOp.Status.Message = fmt.Sprintf("draining node %s", nodeName)
+		if err := r.Status().Update(ctx, hostOp); err != nil {
+			// the drain starts again by the next reconcile
+			r.log.Warnf("Failed to update the drain start time of HostOperation %s: %v", hostOp.Name, err)
+			return false, nil
+		}
+	}
+
+	pending, err := r.drainer.Evict(ctx, nodeName)
+	if err != nil {
+		return false, fmt.Errorf("failed to drain node %s: %v", nodeName, err)
+	}
+	if len(pending) == 0 {
+		return true, nil
+	}
+	timeout := time.Duration(r.agentConfig.NodeDrainTimeout) * time.Second
+	if start, err := time.Parse(time.RFC3339, hostOp.Status.DrainStartTime); err != nil || time.Since(start) > timeout {
+		// the node is uncordoned only when it is cordoned by the operation
+		r.releaseNode(ctx, hostOp)
+		return false, fmt.Errorf("failed to drain node %s: timeout after %v, %d pods are not evicted, such as %s", nodeName, timeout, len(pending), pending[0])
+	}
+	r.log.Debugf("HostOperation %s waits for %d pods to be evicted from node %s", hostOp.Name, len(pending), nodeName)
+	return false, nil
+}
+
+// releaseNode uncordons the node cordoned by the operation when the action fails, so the node is not rebooted
+func (r *HostOperationController) releaseNode(ctx context.Context, hostOp *topohubv1beta1.HostOperation) {
+	if r.drainer == nil || !tools.IsDisruptiveAction(hostOp.Spec.Action) {
+		return
+	}
+	nodeName, err := r.nodeName(ctx, hostOp)
+	if err != nil || nodeName == "" {
+		return
+	}
+	if err := r.drainer.Uncordon(ctx, nodeName, hostOp.Name); err != nil {
+		r.log.Errorf("Failed to uncordon node %s for HostOperation %s: %v", nodeName, hostOp.Name, err)
+	}
+}
@@ -0,0 +1,169 @@
+package hostoperation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	sshstatusdata "github.com/infrastructure-io/topohub/pkg/sshstatus/data"
+	"github.com/infrastructure-io/topohub/pkg/sshstatus/ssh"
+)
+
+const defaultSSHTimeout = 300 * time.Second
+
+// reconcileSSH runs the action on the ssh host, and records the exit code and the output in the status.
+// The running status is persisted before the action, so the action is never run twice
+func (r *HostOperationController) reconcileSSH(ctx context.Context, hostOp *topohubv1beta1.HostOperation) (ctrl.Result, error) {
+	logger := r.log.With("hostoperation", hostOp.Name)
+
+	if hostOp.Status.Status == topohubv1beta1.HostOperationStatusRunning {
+		// the controller restarted or the leader changed while the action was running, the result is unknown
+		logger.Warnf("HostOperation %s was interrupted while running, it is not run again", hostOp.Name)
+		hostOp.Status.Status = topohubv1beta1.HostOperationStatusFailed
+		hostOp.Status.Message = "the operation was interrupted while running, and it is not run again"
+		hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+		r.releaseNode(ctx, hostOp)
+		if err := r.Status().Update(ctx, hostOp); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update HostOperation status: %v", err)
+		}
+		return ctrl.Result{}, nil
+	}
+	if hostOp.Status.Status != "" && hostOp.Status.Status != topohubv1beta1.HostOperationStatusPending {
+		logger.Infof("HostOperation %s has been processed", hostOp.Name)
+		return ctrl.Result{}, nil
+	}
+
+	sshStatus := &topohubv1beta1.SSHStatus{}
+	if err := r.Get(ctx, client.ObjectKey{Name: hostOp.Spec.SSHStatusName}, sshStatus); err != nil {
+		logger.Errorf("Failed to get SSHStatus %s: %v", hostOp.Spec.SSHStatusName, err)
+		return ctrl.Result{}, err
+	}
+
+	logger.Infof("Processing HostOperation %s : %+v", hostOp.Name, hostOp.Spec)
+	hostOp.Status.ClusterName = sshStatus.Status.Basic.ClusterName
+	hostOp.Status.IpAddr = sshStatus.Status.Basic.IpAddr
+
+	d := sshstatusdata.SSHCacheDatabase.Get(hostOp.Spec.SSHStatusName)
+	if d == nil {
+		logger.Warnf("Failed to get connect config %s from cache, retry later", hostOp.Spec.SSHStatusName)
+		return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+	}
+
+	// the update fails with a conflict when the status is changed by others, such as the stale object in the cache
+	hostOp.Status.Status = topohubv1beta1.HostOperationStatusRunning
+	hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Status().Update(ctx, hostOp); err != nil {
+		logger.Errorf("Failed to set HostOperation %s running: %v", hostOp.Name, err)
+		return ctrl.Result{}, fmt.Errorf("failed to update HostOperation status: %v", err)
+	}
+
+	result, err := r.runSSHAction(hostOp, *d)
+	if result != nil {
+		exitCode := int32(result.ExitCode)
+		hostOp.Status.ExitCode = &exitCode
+		hostOp.Status.Stdout = result.Stdout
+		hostOp.Status.Stderr = result.Stderr
+		if err == nil && result.ExitCode != 0 {
+			err = fmt.Errorf("exit code %d", result.ExitCode)
+		}
+	}
+
+	hostOp.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	if err != nil {
+		logger.Errorf("Failed to operate %s: %v", hostOp.Spec.SSHStatusName, err)
+		hostOp.Status.Status = topohubv1beta1.HostOperationStatusFailed
+		hostOp.Status.Message = err.Error()
+		r.releaseNode(ctx, hostOp)
+	} else {
+		logger.Infof("Succeeded to operate %s", hostOp.Spec.SSHStatusName)
+		hostOp.Status.Status = topohubv1beta1.HostOperationStatusSuccess
+		hostOp.Status.Message = ""
+	}
+
+	if err := r.Status().Update(ctx, hostOp); err != nil {
+		logger.Errorf("Action has been done, but failed to update HostOperation status: %v", err)
+		return ctrl.Result{}, fmt.Errorf("failed to update HostOperation status: %v", err)
+	}
+	logger.Debugf("Successfully updated HostOperation %s status", hostOp.Name)
+	return ctrl.Result{}, nil
+}
+
+// runSSHAction connects to the host and runs the action
+func (r *HostOperationController) runSSHAction(hostOp *topohubv1beta1.HostOperation, d sshstatusdata.SSHConnectCon) (*ssh.CommandResult, error) {
+	timeout := defaultSSHTimeout
+	if hostOp.Spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(hostOp.Spec.TimeoutSeconds) * time.Second
+	}
+
+	c, err := ssh.ConnectionPool.Get(d, r.log)
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+
+	switch hostOp.Spec.Action {
+	case topohubv1beta1.BootCmdGracefulShutdown:
+		return c.Power(false, timeout)
+	case topohubv1beta1.BootCmdGracefulRestart:
+		return c.Power(true, timeout)
+	case topohubv1beta1.SSHCmdRunScript:
+		if hostOp.Spec.Script == nil {
+			return nil, fmt.Errorf("script is required by action %s", hostOp.Spec.Action)
+		}
+		file, err := resolveFile(r.agentConfig.StoragePathHttpTools, hostOp.Spec.Script.Name)
+		if err != nil {
+			return nil, err
+		}
+		script, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read script %s: %v", hostOp.Spec.Script.Name, err)
+		}
+		// only the approved content of the script is run
+		if sum := sha256.Sum256(script); !strings.EqualFold(hex.EncodeToString(sum[:]), hostOp.Spec.Script.Sha256) {
+			return nil, fmt.Errorf("the sha256 of script %s is %x, which is not approved", hostOp.Spec.Script.Name, sum)
+		}
+		return c.RunScript(script, hostOp.Spec.Script.Args, hostOp.Spec.Script.Env, timeout)
+	case topohubv1beta1.SSHCmdPushFile:
+		if hostOp.Spec.File == nil {
+			return nil, fmt.Errorf("file is required by action %s", hostOp.Spec.Action)
+		}
+		file, err := resolveFile(r.agentConfig.StoragePathHttp, hostOp.Spec.File.Source)
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %v", hostOp.Spec.File.Source, err)
+		}
+		defer content.Close()
+		mode := hostOp.Spec.File.Mode
+		if mode == "" {
+			mode = "0644"
+		}
+		return c.PushFile(content, hostOp.Spec.File.Destination, mode, timeout)
+	default:
+		return nil, fmt.Errorf("action %s is not supported by the ssh hosts", hostOp.Spec.Action)
+	}
+}
+
+// resolveFile returns the regular file under the root, the path can not escape from the root
+func resolveFile(root, name string) (string, error) {
+	file := filepath.Join(root, filepath.Clean("/"+name))
+	stat, err := os.Stat(file)
+	if err != nil {
+		return "", fmt.Errorf("%s is not found: %v", name, err)
+	}
+	if !stat.Mode().IsRegular() {
+		return "", fmt.Errorf("%s is not a regular file", name)
+	}
+	return file, nil
+}
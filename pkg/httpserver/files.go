@@ -0,0 +1,481 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+
+	"github.com/infrastructure-io/topohub/pkg/metrics"
+)
+
+// the api to manage the files served by the http server, the path is like /api/v1/files/<iso|ztp|tools>/<file>
+// Example:
+//   - curl -H "Authorization: Bearer <token>" http://192.168.1.2/api/v1/files/iso/
+//   - curl -H "Authorization: Bearer <token>" -T ubuntu.iso http://192.168.1.2/api/v1/files/iso/ubuntu.iso
+//   - curl -H "Authorization: Bearer <token>" "http://192.168.1.2/api/v1/files/iso/ubuntu.iso?checksum=sha256"
+//   - curl -H "Authorization: Bearer <token>" -X DELETE http://192.168.1.2/api/v1/files/iso/ubuntu.iso
+const fileApiPathPrefix = "/api/v1/files/"
+
+// the directories under the http root which are managed by the file api. The scripts uploaded to the tools directory
+// are only run by the HostOperations which approve their sha256
+var fileApiDirs = []string{"iso", "ztp", "tools"}
+
+// the headers of the chunked upload. The client sends each chunk by PATCH with the offset and the total length,
+// and queries the offset to resume by HEAD
+const (
+	headerUploadOffset = "Upload-Offset"
+	headerUploadLength = "Upload-Length"
+	// the sha256 of the whole file, it is verified when the upload completes
+	headerUploadChecksum = "Upload-Checksum"
+)
+
+// the suffix of the partial files in the uploads directory
+const partialFileSuffix = ".part"
+
+type fileInfo struct {
+	Name     string    `json:"name"`
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"modTime"`
+	Checksum string    `json:"checksum,omitempty"`
+	Dir      bool      `json:"dir,omitempty"`
+}
+
+// fileApiPath is the file which a request of the file api refers to
+type fileApiPath struct {
+	// the path relative to the http root, like iso/ubuntu.iso
+	name string
+	// the file under the http root
+	file string
+	// the partial file of the chunked upload
+	partial string
+}
+
+// parseFileApiPath validates the request path, the file must be in one of the managed directories
+func (s *httpServer) parseFileApiPath(urlPath string) (*fileApiPath, error) {
+	// the clean of the rooted path removes all the .. elements
+	name := strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(urlPath, fileApiPathPrefix)), "/")
+	dir, _, _ := strings.Cut(name, "/")
+	if !slices.Contains(fileApiDirs, dir) {
+		return nil, fmt.Errorf("the path should be in one of the directories: %s", strings.Join(fileApiDirs, ", "))
+	}
+	return &fileApiPath{
+		name:    name,
+		file:    filepath.Join(s.config.StoragePathHttp, filepath.FromSlash(name)),
+		partial: filepath.Join(s.config.StoragePathUploads, filepath.FromSlash(name)+partialFileSuffix),
+	}, nil
+}
+
+// authenticateFileApi accepts the token in the file api secret, or the token of the allowed service accounts
+func (s *httpServer) authenticateFileApi(ctx context.Context, r *http.Request) error {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return fmt.Errorf("missing bearer token")
+	}
+	secretErr := s.verifySecretToken(ctx, s.config.FileApiSecretName, s.config.FileApiSecretNamespace, token)
+	if secretErr == nil || len(s.config.FileApiServiceAccounts) == 0 {
+		return secretErr
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := s.client.Create(ctx, review); err != nil {
+		return fmt.Errorf("failed to create the TokenReview: %v", err)
+	}
+	if !review.Status.Authenticated {
+		return fmt.Errorf("invalid bearer token: %s", review.Status.Error)
+	}
+	if !slices.Contains(s.config.FileApiServiceAccounts, review.Status.User.Username) {
+		return fmt.Errorf("the user %s is not allowed", review.Status.User.Username)
+	}
+	return nil
+}
+
+// handleFileApi authenticates the request and manages the files
+func (s *httpServer) handleFileApi(w http.ResponseWriter, r *http.Request) {
+	if s.config.FileApiSecretName == "" {
+		http.Error(w, "the file api is disabled", http.StatusNotFound)
+		return
+	}
+	if err := s.authenticateFileApi(r.Context(), r); err != nil {
+		s.log.Warnf("Rejected the file api request from %s: %v", remoteIP(r), err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	s.serveFileApi(w, r)
+}
+
+func (s *httpServer) serveFileApi(w http.ResponseWriter, r *http.Request) {
+	p, err := s.parseFileApiPath(r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.Method != http.MethodGet && !strings.Contains(p.name, "/") {
+		http.Error(w, "the path should be a file in the directory", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.getFile(w, r, p)
+	case http.MethodPut:
+		s.putFile(w, r, p)
+	case http.MethodHead:
+		s.headUpload(w, p)
+	case http.MethodPatch:
+		s.patchUpload(w, r, p)
+	case http.MethodDelete:
+		s.deleteFile(w, p)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// getFile lists the files of a directory recursively, or returns the info of a file with the optional checksum
+func (s *httpServer) getFile(w http.ResponseWriter, r *http.Request, p *fileApiPath) {
+	stat, err := os.Stat(p.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.Error(w, fmt.Sprintf("%s is not found", p.name), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if stat.IsDir() {
+		files := []fileInfo{}
+		err := filepath.WalkDir(p.file, func(file string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			rel, _ := filepath.Rel(s.config.StoragePathHttp, file)
+			files = append(files, fileInfo{Name: filepath.ToSlash(rel), Size: info.Size(), ModTime: info.ModTime().UTC()})
+			return nil
+		})
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to list %s: %v", p.name, err), http.StatusInternalServerError)
+			return
+		}
+		writeJson(w, http.StatusOK, files)
+		return
+	}
+
+	info := fileInfo{Name: p.name, Size: stat.Size(), ModTime: stat.ModTime().UTC()}
+	if algorithm := r.URL.Query().Get("checksum"); algorithm != "" {
+		if info.Checksum, err = fileChecksum(p.file, algorithm); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// the sidecar of the file which is not uploaded by the file api is generated here
+		if algorithm == "sha256" && sidecarStale(p.file, stat) {
+			if err := writeSidecar(p.file, info.Checksum); err != nil {
+				s.log.Warnf("Failed to write the sidecar of %s: %v", p.name, err)
+			}
+		}
+	}
+	writeJson(w, http.StatusOK, info)
+}
+
+// putFile uploads the whole file, the file is replaced only when the upload succeeds
+func (s *httpServer) putFile(w http.ResponseWriter, r *http.Request, p *fileApiPath) {
+	if !s.lockUpload(p.name) {
+		http.Error(w, fmt.Sprintf("%s is being uploaded", p.name), http.StatusConflict)
+		return
+	}
+	defer s.unlockUpload(p.name)
+
+	available, err := s.availableQuota()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// the existing file is replaced
+	if stat, err := os.Stat(p.file); err == nil && available >= 0 {
+		available += stat.Size()
+	}
+	if available >= 0 && r.ContentLength > available {
+		http.Error(w, "the quota is exceeded", http.StatusInsufficientStorage)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.partial), 0755); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p.partial), filepath.Base(p.partial)+".*")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	status, err := copyWithQuota(tmp, r.Body, available)
+	if closeErr := tmp.Close(); err == nil && closeErr != nil {
+		status, err = http.StatusInternalServerError, closeErr
+	}
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	s.completeUpload(w, r, p, tmp.Name())
+}
+
+// headUpload returns the offset to resume the chunked upload
+func (s *httpServer) headUpload(w http.ResponseWriter, p *fileApiPath) {
+	offset := int64(0)
+	if stat, err := os.Stat(p.partial); err == nil {
+		offset = stat.Size()
+	}
+	w.Header().Set(headerUploadOffset, strconv.FormatInt(offset, 10))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+}
+
+// patchUpload appends a chunk to the partial file, the file is moved to the http directory
+// when the length of the partial file reaches the Upload-Length
+func (s *httpServer) patchUpload(w http.ResponseWriter, r *http.Request, p *fileApiPath) {
+	offset, err := strconv.ParseInt(r.Header.Get(headerUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		http.Error(w, fmt.Sprintf("invalid %s header", headerUploadOffset), http.StatusBadRequest)
+		return
+	}
+	length, err := strconv.ParseInt(r.Header.Get(headerUploadLength), 10, 64)
+	if err != nil || length < offset {
+		http.Error(w, fmt.Sprintf("invalid %s header", headerUploadLength), http.StatusBadRequest)
+		return
+	}
+
+	if !s.lockUpload(p.name) {
+		http.Error(w, fmt.Sprintf("%s is being uploaded", p.name), http.StatusConflict)
+		return
+	}
+	defer s.unlockUpload(p.name)
+
+	current := int64(0)
+	if stat, err := os.Stat(p.partial); err == nil {
+		current = stat.Size()
+	}
+	if offset != current {
+		w.Header().Set(headerUploadOffset, strconv.FormatInt(current, 10))
+		http.Error(w, fmt.Sprintf("the offset should be %d", current), http.StatusConflict)
+		return
+	}
+
+	available, err := s.availableQuota()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if available >= 0 && length-offset > available {
+		http.Error(w, "the quota is exceeded", http.StatusInsufficientStorage)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.partial), 0755); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	f, err := os.OpenFile(p.partial, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// the chunk must not go beyond the length of the file
+	written, err := io.Copy(f, io.LimitReader(r.Body, length-offset))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	offset += written
+	w.Header().Set(headerUploadOffset, strconv.FormatInt(offset, 10))
+	if err != nil {
+		// the written part is kept, the client resumes from the returned offset
+		http.Error(w, fmt.Sprintf("failed to write the chunk: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if offset < length {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	s.completeUpload(w, r, p, p.partial)
+}
+
+// completeUpload verifies the checksum of the uploaded file and moves it to the http directory
+func (s *httpServer) completeUpload(w http.ResponseWriter, r *http.Request, p *fileApiPath, uploaded string) {
+	checksum, err := fileChecksum(uploaded, "sha256")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if expected := r.Header.Get(headerUploadChecksum); expected != "" && !strings.EqualFold(strings.TrimPrefix(expected, "sha256:"), strings.TrimPrefix(checksum, "sha256:")) {
+		_ = os.Remove(uploaded)
+		http.Error(w, fmt.Sprintf("the checksum mismatches, the uploaded file is %s", checksum), http.StatusBadRequest)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.file), 0755); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := os.Rename(uploaded, p.file); err != nil {
+		http.Error(w, fmt.Sprintf("failed to save %s: %v", p.name, err), http.StatusInternalServerError)
+		return
+	}
+	// the whole upload replaces the chunked upload which is not finished
+	_ = os.Remove(p.partial)
+	if err := writeSidecar(p.file, checksum); err != nil {
+		s.log.Warnf("Failed to write the sidecar of %s: %v", p.name, err)
+	}
+
+	stat, err := os.Stat(p.file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.log.Infof("Uploaded the file %s by %s, size %d, %s", p.name, remoteIP(r), stat.Size(), checksum)
+	writeJson(w, http.StatusCreated, fileInfo{Name: p.name, Size: stat.Size(), ModTime: stat.ModTime().UTC(), Checksum: checksum})
+}
+
+// deleteFile deletes the file and the unfinished upload of it
+func (s *httpServer) deleteFile(w http.ResponseWriter, p *fileApiPath) {
+	if !s.lockUpload(p.name) {
+		http.Error(w, fmt.Sprintf("%s is being uploaded", p.name), http.StatusConflict)
+		return
+	}
+	defer s.unlockUpload(p.name)
+
+	if stat, err := os.Stat(p.file); err == nil && stat.IsDir() {
+		http.Error(w, "the directory can not be deleted", http.StatusBadRequest)
+		return
+	}
+	deleted := false
+	_ = os.Remove(p.file + sidecarSuffix)
+	for _, file := range []string{p.file, p.partial} {
+		err := os.Remove(file)
+		if err == nil {
+			deleted = true
+		} else if !os.IsNotExist(err) {
+			http.Error(w, fmt.Sprintf("failed to delete %s: %v", p.name, err), http.StatusInternalServerError)
+			return
+		}
+	}
+	if !deleted {
+		http.Error(w, fmt.Sprintf("%s is not found", p.name), http.StatusNotFound)
+		return
+	}
+	metrics.DeleteHttpFile("/" + p.name)
+	s.log.Infof("Deleted the file %s", p.name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lockUpload makes sure a file is not written by two requests at the same time
+func (s *httpServer) lockUpload(name string) bool {
+	s.uploadLock.Lock()
+	defer s.uploadLock.Unlock()
+	if _, ok := s.uploading[name]; ok {
+		return false
+	}
+	s.uploading[name] = struct{}{}
+	return true
+}
+
+func (s *httpServer) unlockUpload(name string) {
+	s.uploadLock.Lock()
+	defer s.uploadLock.Unlock()
+	delete(s.uploading, name)
+}
+
+// availableQuota returns the bytes which could still be uploaded, -1 means no limit.
+// Both the http directory and the unfinished uploads are counted
+func (s *httpServer) availableQuota() (int64, error) {
+	if s.config.FileApiQuota <= 0 {
+		return -1, nil
+	}
+	used := int64(0)
+	for _, dir := range []string{s.config.StoragePathHttp, s.config.StoragePathUploads} {
+		err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return err
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			used += info.Size()
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return 0, fmt.Errorf("failed to calculate the usage of %s: %v", dir, err)
+		}
+	}
+	return max(s.config.FileApiQuota-used, 0), nil
+}
+
+// copyWithQuota copies the body to the file, and fails when the body is larger than the available quota
+func copyWithQuota(dst io.Writer, src io.Reader, available int64) (int, error) {
+	if available < 0 {
+		if _, err := io.Copy(dst, src); err != nil {
+			return http.StatusInternalServerError, fmt.Errorf("failed to write the file: %v", err)
+		}
+		return http.StatusOK, nil
+	}
+	written, err := io.Copy(dst, io.LimitReader(src, available+1))
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("failed to write the file: %v", err)
+	}
+	if written > available {
+		return http.StatusInsufficientStorage, fmt.Errorf("the quota is exceeded")
+	}
+	return http.StatusOK, nil
+}
+
+// fileChecksum returns the checksum like sha256:<hex>, the algorithm is sha256 or md5
+func fileChecksum(file, algorithm string) (string, error) {
+	var h hash.Hash
+	switch algorithm {
+	case "sha256":
+		h = sha256.New()
+	case "md5":
+		h = md5.New()
+	default:
+		return "", fmt.Errorf("unsupported checksum %s, it should be sha256 or md5", algorithm)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to read %s: %v", file, err)
+	}
+	return algorithm + ":" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeJson(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,90 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+)
+
+func newFileApiTestServer(t *testing.T) *httpServer {
+	dir := t.TempDir()
+	return &httpServer{
+		config: &config.AgentConfig{
+			StoragePathHttp:    filepath.Join(dir, "http"),
+			StoragePathUploads: filepath.Join(dir, "uploads"),
+		},
+		log:       zap.NewNop().Sugar(),
+		uploading: make(map[string]struct{}),
+	}
+}
+
+func doFileApi(s *httpServer, method, target, body string, headers map[string]string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	for k, v := range headers {
+		r.Header.Set(k, v)
+	}
+	w := httptest.NewRecorder()
+	s.serveFileApi(w, r)
+	return w
+}
+
+// TestFileApi tests the upload, the chunked upload, the list, the checksum and the delete of the files
+func TestFileApi(t *testing.T) {
+	s := newFileApiTestServer(t)
+
+	if w := doFileApi(s, http.MethodPut, "/api/v1/files/iso/../../etc/passwd", "x", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("expected the traversal to be rejected, got %d", w.Code)
+	}
+	if w := doFileApi(s, http.MethodPut, "/api/v1/files/iso/a.iso", "hello", nil); w.Code != http.StatusCreated {
+		t.Fatalf("failed to upload: %d %s", w.Code, w.Body.String())
+	}
+	if w := doFileApi(s, http.MethodGet, "/api/v1/files/iso/a.iso?checksum=md5", "", nil); !strings.Contains(w.Body.String(), "md5:5d41402abc4b2a76b9719d911017c592") {
+		t.Errorf("unexpected checksum: %s", w.Body.String())
+	}
+
+	// the chunked upload resumes from the offset of the partial file
+	chunk := map[string]string{headerUploadOffset: "0", headerUploadLength: "10"}
+	if w := doFileApi(s, http.MethodPatch, "/api/v1/files/ztp/sw1/ztp.json", "01234", chunk); w.Code != http.StatusNoContent {
+		t.Fatalf("failed to upload the first chunk: %d %s", w.Code, w.Body.String())
+	}
+	if w := doFileApi(s, http.MethodPatch, "/api/v1/files/ztp/sw1/ztp.json", "01234", chunk); w.Code != http.StatusConflict || w.Header().Get(headerUploadOffset) != "5" {
+		t.Errorf("expected the conflict of the offset, got %d %v", w.Code, w.Header())
+	}
+	if w := doFileApi(s, http.MethodHead, "/api/v1/files/ztp/sw1/ztp.json", "", nil); w.Header().Get(headerUploadOffset) != "5" {
+		t.Errorf("unexpected offset: %v", w.Header())
+	}
+	chunk[headerUploadOffset] = "5"
+	if w := doFileApi(s, http.MethodPatch, "/api/v1/files/ztp/sw1/ztp.json", "56789", chunk); w.Code != http.StatusCreated {
+		t.Fatalf("failed to complete the upload: %d %s", w.Code, w.Body.String())
+	}
+	if data, _ := os.ReadFile(filepath.Join(s.config.StoragePathHttp, "ztp/sw1/ztp.json")); string(data) != "0123456789" {
+		t.Errorf("unexpected content: %s", data)
+	}
+
+	w := doFileApi(s, http.MethodGet, "/api/v1/files/ztp/", "", nil)
+	files := []fileInfo{}
+	if err := json.Unmarshal(w.Body.Bytes(), &files); err != nil || len(files) != 2 || files[0].Name != "ztp/sw1/ztp.json" || files[1].Name != "ztp/sw1/ztp.json.sha256" {
+		t.Errorf("unexpected list: %s", w.Body.String())
+	}
+
+	// the uploaded files and the sidecars are counted
+	s.config.FileApiQuota = 200
+	if w := doFileApi(s, http.MethodPut, "/api/v1/files/tools/big", strings.Repeat("0", 100), nil); w.Code != http.StatusInsufficientStorage {
+		t.Errorf("expected the quota to be exceeded, got %d", w.Code)
+	}
+
+	if w := doFileApi(s, http.MethodDelete, "/api/v1/files/iso/a.iso", "", nil); w.Code != http.StatusNoContent {
+		t.Errorf("failed to delete: %d", w.Code)
+	}
+	if w := doFileApi(s, http.MethodDelete, "/api/v1/files/iso/a.iso", "", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected not found, got %d", w.Code)
+	}
+}
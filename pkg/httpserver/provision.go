@@ -0,0 +1,126 @@
+package httpserver
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/provision"
+)
+
+// the phone-home endpoint for the installer, which is called at the end of the installation. The token is the
+// {{ .CallbackToken }} of the ProvisionProfile, which is issued for the mac of the host
+// Example:
+//   - curl -H "Authorization: Bearer <token>" "http://192.168.1.2/provision/callback?mac=00:11:22:33:44:55"
+const provisionCallbackPath = "/provision/callback"
+
+// the directories of the boot files, the kernel and the installer scripts
+var bootFilePrefixes = []string{"/boot/", "/pxe/", "/iso/"}
+
+// responseRecorder records the status code and the size of the response for the access log
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// remoteIP returns the ip of the client
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// accessLog logs each request with the host name of the client, and reports the downloads of the boot files
+func (s *httpServer) accessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		clientIP := remoteIP(r)
+		s.log.Infof("access: %s %s %s %s %d %d", clientIP, s.resolveClientHost(s.stopCtx, clientIP), r.Method, r.URL.Path, rec.status, rec.bytes)
+
+		if r.Method != http.MethodGet || rec.status >= http.StatusBadRequest {
+			return
+		}
+		for _, prefix := range bootFilePrefixes {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				provision.SendEvent(s.provisionEvents, provision.Event{
+					Phase:   topohubv1beta1.ProvisionPhaseBootFileFetched,
+					IpAddr:  clientIP,
+					Message: fmt.Sprintf("the file %s is fetched by http", r.URL.Path),
+				})
+				return
+			}
+		}
+	})
+}
+
+// handleProvisionCallback handles the phone-home of the installer. The host is identified by the mac parameter,
+// which is authenticated by the token issued for the mac, and the ip of the client
+func (s *httpServer) handleProvisionCallback(w http.ResponseWriter, r *http.Request) {
+	if s.config.PhoneHomeSecretName == "" {
+		http.Error(w, "the phone-home endpoint is disabled", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	// the events are only processed by the tracker of the leader, the installer retries on the other replicas
+	if !s.isLeader() {
+		http.Error(w, "not the leader, retry later", http.StatusServiceUnavailable)
+		return
+	}
+
+	hw, err := net.ParseMAC(r.URL.Query().Get("mac"))
+	if err != nil {
+		http.Error(w, "a valid mac is required", http.StatusBadRequest)
+		return
+	}
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	key, err := s.secretToken(r.Context(), s.config.PhoneHomeSecretName, s.config.PhoneHomeSecretNamespace)
+	if err != nil {
+		s.log.Errorf("Failed to authenticate the installer callback: %v", err)
+		http.Error(w, "failed to authenticate", http.StatusServiceUnavailable)
+		return
+	}
+	if !provision.VerifyHostToken(key, hw.String(), token) {
+		s.log.Warnf("Rejected the installer callback of mac %s from %s: invalid token", hw.String(), remoteIP(r))
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	event := provision.Event{
+		Phase:   topohubv1beta1.ProvisionPhaseInstallerCallback,
+		MacAddr: hw.String(),
+		IpAddr:  remoteIP(r),
+		Message: fmt.Sprintf("the installer calls back from %s", remoteIP(r)),
+	}
+	if msg := r.URL.Query().Get("message"); msg != "" {
+		event.Message = msg
+	}
+
+	if !provision.SendEvent(s.provisionEvents, event) {
+		http.Error(w, "the provisioning tracker is busy, retry later", http.StatusServiceUnavailable)
+		return
+	}
+	s.log.Infof("received the installer callback: mac=%s, ip=%s", event.MacAddr, event.IpAddr)
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "ok")
+}
@@ -0,0 +1,278 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/provision"
+)
+
+// the phone-home endpoint for the installed os to register itself as a ssh HostEndpoint
+// Example:
+//   - curl -X POST -H "Authorization: Bearer <token>" -d '{"hostname":"node1","ipAddrs":["192.168.1.10"]}' http://192.168.1.2/provision/register
+const provisionRegisterPath = "/provision/register"
+
+const defaultSSHPort = 22
+
+// registerRequest is the body of the phone-home registration
+type registerRequest struct {
+	Hostname string `json:"hostname"`
+	// the in-band ip addresses of the host, the first one is used to access the ssh server
+	IpAddrs []string `json:"ipAddrs"`
+	// the in-band mac addresses of the host, which are used to find the redfishstatus of the host
+	MacAddrs []string `json:"macAddrs,omitempty"`
+	// the public host key of the ssh server in the authorized_keys format
+	SSHHostKey string `json:"sshHostKey,omitempty"`
+	SSHPort    int32  `json:"sshPort,omitempty"`
+}
+
+type registerResponse struct {
+	HostEndpoint  string `json:"hostEndpoint"`
+	RedfishStatus string `json:"redfishStatus,omitempty"`
+}
+
+// validate checks the registration and returns the ip to access the ssh server
+func (req *registerRequest) validate(clientIP string) (string, error) {
+	if req.Hostname == "" {
+		return "", fmt.Errorf("hostname is required")
+	}
+	if errs := validation.IsDNS1123Subdomain(req.Hostname); len(errs) > 0 {
+		return "", fmt.Errorf("invalid hostname %s: %s", req.Hostname, strings.Join(errs, ", "))
+	}
+	if len(req.IpAddrs) == 0 {
+		return "", fmt.Errorf("ipAddrs is required")
+	}
+	for _, ip := range req.IpAddrs {
+		if net.ParseIP(ip) == nil {
+			return "", fmt.Errorf("invalid ip %s", ip)
+		}
+	}
+	for i, mac := range req.MacAddrs {
+		hw, err := net.ParseMAC(mac)
+		if err != nil {
+			return "", fmt.Errorf("invalid mac %s", mac)
+		}
+		req.MacAddrs[i] = hw.String()
+	}
+	if req.SSHHostKey != "" {
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(req.SSHHostKey)); err != nil {
+			return "", fmt.Errorf("invalid sshHostKey: %v", err)
+		}
+	}
+	if req.SSHPort == 0 {
+		req.SSHPort = defaultSSHPort
+	}
+
+	// prefer the address which the request comes from
+	for _, ip := range req.IpAddrs {
+		if ip == clientIP {
+			return ip, nil
+		}
+	}
+	return req.IpAddrs[0], nil
+}
+
+// authenticate checks the bearer token against the token in the phone-home secret
+func (s *httpServer) authenticate(ctx context.Context, r *http.Request) error {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return fmt.Errorf("missing bearer token")
+	}
+	return s.verifySecretToken(ctx, s.config.PhoneHomeSecretName, s.config.PhoneHomeSecretNamespace, token)
+}
+
+// secretToken returns the token key of the secret
+func (s *httpServer) secretToken(ctx context.Context, name, namespace string) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := s.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the secret %s/%s: %v", namespace, name, err)
+	}
+	return secret.Data["token"], nil
+}
+
+// verifySecretToken checks the token against the token key of the secret
+func (s *httpServer) verifySecretToken(ctx context.Context, name, namespace, token string) error {
+	expected, err := s.secretToken(ctx, name, namespace)
+	if err != nil {
+		return err
+	}
+	if len(expected) == 0 || subtle.ConstantTimeCompare(expected, []byte(token)) != 1 {
+		return fmt.Errorf("invalid bearer token")
+	}
+	return nil
+}
+
+// handleProvisionRegister creates or updates the ssh HostEndpoint of the host which calls home,
+// and links it to the redfishstatus of the same host
+func (s *httpServer) handleProvisionRegister(w http.ResponseWriter, r *http.Request) {
+	if s.config.PhoneHomeSecretName == "" {
+		http.Error(w, "the phone-home endpoint is disabled", http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.config.SSHSecretName == "" {
+		http.Error(w, "no ssh credentials are configured for the registered hosts", http.StatusServiceUnavailable)
+		return
+	}
+	ctx := r.Context()
+	if err := s.authenticate(ctx, r); err != nil {
+		s.log.Warnf("Rejected the phone-home from %s: %v", remoteIP(r), err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	req := &registerRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 64*1024)).Decode(req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+	sshIP, err := req.validate(remoteIP(r))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+	if err := s.client.List(ctx, redfishStatusList); err != nil {
+		http.Error(w, fmt.Sprintf("failed to list redfishstatus: %v", err), http.StatusInternalServerError)
+		return
+	}
+	redfishStatusName := provision.FindRedfishStatus(redfishStatusList.Items, req.MacAddrs, req.IpAddrs)
+
+	status, err := s.applyHostEndpoint(ctx, req, sshIP, remoteIP(r), redfishStatusName)
+	if err != nil {
+		s.log.Errorf("Failed to register HostEndpoint %s: %v", req.Hostname, err)
+		http.Error(w, err.Error(), status)
+		return
+	}
+	s.log.Infof("Registered the ssh HostEndpoint %s (IP: %s, redfishstatus: %s) by phone-home", req.Hostname, sshIP, redfishStatusName)
+
+	// the registration also indicates the installation is finished
+	event := provision.Event{
+		Phase:   topohubv1beta1.ProvisionPhaseInstallerCallback,
+		IpAddr:  sshIP,
+		Message: fmt.Sprintf("the host registers the HostEndpoint %s", req.Hostname),
+	}
+	if len(req.MacAddrs) > 0 {
+		event.MacAddr = req.MacAddrs[0]
+	}
+	provision.SendEvent(s.provisionEvents, event)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(registerResponse{HostEndpoint: req.Hostname, RedfishStatus: redfishStatusName})
+}
+
+// checkReregistration checks the registration of an existing HostEndpoint. The phone-home token is shared by all the
+// hosts, and the per-host token is derived from it, so neither proves the caller is the registered host. The address
+// and the host key which topohub logins with are only changed by the request from the recorded address, otherwise a
+// token holder could redirect the password login of topohub to its own server
+func checkReregistration(existing *topohubv1beta1.HostEndpoint, spec *topohubv1beta1.HostEndpointSpec, clientIP string) error {
+	if clientIP == existing.Spec.IPAddr {
+		return nil
+	}
+	if existing.Spec.IPAddr != spec.IPAddr {
+		return fmt.Errorf("the ip of HostEndpoint %s is only changed by the request from %s", existing.Name, existing.Spec.IPAddr)
+	}
+	if existing.Spec.Port != nil && spec.Port != nil && *existing.Spec.Port != *spec.Port {
+		return fmt.Errorf("the port of HostEndpoint %s is only changed by the request from %s", existing.Name, existing.Spec.IPAddr)
+	}
+	oldKey, newKey := "", ""
+	if existing.Spec.SSHHostKey != nil {
+		oldKey = *existing.Spec.SSHHostKey
+	}
+	if spec.SSHHostKey != nil {
+		newKey = *spec.SSHHostKey
+	}
+	if oldKey != newKey {
+		return fmt.Errorf("the ssh host key of HostEndpoint %s is only changed by the request from %s", existing.Name, existing.Spec.IPAddr)
+	}
+	return nil
+}
+
+// applyHostEndpoint creates or updates the ssh HostEndpoint, and returns the http status code
+func (s *httpServer) applyHostEndpoint(ctx context.Context, req *registerRequest, sshIP, clientIP, redfishStatusName string) (int, error) {
+	endpointType := topohubv1beta1.EndpointTypeSSH
+	https := false
+	// the ssh credentials are not kept in the phone-home secret, so the installers never get them by the token
+	secretName := s.config.SSHSecretName
+	secretNamespace := s.config.SSHSecretNamespace
+	spec := topohubv1beta1.HostEndpointSpec{
+		IPAddr:          sshIP,
+		SecretName:      &secretName,
+		SecretNamespace: &secretNamespace,
+		HTTPS:           &https,
+		Port:            &req.SSHPort,
+		Type:            &endpointType,
+	}
+	if req.SSHHostKey != "" {
+		spec.SSHHostKey = &req.SSHHostKey
+	}
+
+	existing := &topohubv1beta1.HostEndpoint{}
+	err := s.client.Get(ctx, client.ObjectKey{Name: req.Hostname}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return http.StatusInternalServerError, fmt.Errorf("failed to get HostEndpoint %s: %v", req.Hostname, err)
+	}
+
+	var hostEndpoint *topohubv1beta1.HostEndpoint
+	created := errors.IsNotFound(err)
+	if created {
+		hostEndpoint = &topohubv1beta1.HostEndpoint{
+			ObjectMeta: metav1.ObjectMeta{Name: req.Hostname},
+		}
+	} else {
+		if _, ok := existing.Annotations[topohubv1beta1.AnnotationPhoneHome]; !ok {
+			return http.StatusConflict, fmt.Errorf("HostEndpoint %s exists and is not registered by phone-home", req.Hostname)
+		}
+		if err := checkReregistration(existing, &spec, clientIP); err != nil {
+			return http.StatusForbidden, err
+		}
+		hostEndpoint = existing.DeepCopy()
+		spec.ClusterName = existing.Spec.ClusterName
+	}
+	hostEndpoint.Spec = spec
+
+	if hostEndpoint.Annotations == nil {
+		hostEndpoint.Annotations = map[string]string{}
+	}
+	hostEndpoint.Annotations[topohubv1beta1.AnnotationPhoneHome] = time.Now().UTC().Format(time.RFC3339)
+	hostEndpoint.Annotations[topohubv1beta1.AnnotationInbandIPs] = strings.Join(req.IpAddrs, ",")
+	if redfishStatusName != "" {
+		if hostEndpoint.Labels == nil {
+			hostEndpoint.Labels = map[string]string{}
+		}
+		hostEndpoint.Labels[topohubv1beta1.LabelRedfishStatus] = redfishStatusName
+	}
+
+	if created {
+		if err := s.client.Create(ctx, hostEndpoint); err != nil {
+			return http.StatusBadRequest, fmt.Errorf("failed to create HostEndpoint %s: %v", req.Hostname, err)
+		}
+		return http.StatusCreated, nil
+	}
+	if err := s.client.Update(ctx, hostEndpoint); err != nil {
+		if errors.IsConflict(err) {
+			return http.StatusConflict, fmt.Errorf("conflict updating HostEndpoint %s, retry later", req.Hostname)
+		}
+		return http.StatusBadRequest, fmt.Errorf("failed to update HostEndpoint %s: %v", req.Hostname, err)
+	}
+	return http.StatusOK, nil
+}
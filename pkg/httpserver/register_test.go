@@ -0,0 +1,99 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestRegisterRequestValidate tests the validation of the phone-home registration
+func TestRegisterRequestValidate(t *testing.T) {
+	req := &registerRequest{
+		Hostname: "node1",
+		IpAddrs:  []string{"10.0.0.10", "192.168.1.10"},
+		MacAddrs: []string{"00:11:22:33:44:AA"},
+	}
+	ip, err := req.validate("192.168.1.10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ip != "192.168.1.10" {
+		t.Errorf("expected the ip of the client, got %s", ip)
+	}
+	if req.SSHPort != defaultSSHPort || req.MacAddrs[0] != "00:11:22:33:44:aa" {
+		t.Errorf("unexpected request after validation: %+v", req)
+	}
+
+	for _, invalid := range []*registerRequest{
+		{IpAddrs: []string{"192.168.1.10"}},
+		{Hostname: "Node_1", IpAddrs: []string{"192.168.1.10"}},
+		{Hostname: "node1"},
+		{Hostname: "node1", IpAddrs: []string{"192.168.1.10"}, SSHHostKey: "invalid"},
+	} {
+		if _, err := invalid.validate("192.168.1.10"); err == nil {
+			t.Errorf("expected error for %+v", invalid)
+		}
+	}
+}
+
+// TestCheckReregistration tests a second registration with the shared token does not change the ip and the host key
+// of the HostEndpoint, unless it comes from the recorded ip
+func TestCheckReregistration(t *testing.T) {
+	port := int32(22)
+	hostKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl node1"
+	existing := &topohubv1beta1.HostEndpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Spec:       topohubv1beta1.HostEndpointSpec{IPAddr: "192.168.1.10", Port: &port, SSHHostKey: &hostKey},
+	}
+	otherKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIHmJ4xOLZfnH5PmaJ7q4LHqTQ1RQ1K1cqBKHZ1wqPS2R attacker"
+
+	tests := []struct {
+		name     string
+		spec     topohubv1beta1.HostEndpointSpec
+		clientIP string
+		wantErr  bool
+	}{
+		{"unchanged", topohubv1beta1.HostEndpointSpec{IPAddr: "192.168.1.10", Port: &port, SSHHostKey: &hostKey}, "10.0.0.99", false},
+		{"ip changed by another host", topohubv1beta1.HostEndpointSpec{IPAddr: "10.0.0.99", Port: &port, SSHHostKey: &hostKey}, "10.0.0.99", true},
+		{"host key changed by another host", topohubv1beta1.HostEndpointSpec{IPAddr: "192.168.1.10", Port: &port, SSHHostKey: &otherKey}, "10.0.0.99", true},
+		{"host key removed by another host", topohubv1beta1.HostEndpointSpec{IPAddr: "192.168.1.10", Port: &port}, "10.0.0.99", true},
+		{"changed by the recorded ip", topohubv1beta1.HostEndpointSpec{IPAddr: "10.0.0.10", Port: &port, SSHHostKey: &otherKey}, "192.168.1.10", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkReregistration(existing, &tt.spec, tt.clientIP); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestProvisionCallbackNotLeader tests the installer callback is rejected with 503 by the replica which is not the
+// leader, so the installer retries
+func TestProvisionCallbackNotLeader(t *testing.T) {
+	elected := make(chan struct{})
+	s := &httpServer{
+		config:  &config.AgentConfig{PhoneHomeSecretName: "topohub-phone-home"},
+		log:     zap.NewNop().Sugar(),
+		elected: elected,
+	}
+
+	w := httptest.NewRecorder()
+	s.handleProvisionCallback(w, httptest.NewRequest(http.MethodGet, "/provision/callback?mac=00:11:22:33:44:55", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 on the replica which is not the leader, got %d", w.Code)
+	}
+
+	close(elected)
+	w = httptest.NewRecorder()
+	s.handleProvisionCallback(w, httptest.NewRequest(http.MethodGet, "/provision/callback?mac=invalid", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for the invalid mac on the leader, got %d", w.Code)
+	}
+}
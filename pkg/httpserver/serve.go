@@ -0,0 +1,332 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/metrics"
+	"github.com/infrastructure-io/topohub/pkg/provision"
+)
+
+// the sidecar file holds the sha256 of the file in the format of sha256sum. It is generated when the file is uploaded
+// by the file api, or when the sha256 of the file is queried by the file api, and it is served as a static file
+// Example:
+//   - curl http://192.168.1.2/iso/ubuntu.iso.sha256
+//   - curl -H "Authorization: Bearer <token>" "http://192.168.1.2/iso/ubuntu.iso?verify" verifies the file against the sidecar
+//   - curl "http://192.168.1.2/iso/?format=json" lists the directory as json
+const sidecarSuffix = ".sha256"
+
+const (
+	// the idle clients are removed from the host cache and the bandwidth limiters
+	clientIdleTimeout = 5 * time.Minute
+	hostCacheTTL      = time.Minute
+	// the max bytes which are written at once by the limited client
+	maxBandwidthBurst = 256 * 1024
+)
+
+type cachedHost struct {
+	name   string
+	expire time.Time
+}
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	active   int
+	lastSeen time.Time
+}
+
+// throttledWriter limits the bandwidth of the response, the limiter is shared by all the requests of the client
+type throttledWriter struct {
+	http.ResponseWriter
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+func (t *throttledWriter) Write(b []byte) (int, error) {
+	written := 0
+	for len(b) > 0 {
+		n := min(len(b), t.limiter.Burst())
+		if err := t.limiter.WaitN(t.ctx, n); err != nil {
+			return written, err
+		}
+		m, err := t.ResponseWriter.Write(b[:n])
+		written += m
+		if err != nil {
+			return written, err
+		}
+		b = b[n:]
+	}
+	return written, nil
+}
+
+// acquireLimiter returns the bandwidth limiter of the client, nil means no limit
+func (s *httpServer) acquireLimiter(clientIP string) *rate.Limiter {
+	if s.config.HttpClientBandwidth <= 0 {
+		return nil
+	}
+	s.clientLock.Lock()
+	defer s.clientLock.Unlock()
+	c, ok := s.clientLimiters[clientIP]
+	if !ok {
+		burst := int(min(s.config.HttpClientBandwidth, maxBandwidthBurst))
+		c = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(s.config.HttpClientBandwidth), burst)}
+		s.clientLimiters[clientIP] = c
+	}
+	c.active++
+	c.lastSeen = time.Now()
+	return c.limiter
+}
+
+func (s *httpServer) releaseLimiter(clientIP string) {
+	s.clientLock.Lock()
+	defer s.clientLock.Unlock()
+	if c, ok := s.clientLimiters[clientIP]; ok {
+		c.active--
+		c.lastSeen = time.Now()
+	}
+}
+
+// cleanupClients removes the idle clients periodically
+func (s *httpServer) cleanupClients() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stopCtx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			s.clientLock.Lock()
+			for ip, c := range s.clientLimiters {
+				if c.active <= 0 && now.Sub(c.lastSeen) > clientIdleTimeout {
+					delete(s.clientLimiters, ip)
+				}
+			}
+			for ip, h := range s.hostCache {
+				if now.After(h.expire) {
+					delete(s.hostCache, ip)
+				}
+			}
+			s.clientLock.Unlock()
+		}
+	}
+}
+
+// resolveClientHost returns the name of the host for the access log. The client ip is mapped to the mac
+// by the DhcpLease, and the mac is mapped to the RedfishStatus. The hostname in the DhcpLease is used when
+// the RedfishStatus is not found, and "-" is returned for the unknown client
+func (s *httpServer) resolveClientHost(ctx context.Context, clientIP string) string {
+	if s.client == nil {
+		return "-"
+	}
+	s.clientLock.Lock()
+	h, ok := s.hostCache[clientIP]
+	s.clientLock.Unlock()
+	if ok && time.Now().Before(h.expire) {
+		return h.name
+	}
+
+	name := "-"
+	leaseList := &topohubv1beta1.DhcpLeaseList{}
+	if err := s.client.List(ctx, leaseList, client.MatchingLabels{topohubv1beta1.LabelIPAddr: clientIP}); err != nil {
+		s.log.Debugf("Failed to list the DhcpLease of %s: %v", clientIP, err)
+		return name
+	}
+	macs := []string{}
+	for _, lease := range leaseList.Items {
+		macs = append(macs, lease.Spec.MacAddr)
+		if lease.Spec.Hostname != "" {
+			name = lease.Spec.Hostname
+		}
+	}
+	redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+	if err := s.client.List(ctx, redfishStatusList); err == nil {
+		if redfishStatusName := provision.FindRedfishStatus(redfishStatusList.Items, macs, []string{clientIP}); redfishStatusName != "" {
+			name = redfishStatusName
+		}
+	}
+
+	s.clientLock.Lock()
+	s.hostCache[clientIP] = cachedHost{name: name, expire: time.Now().Add(hostCacheTTL)}
+	s.clientLock.Unlock()
+	return name
+}
+
+// serveFiles serves the files under the root by the file server. It verifies the files for the clients of the file api,
+// lists the directories as json, limits the bandwidth of each client and records the bytes served
+func (s *httpServer) serveFiles(root string, fileServer http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.URL.Path = path.Clean(r.URL.Path)
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		file := filepath.Join(root, filepath.FromSlash(r.URL.Path))
+
+		stat, err := os.Stat(file)
+		if err == nil && stat.IsDir() && wantsJson(r) {
+			s.listDir(w, file)
+			return
+		}
+		if err == nil && stat.Mode().IsRegular() && r.URL.Query().Has("verify") {
+			// hashing the whole file is expensive, so only the clients of the file api are allowed to verify
+			if s.config.FileApiSecretName == "" {
+				http.Error(w, "the file api is disabled", http.StatusNotFound)
+				return
+			}
+			if err := s.authenticateFileApi(r.Context(), r); err != nil {
+				s.log.Warnf("Rejected the verification of %s from %s: %v", r.URL.Path, remoteIP(r), err)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			s.verifyFile(w, r, file)
+			return
+		}
+
+		clientIP := remoteIP(r)
+		if limiter := s.acquireLimiter(clientIP); limiter != nil {
+			defer s.releaseLimiter(clientIP)
+			w = &throttledWriter{ResponseWriter: w, ctx: r.Context(), limiter: limiter}
+		}
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		fileServer.ServeHTTP(rec, r)
+
+		if err == nil && stat.Mode().IsRegular() && rec.status < http.StatusBadRequest {
+			metrics.AddHttpBytesServed(r.URL.Path, rec.bytes)
+		}
+	})
+}
+
+func wantsJson(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+// listDir lists the entries of the directory as json
+func (s *httpServer) listDir(w http.ResponseWriter, dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to list the directory: %v", err), http.StatusInternalServerError)
+		return
+	}
+	files := []fileInfo{}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, fileInfo{Name: entry.Name(), Size: info.Size(), ModTime: info.ModTime().UTC(), Dir: entry.IsDir()})
+	}
+	writeJson(w, http.StatusOK, files)
+}
+
+type verifyResult struct {
+	Name     string `json:"name"`
+	Checksum string `json:"checksum"`
+	Expected string `json:"expected"`
+	Valid    bool   `json:"valid"`
+}
+
+// verifyFile compares the sha256 of the file with the sidecar
+func (s *httpServer) verifyFile(w http.ResponseWriter, r *http.Request, file string) {
+	expected, err := readSidecar(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			http.Error(w, fmt.Sprintf("the sidecar of %s is not found", r.URL.Path), http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	checksum, err := fileChecksum(file, "sha256")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	result := verifyResult{Name: r.URL.Path, Checksum: checksum, Expected: expected}
+	result.Valid = result.Checksum == result.Expected
+	if !result.Valid {
+		s.log.Warnf("The file %s is corrupted, the sha256 is %s, expected %s", r.URL.Path, checksum, expected)
+	}
+	writeJson(w, http.StatusOK, result)
+}
+
+// readSidecar returns the checksum like sha256:<hex> in the sidecar of the file
+func readSidecar(file string) (string, error) {
+	data, err := os.ReadFile(file + sidecarSuffix)
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("the sidecar of %s is empty", file)
+	}
+	return "sha256:" + strings.ToLower(fields[0]), nil
+}
+
+// writeSidecar writes the checksum like sha256:<hex> to the sidecar of the file
+func writeSidecar(file, checksum string) error {
+	content := fmt.Sprintf("%s  %s\n", strings.TrimPrefix(checksum, "sha256:"), filepath.Base(file))
+	tmp := file + sidecarSuffix + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file+sidecarSuffix)
+}
+
+// sidecarStale checks if the sidecar of the file does not exist or it is older than the file
+func sidecarStale(file string, stat os.FileInfo) bool {
+	sidecar, err := os.Stat(file + sidecarSuffix)
+	return err != nil || sidecar.ModTime().Before(stat.ModTime())
+}
+
+// serveMemoryFiles serves the files generated in memory for the client, like the grub config of the host which
+// is provisioned in the subnet with the built-in tftp server. The other files are served by the next handler
+func (s *httpServer) serveMemoryFiles(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.tftpFiles != nil && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+			name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+			if mac := s.macByIP(r.Context(), remoteIP(r)); mac != "" {
+				if content, ok := s.tftpFiles.Lookup(mac, name); ok {
+					http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+					return
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// macByIP returns the mac of the client from the binding ips and the leases, it is empty when not found
+func (s *httpServer) macByIP(ctx context.Context, clientIP string) string {
+	if s.client == nil {
+		return ""
+	}
+	bindingIpList := &topohubv1beta1.BindingIpList{}
+	if err := s.client.List(ctx, bindingIpList); err == nil {
+		for _, item := range bindingIpList.Items {
+			if item.Spec.IpAddr == clientIP {
+				return strings.ToLower(item.Spec.MacAddr)
+			}
+		}
+	}
+	leaseList := &topohubv1beta1.DhcpLeaseList{}
+	if err := s.client.List(ctx, leaseList, client.MatchingLabels{topohubv1beta1.LabelIPAddr: clientIP}); err != nil {
+		s.log.Debugf("Failed to list the DhcpLease of %s: %v", clientIP, err)
+		return ""
+	}
+	if len(leaseList.Items) == 0 {
+		return ""
+	}
+	return strings.ToLower(leaseList.Items[0].Spec.MacAddr)
+}
@@ -0,0 +1,90 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/infrastructure-io/topohub/pkg/metrics"
+)
+
+// TestServeFiles tests the range requests, the sidecar and the verification, the json listing and the metrics
+func TestServeFiles(t *testing.T) {
+	s := newFileApiTestServer(t)
+	s.config.HttpClientBandwidth = 1024 * 1024
+	s.clientLimiters = make(map[string]*clientLimiter)
+	iso := filepath.Join(s.config.StoragePathHttp, "iso", "a.iso")
+	if err := os.MkdirAll(filepath.Dir(iso), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(iso, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	handler := s.serveFiles(s.config.StoragePathHttp, http.FileServer(http.Dir(s.config.StoragePathHttp)))
+	serve := func(target string, headers map[string]string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		for k, v := range headers {
+			r.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := serve("/iso/a.iso", map[string]string{"Range": "bytes=1-2"}); w.Code != http.StatusPartialContent || w.Body.String() != "el" {
+		t.Errorf("unexpected range response: %d %s", w.Code, w.Body.String())
+	}
+	served := &dto.Metric{}
+	if err := metrics.HttpBytesServed.WithLabelValues("/iso/a.iso").Write(served); err != nil || served.GetCounter().GetValue() != 2 {
+		t.Errorf("expected 2 bytes served, got %v", served)
+	}
+
+	// the sidecar is not generated by the unauthenticated request
+	if w := serve("/iso/a.iso.sha256", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected no sidecar, got %d", w.Code)
+	}
+	if w := doFileApi(s, http.MethodGet, "/api/v1/files/iso/a.iso?checksum=sha256", "", nil); w.Code != http.StatusOK {
+		t.Fatalf("failed to query the checksum: %d %s", w.Code, w.Body.String())
+	}
+	if w := serve("/iso/a.iso.sha256", nil); !strings.HasPrefix(w.Body.String(), "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824  a.iso") {
+		t.Errorf("unexpected sidecar: %s", w.Body.String())
+	}
+
+	// the verification requires the file api
+	if w := serve("/iso/a.iso?verify", nil); w.Code != http.StatusNotFound {
+		t.Errorf("expected the verification to be disabled, got %d", w.Code)
+	}
+	s.config.FileApiSecretName = "topohub-file-api"
+	if w := serve("/iso/a.iso?verify", nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("expected the verification without token to be rejected, got %d", w.Code)
+	}
+	verify := func() verifyResult {
+		result := verifyResult{}
+		w := httptest.NewRecorder()
+		s.verifyFile(w, httptest.NewRequest(http.MethodGet, "/iso/a.iso?verify", nil), iso)
+		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+			t.Errorf("unexpected verification: %s", w.Body.String())
+		}
+		return result
+	}
+	if !verify().Valid {
+		t.Errorf("expected the file to be valid")
+	}
+	if err := os.WriteFile(iso+sidecarSuffix, []byte("0000  a.iso\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if verify().Valid {
+		t.Errorf("expected the corrupted file to be found")
+	}
+
+	files := []fileInfo{}
+	if w := serve("/iso/?format=json", nil); json.Unmarshal(w.Body.Bytes(), &files) != nil || len(files) != 2 || files[0].Name != "a.iso" {
+		t.Errorf("unexpected list: %s", w.Body.String())
+	}
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"path"
 	"sync"
 
 	"github.com/infrastructure-io/topohub/pkg/config"
 	"github.com/infrastructure-io/topohub/pkg/log"
+	"github.com/infrastructure-io/topohub/pkg/provision"
+	"github.com/infrastructure-io/topohub/pkg/switchztp"
+	"github.com/infrastructure-io/topohub/pkg/tftp"
 	"go.uber.org/zap"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type HttpManager interface {
@@ -19,45 +22,87 @@ type HttpManager interface {
 
 type httpServer struct {
 	config *config.AgentConfig
+	client client.Client
 	log    *zap.SugaredLogger
 
 	server        *http.Server
 	stopOnce      sync.Once
 	stopCtx       context.Context
 	stopCtxCancel context.CancelFunc
+
+	// report the boot file downloads and the installer callbacks to track the provisioning
+	provisionEvents chan provision.Event
+	// closed after elected as the leader, which is the only replica processing the provisioning events
+	elected <-chan struct{}
+	// report the fetches of the ztp files rendered for the switches
+	ztpFetchEvents chan switchztp.FetchEvent
+	// the files generated in memory for the hosts, which are served for the UEFI HTTP boot clients
+	tftpFiles *tftp.MemoryFiles
+
+	// the files which are being uploaded by the file api
+	uploadLock sync.Mutex
+	uploading  map[string]struct{}
+
+	// the bandwidth limiters and the host names of the clients
+	clientLock     sync.Mutex
+	clientLimiters map[string]*clientLimiter
+	hostCache      map[string]cachedHost
 }
 
-func NewHttpServer(config config.AgentConfig) HttpManager {
+func NewHttpServer(config config.AgentConfig, client client.Client, elected <-chan struct{}, provisionEvents chan provision.Event, ztpFetchEvents chan switchztp.FetchEvent, tftpFiles *tftp.MemoryFiles) HttpManager {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	server := &httpServer{
-		config:        &config,
-		stopCtx:       ctx,
-		stopCtxCancel: cancel,
-		log:           log.Logger.Named("httpserver"),
+		config:          &config,
+		client:          client,
+		stopCtx:         ctx,
+		stopCtxCancel:   cancel,
+		log:             log.Logger.Named("httpserver"),
+		provisionEvents: provisionEvents,
+		elected:         elected,
+		ztpFetchEvents:  ztpFetchEvents,
+		tftpFiles:       tftpFiles,
+		uploading:       make(map[string]struct{}),
+		clientLimiters:  make(map[string]*clientLimiter),
+		hostCache:       make(map[string]cachedHost),
 	}
 
 	// Create file server handler
-	fileServer := http.FileServer(http.Dir(config.StoragePathHttp))
+	fileServer := server.serveFiles(config.StoragePathHttp, http.FileServer(http.Dir(config.StoragePathHttp)))
+	// the boot loaders and grub configs in the tftp directory are served for the UEFI HTTP boot clients
+	tftpFileServer := server.serveFiles(config.StoragePathTftp, http.FileServer(http.Dir(config.StoragePathTftp)))
 
 	// Create mux and register routes
 	mux := http.NewServeMux()
-	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Clean the request path to prevent directory traversal
-		cleanPath := path.Clean(r.URL.Path)
-		r.URL.Path = cleanPath
-		fileServer.ServeHTTP(w, r)
-	}))
+	mux.HandleFunc(provisionCallbackPath, server.handleProvisionCallback)
+	mux.HandleFunc(provisionRegisterPath, server.handleProvisionRegister)
+	mux.HandleFunc(topologyPath, server.handleTopology)
+	mux.HandleFunc(fileApiPathPrefix, server.handleFileApi)
+	// the request path is cleaned by serveFiles to prevent directory traversal
+	mux.Handle("/boot/", server.serveMemoryFiles(tftpFileServer))
+	mux.Handle(ztpPathPrefix, server.handleZtp(fileServer))
+	mux.Handle("/", fileServer)
 
 	server.server = &http.Server{
 		Addr:    fmt.Sprintf(":%s", config.HttpPort),
-		Handler: mux,
+		Handler: server.accessLog(mux),
 	}
 
 	return server
 }
 
+// isLeader checks if the replica has been elected as the leader
+func (s *httpServer) isLeader() bool {
+	select {
+	case <-s.elected:
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *httpServer) Run() {
+	go s.cleanupClients()
 	go func() {
 		s.log.Infof("Starting HTTP server on address %s , root path: %s", s.server.Addr, s.config.StoragePathHttp)
 		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
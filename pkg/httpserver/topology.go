@@ -0,0 +1,41 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/switchstatus"
+)
+
+// the topology built from the lldp neighbors of the switches, in the json or graphviz dot format
+// Example:
+//   - curl http://192.168.1.2/topology
+//   - curl "http://192.168.1.2/topology?format=dot" | dot -Tsvg > topology.svg
+const topologyPath = "/topology"
+
+// handleTopology exports the topology of the switches and the hosts
+func (s *httpServer) handleTopology(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	list := &topohubv1beta1.SwitchStatusList{}
+	if err := s.client.List(r.Context(), list); err != nil {
+		http.Error(w, fmt.Sprintf("failed to list switchstatus: %v", err), http.StatusInternalServerError)
+		return
+	}
+	topology := switchstatus.BuildTopology(list.Items)
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(topology)
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		_, _ = w.Write([]byte(topology.DOT()))
+	default:
+		http.Error(w, "unsupported format, it should be json or dot", http.StatusBadRequest)
+	}
+}
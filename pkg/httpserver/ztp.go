@@ -0,0 +1,104 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/switchztp"
+)
+
+// the ztp files, the urls in the dhcp options are resolved to the file rendered for the switch
+// Example:
+//   - GET http://192.168.1.2/ztp/ztp.json from 192.168.1.20 returns /ztp/00-11-22-aa-bb-cc/ztp.json
+const ztpPathPrefix = "/ztp/"
+
+// resolveZtpDir returns the directory of the switch which requests the ztp file, the switch is
+// identified by the mac address or the serial number in the DhcpLease of the client ip
+func (s *httpServer) resolveZtpDir(ctx context.Context, clientIP string) string {
+	if s.client == nil {
+		return ""
+	}
+	leaseList := &topohubv1beta1.DhcpLeaseList{}
+	if err := s.client.List(ctx, leaseList, client.MatchingLabels{topohubv1beta1.LabelIPAddr: clientIP}); err != nil {
+		s.log.Warnf("Failed to list the DhcpLease of %s: %v", clientIP, err)
+		return ""
+	}
+	for _, lease := range leaseList.Items {
+		candidates := []string{
+			switchztp.DeviceDirName(lease.Spec.MacAddr, ""),
+			switchztp.DeviceDirName("", switchztp.ParseSerialFromClientID(lease.Spec.ClientID)),
+		}
+		for _, dirName := range candidates {
+			if dirName == "" {
+				continue
+			}
+			if info, err := os.Stat(filepath.Join(s.config.StoragePathHttpZtp, dirName)); err == nil && info.IsDir() {
+				return dirName
+			}
+		}
+	}
+	return ""
+}
+
+// deviceZtpFile returns the rendered ztp file in the directory of the switch
+func (s *httpServer) deviceZtpFile(dirName string) string {
+	entries, err := os.ReadDir(filepath.Join(s.config.StoragePathHttpZtp, dirName))
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() {
+			return entry.Name()
+		}
+	}
+	return ""
+}
+
+// handleZtp serves the ztp file rendered for the switch, and falls back to the uploaded static file.
+// The fetches of the rendered files are reported to the SwitchZtp controller
+func (s *httpServer) handleZtp(fileServer http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.URL.Path = path.Clean(r.URL.Path)
+		requested := r.URL.Path
+		clientIP := remoteIP(r)
+
+		name := strings.TrimPrefix(requested, ztpPathPrefix)
+		for _, entry := range switchztp.ZtpEntryFiles {
+			if name != entry {
+				continue
+			}
+			if dirName := s.resolveZtpDir(r.Context(), clientIP); dirName != "" {
+				if file := s.deviceZtpFile(dirName); file != "" {
+					r.URL.Path = ztpPathPrefix + path.Join(dirName, file)
+				}
+			}
+			break
+		}
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		fileServer.ServeHTTP(rec, r)
+		if r.Method != http.MethodGet || rec.status >= http.StatusBadRequest {
+			return
+		}
+
+		// only the files in the directory of a switch are rendered
+		dirName, _, found := strings.Cut(strings.TrimPrefix(r.URL.Path, ztpPathPrefix), "/")
+		if !found {
+			return
+		}
+		if !switchztp.SendFetchEvent(s.ztpFetchEvents, switchztp.FetchEvent{
+			DirName: dirName,
+			IpAddr:  clientIP,
+			File:    requested,
+		}) {
+			s.log.Debugf("Dropped the ztp fetch of %s from %s", requested, clientIP)
+		}
+	})
+}
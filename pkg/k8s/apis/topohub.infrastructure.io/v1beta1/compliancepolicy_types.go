@@ -0,0 +1,145 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// the facts of the RedfishStatus are its status.info
+	ComplianceSourceRedfish = "redfish"
+	// the facts of the SSHStatus are its status.info, including the outputs of the SSHCollectors, and the
+	// flattened status.inventory, such as inventory.nics.<name>.firmware
+	ComplianceSourceSSH = "ssh"
+
+	ComplianceOperatorEqual    = "Equal"
+	ComplianceOperatorNotEqual = "NotEqual"
+	ComplianceOperatorIn       = "In"
+	ComplianceOperatorRegex    = "Regex"
+	// the numeric parts of the versions are compared in order, such as 5.15.0-91 >= 5.15.0-76
+	ComplianceOperatorVersionAtLeast = "VersionAtLeast"
+	ComplianceOperatorExists         = "Exists"
+
+	// HostConditionCompliant reports whether the host complies with the CompliancePolicies which select it,
+	// it is set on the RedfishStatus and SSHStatus
+	HostConditionCompliant = "Compliant"
+	// CompliancePolicyConditionEvaluated reports whether all the rules of the policy are valid and evaluated
+	CompliancePolicyConditionEvaluated = "Evaluated"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="CLUSTERNAME",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="HOSTS",type="integer",JSONPath=".status.hostAmount"
+// +kubebuilder:printcolumn:name="NONCOMPLIANT",type="integer",JSONPath=".status.nonCompliantAmount"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CompliancePolicy declares the expected facts of the hosts, the hosts are evaluated by the facts collected
+// in the RedfishStatus and SSHStatus
+type CompliancePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CompliancePolicySpec   `json:"spec"`
+	Status CompliancePolicyStatus `json:"status,omitempty"`
+}
+
+type CompliancePolicySpec struct {
+	// ClusterName limits the policy to the hosts of the cluster, the hosts of all the clusters are evaluated when it is empty
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Selector selects the RedfishStatus and SSHStatus by the labels
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// +kubebuilder:validation:MinItems=1
+	Rules []ComplianceRule `json:"rules"`
+}
+
+type ComplianceRule struct {
+	// Name identifies the rule in the report
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Source is the kind of the hosts which the rule applies to, redfish for the RedfishStatus and ssh for the SSHStatus
+	// +kubebuilder:validation:Enum=redfish;ssh
+	Source string `json:"source"`
+
+	// Fact is the key of the facts, the wildcard * matches any characters except /, such as inventory.nics.*.firmware.
+	// All the matched facts must satisfy the rule
+	// +kubebuilder:validation:MinLength=1
+	Fact string `json:"fact"`
+
+	// +kubebuilder:validation:Enum=Equal;NotEqual;In;Regex;VersionAtLeast;Exists
+	// +kubebuilder:default=Equal
+	// +optional
+	Operator string `json:"operator,omitempty"`
+
+	// Values are the expected values, only the first one is used except for the In operator
+	// +optional
+	Values []string `json:"values,omitempty"`
+}
+
+type CompliancePolicyStatus struct {
+	// HostAmount is the amount of the hosts selected by the policy
+	HostAmount int32 `json:"hostAmount"`
+	// +optional
+	NonCompliantAmount int32 `json:"nonCompliantAmount"`
+	// UnknownAmount is the amount of the unhealthy hosts, whose facts are not collected
+	// +optional
+	UnknownAmount int32 `json:"unknownAmount"`
+
+	// Clusters is the report of each cluster name
+	// +optional
+	Clusters []ComplianceClusterReport `json:"clusters,omitempty"`
+
+	// NonCompliantHosts are the violations of the non-compliant hosts, at most 100 hosts are reported
+	// +optional
+	NonCompliantHosts []ComplianceHostReport `json:"nonCompliantHosts,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type ComplianceClusterReport struct {
+	ClusterName        string `json:"clusterName"`
+	HostAmount         int32  `json:"hostAmount"`
+	CompliantAmount    int32  `json:"compliantAmount"`
+	NonCompliantAmount int32  `json:"nonCompliantAmount"`
+	UnknownAmount      int32  `json:"unknownAmount"`
+	// NonCompliantHosts are the names of the non-compliant hosts
+	// +optional
+	NonCompliantHosts []string `json:"nonCompliantHosts,omitempty"`
+}
+
+type ComplianceHostReport struct {
+	Name string `json:"name"`
+	// Kind is RedfishStatus or SSHStatus
+	Kind string `json:"kind"`
+	// +optional
+	ClusterName string                `json:"clusterName,omitempty"`
+	Violations  []ComplianceViolation `json:"violations"`
+}
+
+type ComplianceViolation struct {
+	Rule string `json:"rule"`
+	Fact string `json:"fact"`
+	// Expected describes the operator and the values of the rule
+	Expected string `json:"expected"`
+	// Actual is the value of the fact, it is empty when the fact is not found
+	// +optional
+	Actual string `json:"actual,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CompliancePolicyList include CompliancePolicy objects
+type CompliancePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []CompliancePolicy `json:"items"`
+}
@@ -0,0 +1,74 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelMacAddr is the mac address of the dhcp client, with ":" replaced by "-"
+	LabelMacAddr = GroupName + "/mac-addr"
+	// LabelDhcpLease records the name of the DhcpLease which a BindingIp is converted from
+	LabelDhcpLease = GroupName + "/dhcplease"
+
+	// AnnotationDhcpLeaseBindIp converts the DhcpLease into a BindingIp when its value is "true"
+	AnnotationDhcpLeaseBindIp = GroupName + "/bind-ip"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="SUBNET",type="string",JSONPath=".spec.subnet"
+// +kubebuilder:printcolumn:name="IPADDR",type="string",JSONPath=".spec.ipAddr"
+// +kubebuilder:printcolumn:name="MACADDR",type="string",JSONPath=".spec.macAddr"
+// +kubebuilder:printcolumn:name="HOSTNAME",type="string",JSONPath=".spec.hostname"
+// +kubebuilder:printcolumn:name="EXPIRE",type="string",JSONPath=".spec.expireTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// DhcpLease is an active lease of the dhcp server, which is maintained by the dhcp server of the subnet
+type DhcpLease struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec DhcpLeaseSpec `json:"spec"`
+}
+
+type DhcpLeaseSpec struct {
+	// subnet name
+	// +kubebuilder:validation:Required
+	Subnet string `json:"subnet"`
+
+	// IP address assigned to the client
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,3}\.){3}[0-9]{1,3}$`
+	IpAddr string `json:"ipAddr"`
+
+	// Mac address of the client
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}([0-9a-fA-F]{2})$`
+	MacAddr string `json:"macAddr"`
+
+	// hostname reported by the client
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// expire time of the lease, in RFC3339 format
+	// +optional
+	ExpireTime string `json:"expireTime,omitempty"`
+
+	// vendor class (option 60) reported by the client
+	// +optional
+	VendorClass string `json:"vendorClass,omitempty"`
+
+	// client identifier (option 61) reported by the client
+	// +optional
+	ClientID string `json:"clientId,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DhcpLeaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []DhcpLease `json:"items"`
+}
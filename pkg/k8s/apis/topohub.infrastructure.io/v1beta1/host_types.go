@@ -0,0 +1,111 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="UUID",type="string",JSONPath=".spec.systemUUID"
+// +kubebuilder:printcolumn:name="SERIAL",type="string",JSONPath=".spec.serialNumber"
+// +kubebuilder:printcolumn:name="CLUSTERNAME",type="string",JSONPath=".status.clusterName"
+// +kubebuilder:printcolumn:name="BMC",type="string",JSONPath=".status.bmc.ipAddr"
+// +kubebuilder:printcolumn:name="SSH",type="string",JSONPath=".status.ssh.ipAddr"
+// +kubebuilder:printcolumn:name="MAINTENANCE",type="string",JSONPath=".spec.maintenance.owner"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Host is the identity of a physical server, which links its RedfishStatus, SSHStatus, DhcpLeases and BindingIps.
+// It is keyed by the system uuid or the serial number, so it stays the same when the BMC IP changes
+type Host struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostSpec   `json:"spec"`
+	Status HostStatus `json:"status,omitempty"`
+}
+
+type HostSpec struct {
+	// SystemUUID is the uuid of the computer system, which is reported by the redfish or the DMI
+	// +optional
+	SystemUUID string `json:"systemUUID,omitempty"`
+
+	// SerialNumber is the serial number of the computer system or the chassis
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// Maintenance cordons the host, the disruptive HostOperations are only allowed on the hosts in maintenance
+	// unless they are forced
+	// +optional
+	Maintenance *HostMaintenance `json:"maintenance,omitempty"`
+}
+
+// HostMaintenance records who cordons the host and why
+type HostMaintenance struct {
+	// Reason is why the host is in maintenance
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Reason string `json:"reason"`
+
+	// Owner is the person or the team working on the host
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Owner string `json:"owner"`
+}
+
+type HostStatus struct {
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// BMC is the RedfishStatus of the host
+	// +optional
+	BMC *HostRecordRef `json:"bmc,omitempty"`
+
+	// SSH is the SSHStatus of the host
+	// +optional
+	SSH *HostRecordRef `json:"ssh,omitempty"`
+
+	// DhcpLeases are the leases of the mac addresses of the BMC and the nics
+	// +optional
+	DhcpLeases []HostAddressRef `json:"dhcpLeases,omitempty"`
+
+	// BindingIps are the bindings of the mac addresses of the BMC and the nics
+	// +optional
+	BindingIps []HostAddressRef `json:"bindingIps,omitempty"`
+
+	// PreviousBmcIPs are the previous IP addresses of the BMC, the latest one comes first
+	// +optional
+	PreviousBmcIPs []string `json:"previousBmcIPs,omitempty"`
+
+	// LastUpdateTime is the time when the links change
+	// +optional
+	LastUpdateTime string `json:"lastUpdateTime,omitempty"`
+}
+
+// HostRecordRef refers to the RedfishStatus or SSHStatus of the host
+type HostRecordRef struct {
+	Name   string `json:"name"`
+	IpAddr string `json:"ipAddr"`
+	// +optional
+	MacAddr string `json:"macAddr,omitempty"`
+}
+
+// HostAddressRef refers to the DhcpLease or BindingIp of the host
+type HostAddressRef struct {
+	Name    string `json:"name"`
+	Subnet  string `json:"subnet"`
+	IpAddr  string `json:"ipAddr"`
+	MacAddr string `json:"macAddr"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HostList include Host objects
+type HostList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Host `json:"items"`
+}
@@ -43,6 +43,16 @@ func (in *HostEndpointSpec) DeepCopyInto(out *HostEndpointSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.SSHHostKey != nil {
+		in, out := &in.SSHHostKey, &out.SSHHostKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.SSHJumpHost != nil {
+		in, out := &in.SSHJumpHost, &out.SSHJumpHost
+		*out = new(SSHJumpHost)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostEndpointSpec.
@@ -10,6 +10,14 @@ const (
 	EndpointTypeRedfish = "redfish"
 	// EndpointTypeSSH represents SSH management interface
 	EndpointTypeSSH = "ssh"
+	// EndpointTypeSwitch represents the SSH management interface of a switch, whose LLDP neighbors are collected
+	EndpointTypeSwitch = "switch"
+
+	// AnnotationPhoneHome marks the HostEndpoint registered by the installer phone-home, the value is the time
+	// of the last registration. Such HostEndpoint is allowed to be updated by the next registration
+	AnnotationPhoneHome = GroupName + "/phone-home"
+	// AnnotationInbandIPs records the in-band ip addresses reported by the installer phone-home, separated by comma
+	AnnotationInbandIPs = GroupName + "/inband-ips"
 )
 
 // +genclient
@@ -55,11 +63,20 @@ type HostEndpointSpec struct {
 	// +kubebuilder:default=443
 	Port *int32 `json:"port,omitempty"`
 
-	// Type specifies the endpoint type, either redfish or ssh
+	// Type specifies the endpoint type, redfish, ssh or switch
 	// +optional
 	// +kubebuilder:default=redfish
-	// +kubebuilder:validation:Enum=redfish;ssh
+	// +kubebuilder:validation:Enum=redfish;ssh;switch
 	Type *string `json:"type,omitempty"`
+
+	// SSHHostKey is the public host key of the ssh server in the authorized_keys format,
+	// the ssh connection is rejected when the host key does not match
+	// +optional
+	SSHHostKey *string `json:"sshHostKey,omitempty"`
+
+	// SSHJumpHost is the bastion through which the ssh or switch endpoint is connected
+	// +optional
+	SSHJumpHost *SSHJumpHost `json:"sshJumpHost,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,101 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// LabelHostGroupAutoCreated marks the HostGroup created by topohub for the cluster name found on the hosts,
+	// it is deleted when the cluster has no host
+	LabelHostGroupAutoCreated = GroupName + "/auto-created"
+
+	// the components of the firmware versions in the status of the HostGroup, the nic firmware is
+	// reported as NIC/<driver>
+	FirmwareComponentBIOS = "BIOS"
+	FirmwareComponentBMC  = "BMC"
+	FirmwareComponentNIC  = "NIC"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="CLUSTERNAME",type="string",JSONPath=".spec.clusterName"
+// +kubebuilder:printcolumn:name="HOSTS",type="integer",JSONPath=".status.hostAmount"
+// +kubebuilder:printcolumn:name="HEALTHY",type="integer",JSONPath=".status.healthyAmount"
+// +kubebuilder:printcolumn:name="UNHEALTHY",type="integer",JSONPath=".status.unhealthyAmount"
+// +kubebuilder:printcolumn:name="WARNING",type="integer",JSONPath=".status.warningLogAmount"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// HostGroup summarizes the hosts, switches and subnets of a cluster name, it is created for each cluster name
+// found on the hosts automatically, and can also be created manually
+type HostGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostGroupSpec   `json:"spec"`
+	Status HostGroupStatus `json:"status,omitempty"`
+}
+
+type HostGroupSpec struct {
+	// ClusterName is the cluster name of the hosts summarized by the HostGroup, it defaults to the name of the HostGroup
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+}
+
+type HostGroupStatus struct {
+	// HostAmount is the amount of the RedfishStatus and SSHStatus of the cluster
+	HostAmount int32 `json:"hostAmount"`
+	// +optional
+	RedfishHostAmount int32 `json:"redfishHostAmount"`
+	// +optional
+	SSHHostAmount int32 `json:"sshHostAmount"`
+	// +optional
+	HealthyAmount int32 `json:"healthyAmount"`
+	// +optional
+	UnhealthyAmount int32 `json:"unhealthyAmount"`
+	// +optional
+	SwitchAmount int32 `json:"switchAmount"`
+	// +optional
+	HealthySwitchAmount int32 `json:"healthySwitchAmount"`
+
+	// PowerStates counts the power states of the redfish hosts, such as On and Off
+	// +optional
+	PowerStates map[string]int32 `json:"powerStates,omitempty"`
+
+	// WarningLogAmount is the sum of the warning logs of the hosts
+	// +optional
+	WarningLogAmount int32 `json:"warningLogAmount"`
+
+	// FirmwareVersions counts the hosts by the firmware versions of the BIOS, the BMC and the nics
+	// +optional
+	FirmwareVersions []HostGroupFirmwareVersion `json:"firmwareVersions,omitempty"`
+
+	// Subnets are the subnets serving the cluster, whose default cluster name is the cluster, or which
+	// the hosts belong to
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+
+	// LastUpdateTime is the time when the summary changes
+	// +optional
+	LastUpdateTime string `json:"lastUpdateTime,omitempty"`
+}
+
+type HostGroupFirmwareVersion struct {
+	// Component is BIOS, BMC or NIC/<driver>
+	Component string `json:"component"`
+	Version   string `json:"version"`
+	// Amount is the amount of the hosts with the version
+	Amount int32 `json:"amount"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HostGroupList include HostGroup objects
+type HostGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []HostGroup `json:"items"`
+}
@@ -7,6 +7,8 @@ import (
 
 const (
 	HostOperationStatusPending = "pending"
+	// the ssh action is running, it is not run again when the controller restarts
+	HostOperationStatusRunning = "running"
 	HostOperationStatusSuccess = "success"
 	HostOperationStatusFailed  = "failure"
 )
@@ -30,6 +32,14 @@ const (
 	BootCmdResetPxeOnce string = "PxeReboot"
 )
 
+const (
+	// the actions of the ssh hosts, the GracefulShutdown and GracefulRestart are also supported by the ssh hosts
+	// "RunScript" runs a script under the tools directory of the http server, whose sha256 is pinned in the spec
+	SSHCmdRunScript = "RunScript"
+	// "PushFile" copies a file under the http directory to the host
+	SSHCmdPushFile = "PushFile"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -39,6 +49,7 @@ const (
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.status"
 // +kubebuilder:printcolumn:name="CLUSTERNAME",type="string",JSONPath=".status.clusterName"
 // +kubebuilder:printcolumn:name="HOSTIP",type="string",JSONPath=".status.ipAddr"
+// +kubebuilder:printcolumn:name="EXITCODE",type="integer",JSONPath=".status.exitCode",priority=1
 
 type HostOperation struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -49,16 +60,76 @@ type HostOperation struct {
 }
 
 type HostOperationSpec struct {
-	// +kubebuilder:validation:Enum=ForceOn;On;ForceOff;GracefulShutdown;ForceRestart;GracefulRestart;PxeReboot
+	// +kubebuilder:validation:Enum=ForceOn;On;ForceOff;GracefulShutdown;ForceRestart;GracefulRestart;PxeReboot;RunScript;PushFile
 	// +kubebuilder:validation:Required
 	Action string `json:"action"`
 
+	// RedfishStatusName is the bmc host to operate by the redfish, either it or SSHStatusName is required
+	// +optional
+	RedfishStatusName string `json:"redfishStatusName,omitempty"`
+
+	// SSHStatusName is the host to operate by the ssh, which supports GracefulShutdown, GracefulRestart, RunScript and PushFile
+	// +optional
+	SSHStatusName string `json:"sshStatusName,omitempty"`
+
+	// Script is required by the RunScript action
+	// +optional
+	Script *HostOperationScript `json:"script,omitempty"`
+
+	// File is required by the PushFile action
+	// +optional
+	File *HostOperationFile `json:"file,omitempty"`
+
+	// TimeoutSeconds is the timeout of the ssh action
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=300
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// Force allows the disruptive action on the host which is not in maintenance
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
+// HostOperationScript is the script under the tools directory of the http server, which is sent to the host
+// by the ssh and run by bash. The script is approved by its sha256, and it is not run when the content changes
+type HostOperationScript struct {
+	// Name is the path relative to the tools directory, such as system/set-netplan.sh
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Sha256 is the hex sha256 of the approved script
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{64}$`
 	// +kubebuilder:validation:Required
-	RedfishStatusName string `json:"redfishStatusName"`
+	Sha256 string `json:"sha256"`
+
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env is the environment variables of the script, such as the GPU_NIC_LIST of set-rdma-qos.sh
+	// +optional
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// HostOperationFile is the file under the http directory, which is copied to the host by the ssh
+type HostOperationFile struct {
+	// Source is the path relative to the http directory, such as ztp/ztp.json
+	// +kubebuilder:validation:Required
+	Source string `json:"source"`
+
+	// Destination is the absolute path on the host
+	// +kubebuilder:validation:Required
+	Destination string `json:"destination"`
+
+	// Mode is the octal permission of the file
+	// +kubebuilder:validation:Pattern=`^0?[0-7]{3}$`
+	// +kubebuilder:default="0644"
+	// +optional
+	Mode string `json:"mode,omitempty"`
 }
 
 type HostOperationStatus struct {
-	// +kubebuilder:validation:Enum=pending;success;failure
+	// +kubebuilder:validation:Enum=pending;running;success;failure
 	Status string `json:"status,omitempty"`
 
 	Message string `json:"message,omitempty"`
@@ -68,6 +139,22 @@ type HostOperationStatus struct {
 	ClusterName string `json:"clusterName,omitempty"`
 
 	IpAddr string `json:"ipAddr,omitempty"`
+
+	// ExitCode is the exit code of the ssh action
+	// +optional
+	ExitCode *int32 `json:"exitCode,omitempty"`
+
+	// Stdout is the tail of the stdout of the ssh action
+	// +optional
+	Stdout string `json:"stdout,omitempty"`
+
+	// Stderr is the tail of the stderr of the ssh action
+	// +optional
+	Stderr string `json:"stderr,omitempty"`
+
+	// DrainStartTime is the time when the node of the host starts to be drained before the disruptive action
+	// +optional
+	DrainStartTime string `json:"drainStartTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,109 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AutoInstallTypeKickstart   = "Kickstart"
+	AutoInstallTypeAutoinstall = "Autoinstall"
+	AutoInstallTypeCloudInit   = "CloudInit"
+
+	// ProvisionProfileConditionRendered reports whether the boot configs of the profile are rendered
+	ProvisionProfileConditionRendered = "Rendered"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="KERNEL",type="string",JSONPath=".spec.kernel"
+// +kubebuilder:printcolumn:name="AUTOINSTALL",type="string",JSONPath=".spec.autoInstall.type"
+// +kubebuilder:printcolumn:name="HOSTS",type="integer",JSONPath=".status.hostAmount"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ProvisionProfile describes the operating system installed by PXE for the assigned hosts
+type ProvisionProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProvisionProfileSpec   `json:"spec"`
+	Status ProvisionProfileStatus `json:"status,omitempty"`
+}
+
+type ProvisionProfileSpec struct {
+	// path of the kernel, relative to the http/iso directory
+	// +kubebuilder:validation:Required
+	Kernel string `json:"kernel"`
+
+	// path of the initrd, relative to the http/iso directory
+	// +kubebuilder:validation:Required
+	Initrd string `json:"initrd"`
+
+	// path of the iso, relative to the http/iso directory, which could be referred by {{ .IsoUrl }} in the kernel args
+	// +optional
+	Iso *string `json:"iso,omitempty"`
+
+	// kernel args, which is a go template with the variables: .HttpServer, .IsoUrl, .AutoInstallUrl, .MacAddr, .IpAddr, .Hostname, .Subnet, .CallbackToken
+	// +optional
+	KernelArgs string `json:"kernelArgs,omitempty"`
+
+	// the template of the unattended installation
+	// +optional
+	AutoInstall *AutoInstallSpec `json:"autoInstall,omitempty"`
+
+	// the hosts which install the operating system of the profile
+	// +kubebuilder:validation:Required
+	Hosts ProvisionHostSelector `json:"hosts"`
+}
+
+type AutoInstallSpec struct {
+	// type of the template, the kickstart is rendered to ks.cfg, and the others are rendered to the user-data of nocloud
+	// +kubebuilder:validation:Enum=Kickstart;Autoinstall;CloudInit
+	// +kubebuilder:validation:Required
+	Type string `json:"type"`
+
+	// content of the template, which is a go template with the same variables as the kernel args
+	// +kubebuilder:validation:Required
+	Template string `json:"template"`
+}
+
+// ProvisionHostSelector selects the hosts by the mac address of the pxe interface.
+// The ip, hostname and subnet of the host are looked up from the BindingIp and DhcpLease objects
+type ProvisionHostSelector struct {
+	// mac addresses of the hosts
+	// +optional
+	MacAddrs []string `json:"macAddrs,omitempty"`
+
+	// select the BindingIp and DhcpLease objects by labels
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+type ProvisionProfileStatus struct {
+	// amount of the hosts whose boot configs are rendered
+	HostAmount int32 `json:"hostAmount"`
+
+	// the hosts whose boot configs are rendered
+	// +optional
+	Hosts []ProvisionHost `json:"hosts,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type ProvisionHost struct {
+	MacAddr  string `json:"macAddr"`
+	IpAddr   string `json:"ipAddr"`
+	Hostname string `json:"hostname,omitempty"`
+	Subnet   string `json:"subnet"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ProvisionProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ProvisionProfile `json:"items"`
+}
@@ -4,6 +4,39 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// AnnotationGCProtect protects the redfishstatus from the garbage collection when set to "true"
+	AnnotationGCProtect = GroupName + "/gc-protect"
+	// LabelArchivedRedfishStatus is set on the configmap which archives the redfishstatus
+	LabelArchivedRedfishStatus = GroupName + "/archived-redfishstatus"
+
+	GCActionDelete  = "Delete"
+	GCActionArchive = "Archive"
+
+	// the naming strategies of the redfishstatus objects created for the dhcp clients
+	NamingStrategyIP       = "ip"
+	NamingStrategyMAC      = "mac"
+	NamingStrategyHostname = "hostname"
+	NamingStrategySerial   = "serial"
+	NamingStrategyTemplate = "template"
+
+	// AnnotationProvisionMac specifies the mac addresses of the pxe interfaces of the host, separated by comma.
+	// When it is not set, the mac addresses of the ethernet interfaces reported by the bmc are used
+	AnnotationProvisionMac = GroupName + "/provision-mac"
+
+	// AnnotationNodeName is the kubernetes node of the host, which is found by the node integration
+	AnnotationNodeName = GroupName + "/node-name"
+)
+
+const (
+	// the phases of the provisioning lifecycle, in order
+	ProvisionPhasePowerCycled       = "PowerCycled"
+	ProvisionPhaseDhcpSeen          = "DhcpSeen"
+	ProvisionPhaseBootFileFetched   = "BootFileFetched"
+	ProvisionPhaseInstallerCallback = "InstallerCallback"
+	ProvisionPhaseOsReady           = "OsReady"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -14,6 +47,7 @@ import (
 // +kubebuilder:printcolumn:name="IPADDR",type="string",JSONPath=".status.basic.ipAddr"
 // +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.basic.type"
 // +kubebuilder:printcolumn:name="WARNING",type="string",JSONPath=".status.log.warningLogAccount"
+// +kubebuilder:printcolumn:name="PROVISION",type="string",JSONPath=".status.provision.phase"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 type RedfishStatus struct {
@@ -24,11 +58,62 @@ type RedfishStatus struct {
 }
 
 type RedfishStatusStatus struct {
-	Healthy        bool              `json:"healthy"`
-	LastUpdateTime string            `json:"lastUpdateTime"`
+	Healthy        bool   `json:"healthy"`
+	LastUpdateTime string `json:"lastUpdateTime"`
+	// UnhealthySince is the time since when the host is unhealthy
+	// +optional
+	UnhealthySince *string           `json:"unhealthySince,omitempty"`
 	Basic          BasicInfo         `json:"basic"`
 	Info           map[string]string `json:"info"`
 	Log            LogStruct         `json:"log"`
+	// Provision records the provisioning lifecycle since the last pxe reboot
+	// +optional
+	Provision *ProvisionStatus `json:"provision,omitempty"`
+	// Conditions reports the Compliant condition set by the CompliancePolicies
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// PreviousIpAddrs are the previous ip addresses of the dhcp client, the latest one comes first. They are only
+	// recorded when the redfishstatus is not named by the ip address
+	// +optional
+	PreviousIpAddrs []PreviousIpAddr `json:"previousIpAddrs,omitempty"`
+	// PreviousNames are the names of the redfishstatus before it is renamed by the naming strategy, the latest one comes first
+	// +optional
+	PreviousNames []string `json:"previousNames,omitempty"`
+}
+
+// PreviousIpAddr is an ip address used by the BMC before
+type PreviousIpAddr struct {
+	IpAddr string `json:"ipAddr"`
+	// ChangeTime is the time when the BMC changes to another ip address
+	ChangeTime string `json:"changeTime"`
+}
+
+// ProvisionStatus tracks the host from the pxe reboot to the os ready
+type ProvisionStatus struct {
+	// +kubebuilder:validation:Enum=PowerCycled;DhcpSeen;BootFileFetched;InstallerCallback;OsReady
+	Phase string `json:"phase"`
+	// HostOperation is the name of the HostOperation which starts the provisioning
+	// +optional
+	HostOperation string `json:"hostOperation,omitempty"`
+	StartTime     string `json:"startTime"`
+	// MacAddr is the mac address of the pxe interface, which is learned from the dhcp request
+	// +optional
+	MacAddr string `json:"macAddr,omitempty"`
+	// IpAddr is the ip address of the host in the provisioning network
+	// +optional
+	IpAddr string `json:"ipAddr,omitempty"`
+	// Phases records the time of each phase which has been reached
+	// +optional
+	Phases []ProvisionPhaseRecord `json:"phases,omitempty"`
+}
+
+type ProvisionPhaseRecord struct {
+	Phase string `json:"phase"`
+	Time  string `json:"time"`
+	// ElapsedSeconds is the duration since the start of the provisioning
+	ElapsedSeconds int64 `json:"elapsedSeconds"`
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type LogStruct struct {
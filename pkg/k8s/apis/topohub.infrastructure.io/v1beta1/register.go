@@ -38,6 +38,30 @@ const (
 
 	// KindSSHStatus is the kind name for SSHStatus resource
 	KindSSHStatus = "SSHStatus"
+
+	// KindDhcpLease is the kind name for DhcpLease resource
+	KindDhcpLease = "DhcpLease"
+
+	// KindProvisionProfile is the kind name for ProvisionProfile resource
+	KindProvisionProfile = "ProvisionProfile"
+
+	// KindSwitchZtp is the kind name for SwitchZtp resource
+	KindSwitchZtp = "SwitchZtp"
+
+	// KindSwitchStatus is the kind name for SwitchStatus resource
+	KindSwitchStatus = "SwitchStatus"
+
+	// KindSSHCollector is the kind name for SSHCollector resource
+	KindSSHCollector = "SSHCollector"
+
+	// KindCompliancePolicy is the kind name for CompliancePolicy resource
+	KindCompliancePolicy = "CompliancePolicy"
+
+	// KindHostGroup is the kind name for HostGroup resource
+	KindHostGroup = "HostGroup"
+
+	// KindHost is the kind name for Host resource
+	KindHost = "Host"
 )
 
 var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: Version}
@@ -69,4 +93,12 @@ func init() {
 	SchemeBuilder.Register(&HostOperation{}, &HostOperationList{})
 	SchemeBuilder.Register(&BindingIp{}, &BindingIpList{})
 	SchemeBuilder.Register(&SSHStatus{}, &SSHStatusList{})
+	SchemeBuilder.Register(&DhcpLease{}, &DhcpLeaseList{})
+	SchemeBuilder.Register(&ProvisionProfile{}, &ProvisionProfileList{})
+	SchemeBuilder.Register(&SwitchZtp{}, &SwitchZtpList{})
+	SchemeBuilder.Register(&SwitchStatus{}, &SwitchStatusList{})
+	SchemeBuilder.Register(&SSHCollector{}, &SSHCollectorList{})
+	SchemeBuilder.Register(&CompliancePolicy{}, &CompliancePolicyList{})
+	SchemeBuilder.Register(&HostGroup{}, &HostGroupList{})
+	SchemeBuilder.Register(&Host{}, &HostList{})
 }
@@ -0,0 +1,70 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// the output is stored as it is in the key <name>/output
+	SSHCollectorParserRaw = "raw"
+	// the output is a json object, each field is stored in the key <name>/<field>
+	SSHCollectorParserJson = "json"
+	// each line of the output is key=value, which is stored in the key <name>/<key>
+	SSHCollectorParserKeyValue = "keyValue"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="PARSER",type="string",JSONPath=".spec.parser"
+// +kubebuilder:printcolumn:name="INTERVAL",type="integer",JSONPath=".spec.intervalSeconds"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SSHCollector defines a command which is run on the selected ssh hosts by the sshstatus controller, the parsed
+// output is written into the status.info of the SSHStatus with the keys prefixed by the name of the SSHCollector
+type SSHCollector struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SSHCollectorSpec `json:"spec"`
+}
+
+type SSHCollectorSpec struct {
+	// Command is run by the login shell of the ssh user on the host
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Command string `json:"command"`
+
+	// Parser decides how the output is written into the status.info
+	// +kubebuilder:validation:Enum=raw;json;keyValue
+	// +kubebuilder:default=raw
+	// +optional
+	Parser string `json:"parser,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=30
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// IntervalSeconds is the minimum interval between two runs on the same host. The command runs at each update
+	// of the SSHStatus when it is 0
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=300
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+
+	// Selector selects the SSHStatus by the labels, all the ssh hosts are selected when it is empty
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SSHCollectorList include SSHCollector objects
+type SSHCollectorList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SSHCollector `json:"items"`
+}
@@ -6,6 +6,20 @@ import (
 
 const (
 	HostTypeSSH = "ssh"
+
+	// SSHStatusConditionConnected reports whether the ssh connection to the host is established, the reason
+	// categorizes the failure
+	SSHStatusConditionConnected = "Connected"
+
+	SSHReasonConnected = "Connected"
+	// SSHReasonAuthFailed means the credentials are rejected by the host or the jump host
+	SSHReasonAuthFailed = "AuthFailed"
+	// SSHReasonNetworkError means the host or the jump host is unreachable, or the connection times out
+	SSHReasonNetworkError = "NetworkError"
+	// SSHReasonHostKeyMismatch means the host key does not match the pinned one
+	SSHReasonHostKeyMismatch = "HostKeyMismatch"
+	// SSHReasonConfigError means the credentials or the host key in the configuration are invalid
+	SSHReasonConfigError = "ConfigError"
 )
 
 // +genclient
@@ -17,6 +31,7 @@ const (
 // +kubebuilder:printcolumn:name="HEALTHY",type="boolean",JSONPath=".status.healthy"
 // +kubebuilder:printcolumn:name="IPADDR",type="string",JSONPath=".status.basic.ipAddr"
 // +kubebuilder:printcolumn:name="TYPE",type="string",JSONPath=".status.basic.type"
+// +kubebuilder:printcolumn:name="WARNING",type="string",JSONPath=".status.log.warningLogAccount"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 type SSHStatus struct {
@@ -31,6 +46,114 @@ type SSHStatusStatus struct {
 	LastUpdateTime string            `json:"lastUpdateTime"`
 	Basic          SSHBasicInfo      `json:"basic"`
 	Info           map[string]string `json:"info"`
+	// Inventory is the hardware of the host collected from the sysfs, ip and lsblk
+	// +optional
+	Inventory *SSHInventory `json:"inventory,omitempty"`
+	// Log is the hardware errors collected from the kernel journal of the host
+	// +optional
+	Log SSHLogStruct `json:"log,omitempty"`
+	// Conditions reports the state of the ssh connection, and the Compliant condition set by the CompliancePolicies
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// SSHInventory is the structured in-band inventory of the host
+type SSHInventory struct {
+	// +optional
+	SystemVendor string `json:"systemVendor,omitempty"`
+	// +optional
+	SystemProduct string `json:"systemProduct,omitempty"`
+	// SystemSerial is the serial number in the DMI, which requires the root privilege to read
+	// +optional
+	SystemSerial string `json:"systemSerial,omitempty"`
+	// SystemUUID is the uuid in the DMI, which requires the root privilege to read
+	// +optional
+	SystemUUID string `json:"systemUUID,omitempty"`
+	// Nics are the physical network interfaces
+	// +optional
+	Nics []SSHNicInfo `json:"nics,omitempty"`
+	// +optional
+	RdmaDevices []SSHRdmaDevice `json:"rdmaDevices,omitempty"`
+	// +optional
+	NumaNodes []SSHNumaNode `json:"numaNodes,omitempty"`
+	// Gpus are the nvidia gpus reported by the nvidia-smi
+	// +optional
+	Gpus []SSHGpuInfo `json:"gpus,omitempty"`
+	// BlockDevices are the disks reported by the lsblk
+	// +optional
+	BlockDevices []SSHBlockDevice `json:"blockDevices,omitempty"`
+}
+
+type SSHNicInfo struct {
+	Name    string `json:"name"`
+	MacAddr string `json:"macAddr,omitempty"`
+	// +optional
+	Driver string `json:"driver,omitempty"`
+	// +optional
+	Firmware string `json:"firmware,omitempty"`
+	// SpeedMbps is the negotiated speed, it is 0 when the link is down
+	// +optional
+	SpeedMbps int32 `json:"speedMbps,omitempty"`
+	// +optional
+	PciAddr string `json:"pciAddr,omitempty"`
+	// +optional
+	NumaNode *int32 `json:"numaNode,omitempty"`
+}
+
+type SSHRdmaDevice struct {
+	Name string `json:"name"`
+	// Nic is the network interface of the rdma device
+	// +optional
+	Nic string `json:"nic,omitempty"`
+	// LinkLayer is InfiniBand or Ethernet
+	// +optional
+	LinkLayer string `json:"linkLayer,omitempty"`
+	// +optional
+	PciAddr string `json:"pciAddr,omitempty"`
+}
+
+type SSHNumaNode struct {
+	ID int32 `json:"id"`
+	// Cpus is the cpu list, such as 0-31,64-95
+	// +optional
+	Cpus string `json:"cpus,omitempty"`
+	// +optional
+	MemoryMiB int64 `json:"memoryMiB,omitempty"`
+}
+
+type SSHGpuInfo struct {
+	Model string `json:"model"`
+	// +optional
+	UUID string `json:"uuid,omitempty"`
+	// +optional
+	DriverVersion string `json:"driverVersion,omitempty"`
+	// +optional
+	PciAddr string `json:"pciAddr,omitempty"`
+	// +optional
+	MemoryMiB int64 `json:"memoryMiB,omitempty"`
+}
+
+type SSHBlockDevice struct {
+	Name string `json:"name"`
+	// +optional
+	Model string `json:"model,omitempty"`
+	// +optional
+	Serial string `json:"serial,omitempty"`
+	// +optional
+	SizeBytes int64 `json:"sizeBytes,omitempty"`
+	// +optional
+	Rotational bool `json:"rotational,omitempty"`
+	// Transport is the transport of the device, such as sata, sas and nvme
+	// +optional
+	Transport string `json:"transport,omitempty"`
+}
+
+// SSHLogStruct counts the logs collected incrementally from the journal
+type SSHLogStruct struct {
+	LogStruct `json:",inline"`
+	// Cursor is the journal cursor of the last collected entry, the next collection starts after it
+	// +optional
+	Cursor string `json:"cursor,omitempty"`
 }
 
 // SSHBasicInfo incluse SSH connection basic info
@@ -45,6 +168,30 @@ type SSHBasicInfo struct {
 	// SubnetName is the name of the subnet this host belongs to
 	// +optional
 	SubnetName *string `json:"subnetName,omitempty"`
+	// SSHHostKey is the public host key of the ssh server, which is verified when it is not empty
+	// +optional
+	SSHHostKey string `json:"sshHostKey,omitempty"`
+	// JumpHost is the bastion through which the host is connected
+	// +optional
+	JumpHost *SSHJumpHost `json:"jumpHost,omitempty"`
+}
+
+// SSHJumpHost is the bastion host which forwards the ssh connection to the target host, like the ProxyJump of openssh
+type SSHJumpHost struct {
+	// IPAddr is the address of the jump host
+	IPAddr string `json:"ipAddr"`
+	// +optional
+	// +kubebuilder:default=22
+	Port int32 `json:"port,omitempty"`
+	// SecretName is the name of the secret containing the credentials of the jump host,
+	// the credentials of the target host are used when it is empty
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+	// +optional
+	SecretNamespace string `json:"secretNamespace,omitempty"`
+	// SSHHostKey is the public host key of the jump host in the authorized_keys format, which is verified when it is not empty
+	// +optional
+	SSHHostKey string `json:"sshHostKey,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
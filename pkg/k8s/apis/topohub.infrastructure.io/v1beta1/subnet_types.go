@@ -4,6 +4,22 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// SubnetConditionIPConflict reports the ip address conflicts found by the arp scan
+	SubnetConditionIPConflict = "IPConflict"
+	// SubnetConditionPoolNearlyFull reports the utilization of the ip pool exceeds the nearly full threshold
+	SubnetConditionPoolNearlyFull = "PoolNearlyFull"
+	// SubnetConditionPoolExhausted reports the utilization of the ip pool exceeds the exhausted threshold
+	SubnetConditionPoolExhausted = "PoolExhausted"
+
+	// AnnotationAllowIPRangeShrink allows to shrink the ip range of the subnet when its value is "true"
+	AnnotationAllowIPRangeShrink = GroupName + "/allow-ip-range-shrink"
+
+	// AnnotationDiscoveryApprove approves the discovered hosts of the subnet to be registered,
+	// the value is "all" or a list of ip separated by ","
+	AnnotationDiscoveryApprove = GroupName + "/discovery-approve"
+)
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -87,11 +103,160 @@ type FeatureSpec struct {
 	// +kubebuilder:default=false
 	// +optional
 	EnableDhcpTrustedOnly bool `json:"enableDhcpTrustedOnly"`
+
+	// IP conflict detection configuration
+	// +optional
+	ConflictDetection *ConflictDetectionSpec `json:"conflictDetection,omitempty"`
+
+	// Discovery configuration for the hosts with static ip
+	// +optional
+	Discovery *DiscoverySpec `json:"discovery,omitempty"`
+
+	// Utilization thresholds of the ip pool
+	// +optional
+	PoolThreshold *PoolThresholdSpec `json:"poolThreshold,omitempty"`
+
+	// Boot files for each client architecture when the pxe is enabled
+	// +optional
+	PxeBootFiles *PxeBootFilesSpec `json:"pxeBootFiles,omitempty"`
+
+	// Built-in tftp server, which replaces the tftp server of dnsmasq when the pxe is enabled
+	// +optional
+	BuiltinTftp *BuiltinTftpSpec `json:"builtinTftp,omitempty"`
+}
+
+// BuiltinTftpSpec defines the in-process tftp server bound to the interface ip of the subnet.
+// Each transfer is logged and recorded in the metrics, and the files generated in memory for the
+// mac address of the client are served without writing them to the tftp directory
+type BuiltinTftpSpec struct {
+	// Enable the built-in tftp server
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Max block size negotiated with the clients (RFC 2348), 1468 fits the ethernet mtu of 1500
+	// +kubebuilder:validation:Minimum=512
+	// +kubebuilder:validation:Maximum=65464
+	// +kubebuilder:default=1468
+	// +optional
+	MaxBlockSize int32 `json:"maxBlockSize,omitempty"`
+
+	// Max window size negotiated with the clients (RFC 7440)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=64
+	// +kubebuilder:default=8
+	// +optional
+	MaxWindowSize int32 `json:"maxWindowSize,omitempty"`
+}
+
+// PxeBootFilesSpec defines the boot file for each client architecture (dhcp option 93).
+// The tftp files are relative to the tftp directory, and the http files are relative to the http server,
+// where the boot directory of the tftp directory is served as /boot/
+type PxeBootFilesSpec struct {
+	// Tftp boot file for the legacy BIOS clients (arch 0)
+	// +kubebuilder:default="boot/grub/i386-pc/core.0"
+	// +optional
+	Bios string `json:"bios,omitempty"`
+
+	// Tftp boot file for the x86_64 UEFI clients (arch 7 and 9)
+	// +kubebuilder:default="boot/grub/x86_64-efi/core.efi"
+	// +optional
+	EfiX64 string `json:"efiX64,omitempty"`
+
+	// Tftp boot file for the arm64 UEFI clients (arch 11)
+	// +kubebuilder:default="boot/grub/arm64-efi/core.efi"
+	// +optional
+	EfiArm64 string `json:"efiArm64,omitempty"`
+
+	// Http boot file for the x86_64 UEFI HTTP boot clients (arch 16)
+	// +kubebuilder:default="boot/grub/x86_64-efi/core.efi"
+	// +optional
+	HttpBootX64 string `json:"httpBootX64,omitempty"`
+
+	// Http boot file for the arm64 UEFI HTTP boot clients (arch 19)
+	// +kubebuilder:default="boot/grub/arm64-efi/core.efi"
+	// +optional
+	HttpBootArm64 string `json:"httpBootArm64,omitempty"`
+}
+
+// PoolThresholdSpec defines the utilization thresholds of the ip pool, in percent
+type PoolThresholdSpec struct {
+	// Utilization to set the PoolNearlyFull condition
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=80
+	// +optional
+	NearlyFull int32 `json:"nearlyFull,omitempty"`
+
+	// Utilization to set the PoolExhausted condition
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=100
+	// +optional
+	Exhausted int32 `json:"exhausted,omitempty"`
+}
+
+// ConflictDetectionSpec defines the configuration of the ip conflict detection
+type ConflictDetectionSpec struct {
+	// Enable the arp scan on the subnet interface to detect the ip conflicts
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Interval in seconds between two arp scans
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:default=300
+	// +optional
+	ScanInterval int32 `json:"scanInterval,omitempty"`
+}
+
+// DiscoverySpec defines the configuration of sweeping the network for the redfish and ssh hosts
+type DiscoverySpec struct {
+	// Enable the discovery scan
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// CIDR to be scanned, default to the subnet
+	// +kubebuilder:validation:Pattern=`^([0-9]{1,3}\.){3}[0-9]{1,3}/([0-9]|[1-2][0-9]|3[0-2])$`
+	// +optional
+	Cidr *string `json:"cidr,omitempty"`
+
+	// Interval in seconds between two scans
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:default=3600
+	// +optional
+	ScanInterval int32 `json:"scanInterval,omitempty"`
+
+	// Register the discovered hosts as hostEndpoint automatically. Otherwise, they are listed in
+	// the status of the subnet, and wait for the approval by the annotation
+	// +kubebuilder:default=false
+	// +optional
+	AutoRegister bool `json:"autoRegister,omitempty"`
+
+	// Port of the redfish service, the redfish service root is probed by https
+	// +kubebuilder:default=443
+	// +optional
+	RedfishPort int32 `json:"redfishPort,omitempty"`
+
+	// Port of the ssh service, 0 means to skip the ssh probe
+	// +kubebuilder:default=22
+	// +optional
+	SSHPort *int32 `json:"sshPort,omitempty"`
+
+	// Secret for the discovered ssh hosts
+	// +optional
+	SSHSecretName *string `json:"sshSecretName,omitempty"`
+
+	// Secret namespace for the discovered ssh hosts
+	// +optional
+	SSHSecretNamespace *string `json:"sshSecretNamespace,omitempty"`
+
+	// Cluster name for the discovered hosts
+	// +optional
+	DefaultClusterName *string `json:"defaultClusterName,omitempty"`
 }
 
 // SyncRedfishstatusSpec defines the sync endpoint configuration
 type SyncRedfishstatusSpec struct {
-	// Enable automatically create the redfishstatus object for the dhcp client. Notice, it will not be deleted automatically
+	// Enable automatically create the redfishstatus object for the dhcp client. Notice, it will not be deleted automatically unless the garbage collection is enabled
 	// +kubebuilder:default=false
 	Enabled bool `json:"enabled"`
 
@@ -103,6 +268,64 @@ type SyncRedfishstatusSpec struct {
 	// Default cluster name
 	// +optional
 	DefaultClusterName *string `json:"defaultClusterName,omitempty"`
+
+	// Garbage collection of the stale redfishstatus objects created for the dhcp clients
+	// +optional
+	GarbageCollection *RedfishStatusGCSpec `json:"garbageCollection,omitempty"`
+
+	// Naming of the redfishstatus objects created for the dhcp clients, they are named by the ip address by default
+	// +optional
+	Naming *RedfishStatusNamingSpec `json:"naming,omitempty"`
+}
+
+// RedfishStatusNamingSpec defines how the redfishstatus objects of the dhcp clients are named
+type RedfishStatusNamingSpec struct {
+	// Strategy of the naming. With the strategies other than ip, the redfishstatus is found by the mac address,
+	// so the ip change of the BMC is recorded in its status rather than creating a new one.
+	// ip: the ip address, such as 192-168-0-10.
+	// mac: the mac address, such as b8-3f-d2-00-00-0a.
+	// hostname: the hostname reported by the dhcp client, or the mac address when it is empty.
+	// serial: the serial number reported by the redfish, the redfishstatus is named by the mac address before the first redfish contact, and renamed after it.
+	// template: the go template with the variables .IP, .MAC, .Hostname, .Subnet, .ClusterName and .Serial
+	// +kubebuilder:validation:Enum=ip;mac;hostname;serial;template
+	// +kubebuilder:default=ip
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+
+	// Template of the name, it is required by the template strategy. The result is lower-cased, and the characters
+	// not allowed in the name are replaced by "-"
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+// RedfishStatusGCSpec defines the garbage collection policy of the redfishstatus objects created for the dhcp clients
+type RedfishStatusGCSpec struct {
+	// Enable the garbage collection
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled"`
+
+	// Hours to retain the redfishstatus after its dhcp lease expires, it is 168 when it is not set
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=168
+	// +optional
+	RetentionHours int32 `json:"retentionHours,omitempty"`
+
+	// Days for which the redfishstatus has to be unhealthy before it is collected, 0 means it is not required
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	// +optional
+	UnhealthyDays int32 `json:"unhealthyDays,omitempty"`
+
+	// Action for the stale redfishstatus. Archive saves the redfishstatus and its bindingIp to a configmap before deleting them
+	// +kubebuilder:validation:Enum=Delete;Archive
+	// +kubebuilder:default=Delete
+	// +optional
+	Action string `json:"action,omitempty"`
+
+	// Only report the stale redfishstatus by events, without deleting them
+	// +kubebuilder:default=false
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // SubnetSpec defines the desired state of Subnet
@@ -139,6 +362,63 @@ type SubnetStatus struct {
 
 	// Dhcp client details
 	DhcpClientDetails string `json:"dhcpClientDetails"`
+
+	// Hosts found by the discovery scan
+	// +optional
+	DiscoveredHosts []DiscoveredHost `json:"discoveredHosts,omitempty"`
+
+	// Leases which fall outside the ip range after the ip range is shrunk
+	// +optional
+	OutOfRangeLeases []OutOfRangeLease `json:"outOfRangeLeases,omitempty"`
+
+	// Client architectures found in the vendor class of the dhcp requests when the pxe is enabled
+	// +optional
+	PxeClientArchs []PxeClientArch `json:"pxeClientArchs,omitempty"`
+}
+
+// PxeClientArch reports the clients of an architecture and its boot file
+type PxeClientArch struct {
+	// Client architecture of the dhcp option 93
+	Arch int32 `json:"arch"`
+
+	// Name of the architecture
+	Name string `json:"name"`
+
+	// Amount of the clients with the architecture
+	ClientAmount int32 `json:"clientAmount"`
+
+	// Boot file for the architecture, empty means the architecture is not supported
+	// +optional
+	BootFile string `json:"bootFile,omitempty"`
+
+	// Whether the boot file exists
+	BootFileFound bool `json:"bootFileFound"`
+}
+
+// OutOfRangeLease is a lease whose ip is not in the ip range of the subnet
+type OutOfRangeLease struct {
+	IpAddr   string `json:"ipAddr"`
+	MacAddr  string `json:"macAddr"`
+	Hostname string `json:"hostname,omitempty"`
+}
+
+// DiscoveredHost is a host found by the discovery scan
+type DiscoveredHost struct {
+	// IP address of the host
+	IpAddr string `json:"ipAddr"`
+
+	// Type of the host, redfish or ssh
+	Type string `json:"type"`
+
+	// Port of the service
+	Port int32 `json:"port"`
+
+	// Name of the hostEndpoint registered for the host, empty means waiting for the approval
+	// +optional
+	HostEndpoint string `json:"hostEndpoint,omitempty"`
+
+	// The last time when the host is found
+	LastSeenTime string `json:"lastSeenTime"`
 }
 
 type DhcpStatusSpec struct {
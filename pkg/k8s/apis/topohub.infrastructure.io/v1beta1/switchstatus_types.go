@@ -0,0 +1,97 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	HostTypeSwitch = "switch"
+
+	// the kinds of the object which a LLDP neighbor is correlated to
+	PeerKindRedfishStatus = "RedfishStatus"
+	PeerKindSSHStatus     = "SSHStatus"
+	PeerKindSwitchStatus  = "SwitchStatus"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="HEALTHY",type="boolean",JSONPath=".status.healthy"
+// +kubebuilder:printcolumn:name="IPADDR",type="string",JSONPath=".status.basic.ipAddr"
+// +kubebuilder:printcolumn:name="SYSTEMNAME",type="string",JSONPath=".status.systemName"
+// +kubebuilder:printcolumn:name="NEIGHBORS",type="integer",JSONPath=".status.neighborAmount"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SwitchStatus records the LLDP neighbors of a switch managed by the switch HostEndpoint,
+// the neighbors are correlated to the hosts and switches to build the topology
+type SwitchStatus struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status SwitchStatusStatus `json:"status,omitempty"`
+}
+
+type SwitchStatusStatus struct {
+	Healthy        bool         `json:"healthy"`
+	LastUpdateTime string       `json:"lastUpdateTime"`
+	Basic          SSHBasicInfo `json:"basic"`
+
+	// the local chassis id of the switch, which is usually the mac address
+	// +optional
+	ChassisID string `json:"chassisId,omitempty"`
+
+	// the local system name of the switch
+	// +optional
+	SystemName string `json:"systemName,omitempty"`
+
+	NeighborAmount int32 `json:"neighborAmount"`
+
+	// +optional
+	Neighbors []LldpNeighbor `json:"neighbors,omitempty"`
+}
+
+// LldpNeighbor is a LLDP neighbor learned on a port of the switch
+type LldpNeighbor struct {
+	// the port of the switch
+	LocalPort string `json:"localPort"`
+
+	// +optional
+	ChassisID string `json:"chassisId,omitempty"`
+
+	// the port id of the neighbor, which is the mac address or the interface name
+	// +optional
+	PortID string `json:"portId,omitempty"`
+
+	// +optional
+	PortDescription string `json:"portDescription,omitempty"`
+
+	// +optional
+	SystemName string `json:"systemName,omitempty"`
+
+	// +optional
+	MgmtAddr string `json:"mgmtAddr,omitempty"`
+
+	// the kind of the object which the neighbor is correlated to, RedfishStatus, SSHStatus or SwitchStatus
+	// +optional
+	PeerKind string `json:"peerKind,omitempty"`
+
+	// the name of the object which the neighbor is correlated to
+	// +optional
+	PeerName string `json:"peerName,omitempty"`
+
+	// the interface of the peer, like the mac address of the nic
+	// +optional
+	PeerInterface string `json:"peerInterface,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SwitchStatusList include SwitchStatus objects
+type SwitchStatusList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SwitchStatus `json:"items"`
+}
@@ -0,0 +1,115 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SwitchVendorSonic   = "Sonic"
+	SwitchVendorCumulus = "Cumulus"
+	SwitchVendorArista  = "Arista"
+
+	// SwitchZtpConditionRendered reports whether the ztp configs of the switches are rendered
+	SwitchZtpConditionRendered = "Rendered"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="SUBNET",type="string",JSONPath=".spec.subnet"
+// +kubebuilder:printcolumn:name="VENDOR",type="string",JSONPath=".spec.vendor"
+// +kubebuilder:printcolumn:name="SWITCHES",type="integer",JSONPath=".status.switchAmount"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SwitchZtp describes the ztp config rendered for each switch, which is identified by the mac address
+// or the serial number reported in the dhcp client identifier (option 61)
+type SwitchZtp struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SwitchZtpSpec   `json:"spec"`
+	Status SwitchZtpStatus `json:"status,omitempty"`
+}
+
+type SwitchZtpSpec struct {
+	// the subnet which the switches belong to, whose enableZtp must be true
+	// +kubebuilder:validation:Required
+	Subnet string `json:"subnet"`
+
+	// the network operating system of the switches, which decides the name and the format of the ztp file.
+	// Sonic: ztp.json, fetched by dhcp option 67; Cumulus: ztp.sh, fetched by dhcp option 239; Arista: bootstrap, fetched by dhcp option 67
+	// +kubebuilder:validation:Enum=Sonic;Cumulus;Arista
+	// +kubebuilder:validation:Required
+	Vendor string `json:"vendor"`
+
+	// content of the ztp file, which is a go template with the variables: .HttpServer, .Subnet, .MacAddr, .Serial, .Hostname, .IpAddr, .Variables
+	// +kubebuilder:validation:Required
+	Template string `json:"template"`
+
+	// the switches which the ztp file is rendered for
+	// +kubebuilder:validation:MinItems=1
+	Switches []ZtpSwitch `json:"switches"`
+}
+
+// ZtpSwitch identifies a switch by the mac address or the serial number, the mac address takes precedence when both are set
+type ZtpSwitch struct {
+	// mac address of the management interface
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9a-fA-F]{2}:){5}([0-9a-fA-F]{2})$`
+	MacAddr string `json:"macAddr,omitempty"`
+
+	// serial number reported in the dhcp client identifier
+	// +optional
+	Serial string `json:"serial,omitempty"`
+
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// the custom variables of the switch, which are referred by {{ .Variables.<key> }} in the template
+	// +optional
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+type SwitchZtpStatus struct {
+	// amount of the switches whose ztp files are rendered
+	SwitchAmount int32 `json:"switchAmount"`
+
+	// +optional
+	Switches []ZtpSwitchStatus `json:"switches,omitempty"`
+
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type ZtpSwitchStatus struct {
+	// +optional
+	MacAddr string `json:"macAddr,omitempty"`
+
+	// +optional
+	Serial string `json:"serial,omitempty"`
+
+	// the http path of the rendered ztp file
+	File string `json:"file"`
+
+	// the ip address of the switch which fetched the ztp file at the last time
+	// +optional
+	FetchedBy string `json:"fetchedBy,omitempty"`
+
+	// the file requested by the switch, like /ztp/ztp.json
+	// +optional
+	FetchedFile string `json:"fetchedFile,omitempty"`
+
+	// the last time the ztp file is fetched, in RFC3339 format
+	// +optional
+	FetchTime string `json:"fetchTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type SwitchZtpList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SwitchZtp `json:"items"`
+}
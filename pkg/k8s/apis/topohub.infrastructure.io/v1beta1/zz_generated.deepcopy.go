@@ -9,6 +9,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoInstallSpec) DeepCopyInto(out *AutoInstallSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoInstallSpec.
+func (in *AutoInstallSpec) DeepCopy() *AutoInstallSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoInstallSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BasicInfo) DeepCopyInto(out *BasicInfo) {
 	*out = *in
@@ -129,57 +144,81 @@ func (in *BindingIpStatus) DeepCopy() *BindingIpStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DhcpStatusSpec) DeepCopyInto(out *DhcpStatusSpec) {
+func (in *BuiltinTftpSpec) DeepCopyInto(out *BuiltinTftpSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DhcpStatusSpec.
-func (in *DhcpStatusSpec) DeepCopy() *DhcpStatusSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuiltinTftpSpec.
+func (in *BuiltinTftpSpec) DeepCopy() *BuiltinTftpSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DhcpStatusSpec)
+	out := new(BuiltinTftpSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
+func (in *ComplianceClusterReport) DeepCopyInto(out *ComplianceClusterReport) {
 	*out = *in
-	in.SyncRedfishstatus.DeepCopyInto(&out.SyncRedfishstatus)
+	if in.NonCompliantHosts != nil {
+		in, out := &in.NonCompliantHosts, &out.NonCompliantHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSpec.
-func (in *FeatureSpec) DeepCopy() *FeatureSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceClusterReport.
+func (in *ComplianceClusterReport) DeepCopy() *ComplianceClusterReport {
 	if in == nil {
 		return nil
 	}
-	out := new(FeatureSpec)
+	out := new(ComplianceClusterReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostOperation) DeepCopyInto(out *HostOperation) {
+func (in *ComplianceHostReport) DeepCopyInto(out *ComplianceHostReport) {
+	*out = *in
+	if in.Violations != nil {
+		in, out := &in.Violations, &out.Violations
+		*out = make([]ComplianceViolation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceHostReport.
+func (in *ComplianceHostReport) DeepCopy() *ComplianceHostReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceHostReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompliancePolicy) DeepCopyInto(out *CompliancePolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperation.
-func (in *HostOperation) DeepCopy() *HostOperation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompliancePolicy.
+func (in *CompliancePolicy) DeepCopy() *CompliancePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(HostOperation)
+	out := new(CompliancePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HostOperation) DeepCopyObject() runtime.Object {
+func (in *CompliancePolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -187,31 +226,31 @@ func (in *HostOperation) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostOperationList) DeepCopyInto(out *HostOperationList) {
+func (in *CompliancePolicyList) DeepCopyInto(out *CompliancePolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]HostOperation, len(*in))
+		*out = make([]CompliancePolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationList.
-func (in *HostOperationList) DeepCopy() *HostOperationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompliancePolicyList.
+func (in *CompliancePolicyList) DeepCopy() *CompliancePolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(HostOperationList)
+	out := new(CompliancePolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *HostOperationList) DeepCopyObject() runtime.Object {
+func (in *CompliancePolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -219,140 +258,138 @@ func (in *HostOperationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostOperationSpec) DeepCopyInto(out *HostOperationSpec) {
+func (in *CompliancePolicySpec) DeepCopyInto(out *CompliancePolicySpec) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationSpec.
-func (in *HostOperationSpec) DeepCopy() *HostOperationSpec {
-	if in == nil {
-		return nil
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ComplianceRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out := new(HostOperationSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostOperationStatus) DeepCopyInto(out *HostOperationStatus) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationStatus.
-func (in *HostOperationStatus) DeepCopy() *HostOperationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompliancePolicySpec.
+func (in *CompliancePolicySpec) DeepCopy() *CompliancePolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(HostOperationStatus)
+	out := new(CompliancePolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IPv4SubnetSpec) DeepCopyInto(out *IPv4SubnetSpec) {
+func (in *CompliancePolicyStatus) DeepCopyInto(out *CompliancePolicyStatus) {
 	*out = *in
-	if in.Gateway != nil {
-		in, out := &in.Gateway, &out.Gateway
-		*out = new(string)
-		**out = **in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ComplianceClusterReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Dns != nil {
-		in, out := &in.Dns, &out.Dns
-		*out = new(string)
-		**out = **in
+	if in.NonCompliantHosts != nil {
+		in, out := &in.NonCompliantHosts, &out.NonCompliantHosts
+		*out = make([]ComplianceHostReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPv4SubnetSpec.
-func (in *IPv4SubnetSpec) DeepCopy() *IPv4SubnetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompliancePolicyStatus.
+func (in *CompliancePolicyStatus) DeepCopy() *CompliancePolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IPv4SubnetSpec)
+	out := new(CompliancePolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InterfaceSpec) DeepCopyInto(out *InterfaceSpec) {
+func (in *ComplianceRule) DeepCopyInto(out *ComplianceRule) {
 	*out = *in
-	if in.VlanID != nil {
-		in, out := &in.VlanID, &out.VlanID
-		*out = new(int32)
-		**out = **in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceSpec.
-func (in *InterfaceSpec) DeepCopy() *InterfaceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceRule.
+func (in *ComplianceRule) DeepCopy() *ComplianceRule {
 	if in == nil {
 		return nil
 	}
-	out := new(InterfaceSpec)
+	out := new(ComplianceRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogEntry) DeepCopyInto(out *LogEntry) {
+func (in *ComplianceViolation) DeepCopyInto(out *ComplianceViolation) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogEntry.
-func (in *LogEntry) DeepCopy() *LogEntry {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceViolation.
+func (in *ComplianceViolation) DeepCopy() *ComplianceViolation {
 	if in == nil {
 		return nil
 	}
-	out := new(LogEntry)
+	out := new(ComplianceViolation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogStruct) DeepCopyInto(out *LogStruct) {
+func (in *ConflictDetectionSpec) DeepCopyInto(out *ConflictDetectionSpec) {
 	*out = *in
-	if in.LastestLog != nil {
-		in, out := &in.LastestLog, &out.LastestLog
-		*out = new(LogEntry)
-		**out = **in
-	}
-	if in.LastestWarningLog != nil {
-		in, out := &in.LastestWarningLog, &out.LastestWarningLog
-		*out = new(LogEntry)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStruct.
-func (in *LogStruct) DeepCopy() *LogStruct {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConflictDetectionSpec.
+func (in *ConflictDetectionSpec) DeepCopy() *ConflictDetectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LogStruct)
+	out := new(ConflictDetectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RedfishStatus) DeepCopyInto(out *RedfishStatus) {
+func (in *DhcpLease) DeepCopyInto(out *DhcpLease) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Spec = in.Spec
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedfishStatus.
-func (in *RedfishStatus) DeepCopy() *RedfishStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DhcpLease.
+func (in *DhcpLease) DeepCopy() *DhcpLease {
 	if in == nil {
 		return nil
 	}
-	out := new(RedfishStatus)
+	out := new(DhcpLease)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RedfishStatus) DeepCopyObject() runtime.Object {
+func (in *DhcpLease) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -360,31 +397,31 @@ func (in *RedfishStatus) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RedfishStatusList) DeepCopyInto(out *RedfishStatusList) {
+func (in *DhcpLeaseList) DeepCopyInto(out *DhcpLeaseList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RedfishStatus, len(*in))
+		*out = make([]DhcpLease, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedfishStatusList.
-func (in *RedfishStatusList) DeepCopy() *RedfishStatusList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DhcpLeaseList.
+func (in *DhcpLeaseList) DeepCopy() *DhcpLeaseList {
 	if in == nil {
 		return nil
 	}
-	out := new(RedfishStatusList)
+	out := new(DhcpLeaseList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RedfishStatusList) DeepCopyObject() runtime.Object {
+func (in *DhcpLeaseList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -392,132 +429,133 @@ func (in *RedfishStatusList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RedfishStatusStatus) DeepCopyInto(out *RedfishStatusStatus) {
+func (in *DhcpLeaseSpec) DeepCopyInto(out *DhcpLeaseSpec) {
 	*out = *in
-	in.Basic.DeepCopyInto(&out.Basic)
-	if in.Info != nil {
-		in, out := &in.Info, &out.Info
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Log.DeepCopyInto(&out.Log)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedfishStatusStatus.
-func (in *RedfishStatusStatus) DeepCopy() *RedfishStatusStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DhcpLeaseSpec.
+func (in *DhcpLeaseSpec) DeepCopy() *DhcpLeaseSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RedfishStatusStatus)
+	out := new(DhcpLeaseSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SSHBasicInfo) DeepCopyInto(out *SSHBasicInfo) {
+func (in *DhcpStatusSpec) DeepCopyInto(out *DhcpStatusSpec) {
 	*out = *in
-	if in.SubnetName != nil {
-		in, out := &in.SubnetName, &out.SubnetName
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHBasicInfo.
-func (in *SSHBasicInfo) DeepCopy() *SSHBasicInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DhcpStatusSpec.
+func (in *DhcpStatusSpec) DeepCopy() *DhcpStatusSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SSHBasicInfo)
+	out := new(DhcpStatusSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SSHStatus) DeepCopyInto(out *SSHStatus) {
+func (in *DiscoveredHost) DeepCopyInto(out *DiscoveredHost) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHStatus.
-func (in *SSHStatus) DeepCopy() *SSHStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredHost.
+func (in *DiscoveredHost) DeepCopy() *DiscoveredHost {
 	if in == nil {
 		return nil
 	}
-	out := new(SSHStatus)
+	out := new(DiscoveredHost)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SSHStatus) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SSHStatusList) DeepCopyInto(out *SSHStatusList) {
+func (in *DiscoverySpec) DeepCopyInto(out *DiscoverySpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]SSHStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Cidr != nil {
+		in, out := &in.Cidr, &out.Cidr
+		*out = new(string)
+		**out = **in
+	}
+	if in.SSHPort != nil {
+		in, out := &in.SSHPort, &out.SSHPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SSHSecretName != nil {
+		in, out := &in.SSHSecretName, &out.SSHSecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SSHSecretNamespace != nil {
+		in, out := &in.SSHSecretNamespace, &out.SSHSecretNamespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.DefaultClusterName != nil {
+		in, out := &in.DefaultClusterName, &out.DefaultClusterName
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHStatusList.
-func (in *SSHStatusList) DeepCopy() *SSHStatusList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoverySpec.
+func (in *DiscoverySpec) DeepCopy() *DiscoverySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SSHStatusList)
+	out := new(DiscoverySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SSHStatusList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SSHStatusStatus) DeepCopyInto(out *SSHStatusStatus) {
+func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
 	*out = *in
-	in.Basic.DeepCopyInto(&out.Basic)
-	if in.Info != nil {
-		in, out := &in.Info, &out.Info
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	in.SyncRedfishstatus.DeepCopyInto(&out.SyncRedfishstatus)
+	if in.ConflictDetection != nil {
+		in, out := &in.ConflictDetection, &out.ConflictDetection
+		*out = new(ConflictDetectionSpec)
+		**out = **in
+	}
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(DiscoverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PoolThreshold != nil {
+		in, out := &in.PoolThreshold, &out.PoolThreshold
+		*out = new(PoolThresholdSpec)
+		**out = **in
+	}
+	if in.PxeBootFiles != nil {
+		in, out := &in.PxeBootFiles, &out.PxeBootFiles
+		*out = new(PxeBootFilesSpec)
+		**out = **in
+	}
+	if in.BuiltinTftp != nil {
+		in, out := &in.BuiltinTftp, &out.BuiltinTftp
+		*out = new(BuiltinTftpSpec)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHStatusStatus.
-func (in *SSHStatusStatus) DeepCopy() *SSHStatusStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSpec.
+func (in *FeatureSpec) DeepCopy() *FeatureSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SSHStatusStatus)
+	out := new(FeatureSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Subnet) DeepCopyInto(out *Subnet) {
+func (in *Host) DeepCopyInto(out *Host) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -525,18 +563,18 @@ func (in *Subnet) DeepCopyInto(out *Subnet) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subnet.
-func (in *Subnet) DeepCopy() *Subnet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Host.
+func (in *Host) DeepCopy() *Host {
 	if in == nil {
 		return nil
 	}
-	out := new(Subnet)
+	out := new(Host)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Subnet) DeepCopyObject() runtime.Object {
+func (in *Host) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -544,30 +582,1312 @@ func (in *Subnet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SubnetList) DeepCopyInto(out *SubnetList) {
+func (in *HostAddressRef) DeepCopyInto(out *HostAddressRef) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Subnet, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetList.
-func (in *SubnetList) DeepCopy() *SubnetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostAddressRef.
+func (in *HostAddressRef) DeepCopy() *HostAddressRef {
 	if in == nil {
 		return nil
 	}
-	out := new(SubnetList)
+	out := new(HostAddressRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostGroup) DeepCopyInto(out *HostGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostGroup.
+func (in *HostGroup) DeepCopy() *HostGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(HostGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostGroupFirmwareVersion) DeepCopyInto(out *HostGroupFirmwareVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostGroupFirmwareVersion.
+func (in *HostGroupFirmwareVersion) DeepCopy() *HostGroupFirmwareVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(HostGroupFirmwareVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostGroupList) DeepCopyInto(out *HostGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostGroupList.
+func (in *HostGroupList) DeepCopy() *HostGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostGroupSpec) DeepCopyInto(out *HostGroupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostGroupSpec.
+func (in *HostGroupSpec) DeepCopy() *HostGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostGroupStatus) DeepCopyInto(out *HostGroupStatus) {
+	*out = *in
+	if in.PowerStates != nil {
+		in, out := &in.PowerStates, &out.PowerStates
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FirmwareVersions != nil {
+		in, out := &in.FirmwareVersions, &out.FirmwareVersions
+		*out = make([]HostGroupFirmwareVersion, len(*in))
+		copy(*out, *in)
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostGroupStatus.
+func (in *HostGroupStatus) DeepCopy() *HostGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostList) DeepCopyInto(out *HostList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Host, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostList.
+func (in *HostList) DeepCopy() *HostList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostMaintenance) DeepCopyInto(out *HostMaintenance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostMaintenance.
+func (in *HostMaintenance) DeepCopy() *HostMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(HostMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperation) DeepCopyInto(out *HostOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperation.
+func (in *HostOperation) DeepCopy() *HostOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperationFile) DeepCopyInto(out *HostOperationFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationFile.
+func (in *HostOperationFile) DeepCopy() *HostOperationFile {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperationFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperationList) DeepCopyInto(out *HostOperationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HostOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationList.
+func (in *HostOperationList) DeepCopy() *HostOperationList {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HostOperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperationScript) DeepCopyInto(out *HostOperationScript) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationScript.
+func (in *HostOperationScript) DeepCopy() *HostOperationScript {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperationScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperationSpec) DeepCopyInto(out *HostOperationSpec) {
+	*out = *in
+	if in.Script != nil {
+		in, out := &in.Script, &out.Script
+		*out = new(HostOperationScript)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(HostOperationFile)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationSpec.
+func (in *HostOperationSpec) DeepCopy() *HostOperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostOperationStatus) DeepCopyInto(out *HostOperationStatus) {
+	*out = *in
+	if in.ExitCode != nil {
+		in, out := &in.ExitCode, &out.ExitCode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostOperationStatus.
+func (in *HostOperationStatus) DeepCopy() *HostOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostRecordRef) DeepCopyInto(out *HostRecordRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostRecordRef.
+func (in *HostRecordRef) DeepCopy() *HostRecordRef {
+	if in == nil {
+		return nil
+	}
+	out := new(HostRecordRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSpec) DeepCopyInto(out *HostSpec) {
+	*out = *in
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(HostMaintenance)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSpec.
+func (in *HostSpec) DeepCopy() *HostSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostStatus) DeepCopyInto(out *HostStatus) {
+	*out = *in
+	if in.BMC != nil {
+		in, out := &in.BMC, &out.BMC
+		*out = new(HostRecordRef)
+		**out = **in
+	}
+	if in.SSH != nil {
+		in, out := &in.SSH, &out.SSH
+		*out = new(HostRecordRef)
+		**out = **in
+	}
+	if in.DhcpLeases != nil {
+		in, out := &in.DhcpLeases, &out.DhcpLeases
+		*out = make([]HostAddressRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.BindingIps != nil {
+		in, out := &in.BindingIps, &out.BindingIps
+		*out = make([]HostAddressRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreviousBmcIPs != nil {
+		in, out := &in.PreviousBmcIPs, &out.PreviousBmcIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostStatus.
+func (in *HostStatus) DeepCopy() *HostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPv4SubnetSpec) DeepCopyInto(out *IPv4SubnetSpec) {
+	*out = *in
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(string)
+		**out = **in
+	}
+	if in.Dns != nil {
+		in, out := &in.Dns, &out.Dns
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPv4SubnetSpec.
+func (in *IPv4SubnetSpec) DeepCopy() *IPv4SubnetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IPv4SubnetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterfaceSpec) DeepCopyInto(out *InterfaceSpec) {
+	*out = *in
+	if in.VlanID != nil {
+		in, out := &in.VlanID, &out.VlanID
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterfaceSpec.
+func (in *InterfaceSpec) DeepCopy() *InterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LldpNeighbor) DeepCopyInto(out *LldpNeighbor) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LldpNeighbor.
+func (in *LldpNeighbor) DeepCopy() *LldpNeighbor {
+	if in == nil {
+		return nil
+	}
+	out := new(LldpNeighbor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogEntry) DeepCopyInto(out *LogEntry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogEntry.
+func (in *LogEntry) DeepCopy() *LogEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(LogEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogStruct) DeepCopyInto(out *LogStruct) {
+	*out = *in
+	if in.LastestLog != nil {
+		in, out := &in.LastestLog, &out.LastestLog
+		*out = new(LogEntry)
+		**out = **in
+	}
+	if in.LastestWarningLog != nil {
+		in, out := &in.LastestWarningLog, &out.LastestWarningLog
+		*out = new(LogEntry)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogStruct.
+func (in *LogStruct) DeepCopy() *LogStruct {
+	if in == nil {
+		return nil
+	}
+	out := new(LogStruct)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutOfRangeLease) DeepCopyInto(out *OutOfRangeLease) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutOfRangeLease.
+func (in *OutOfRangeLease) DeepCopy() *OutOfRangeLease {
+	if in == nil {
+		return nil
+	}
+	out := new(OutOfRangeLease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolThresholdSpec) DeepCopyInto(out *PoolThresholdSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolThresholdSpec.
+func (in *PoolThresholdSpec) DeepCopy() *PoolThresholdSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolThresholdSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreviousIpAddr) DeepCopyInto(out *PreviousIpAddr) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreviousIpAddr.
+func (in *PreviousIpAddr) DeepCopy() *PreviousIpAddr {
+	if in == nil {
+		return nil
+	}
+	out := new(PreviousIpAddr)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionHost) DeepCopyInto(out *ProvisionHost) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionHost.
+func (in *ProvisionHost) DeepCopy() *ProvisionHost {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionHostSelector) DeepCopyInto(out *ProvisionHostSelector) {
+	*out = *in
+	if in.MacAddrs != nil {
+		in, out := &in.MacAddrs, &out.MacAddrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionHostSelector.
+func (in *ProvisionHostSelector) DeepCopy() *ProvisionHostSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionHostSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionPhaseRecord) DeepCopyInto(out *ProvisionPhaseRecord) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionPhaseRecord.
+func (in *ProvisionPhaseRecord) DeepCopy() *ProvisionPhaseRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionPhaseRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionProfile) DeepCopyInto(out *ProvisionProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionProfile.
+func (in *ProvisionProfile) DeepCopy() *ProvisionProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProvisionProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionProfileList) DeepCopyInto(out *ProvisionProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProvisionProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionProfileList.
+func (in *ProvisionProfileList) DeepCopy() *ProvisionProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProvisionProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionProfileSpec) DeepCopyInto(out *ProvisionProfileSpec) {
+	*out = *in
+	if in.Iso != nil {
+		in, out := &in.Iso, &out.Iso
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutoInstall != nil {
+		in, out := &in.AutoInstall, &out.AutoInstall
+		*out = new(AutoInstallSpec)
+		**out = **in
+	}
+	in.Hosts.DeepCopyInto(&out.Hosts)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionProfileSpec.
+func (in *ProvisionProfileSpec) DeepCopy() *ProvisionProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionProfileStatus) DeepCopyInto(out *ProvisionProfileStatus) {
+	*out = *in
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]ProvisionHost, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionProfileStatus.
+func (in *ProvisionProfileStatus) DeepCopy() *ProvisionProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProvisionStatus) DeepCopyInto(out *ProvisionStatus) {
+	*out = *in
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]ProvisionPhaseRecord, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisionStatus.
+func (in *ProvisionStatus) DeepCopy() *ProvisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProvisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PxeBootFilesSpec) DeepCopyInto(out *PxeBootFilesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PxeBootFilesSpec.
+func (in *PxeBootFilesSpec) DeepCopy() *PxeBootFilesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PxeBootFilesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PxeClientArch) DeepCopyInto(out *PxeClientArch) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PxeClientArch.
+func (in *PxeClientArch) DeepCopy() *PxeClientArch {
+	if in == nil {
+		return nil
+	}
+	out := new(PxeClientArch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedfishStatus) DeepCopyInto(out *RedfishStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedfishStatus.
+func (in *RedfishStatus) DeepCopy() *RedfishStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RedfishStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedfishStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedfishStatusGCSpec) DeepCopyInto(out *RedfishStatusGCSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedfishStatusGCSpec.
+func (in *RedfishStatusGCSpec) DeepCopy() *RedfishStatusGCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedfishStatusGCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedfishStatusList) DeepCopyInto(out *RedfishStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RedfishStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedfishStatusList.
+func (in *RedfishStatusList) DeepCopy() *RedfishStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(RedfishStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RedfishStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedfishStatusNamingSpec) DeepCopyInto(out *RedfishStatusNamingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedfishStatusNamingSpec.
+func (in *RedfishStatusNamingSpec) DeepCopy() *RedfishStatusNamingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RedfishStatusNamingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedfishStatusStatus) DeepCopyInto(out *RedfishStatusStatus) {
+	*out = *in
+	if in.UnhealthySince != nil {
+		in, out := &in.UnhealthySince, &out.UnhealthySince
+		*out = new(string)
+		**out = **in
+	}
+	in.Basic.DeepCopyInto(&out.Basic)
+	if in.Info != nil {
+		in, out := &in.Info, &out.Info
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Log.DeepCopyInto(&out.Log)
+	if in.Provision != nil {
+		in, out := &in.Provision, &out.Provision
+		*out = new(ProvisionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreviousIpAddrs != nil {
+		in, out := &in.PreviousIpAddrs, &out.PreviousIpAddrs
+		*out = make([]PreviousIpAddr, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreviousNames != nil {
+		in, out := &in.PreviousNames, &out.PreviousNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedfishStatusStatus.
+func (in *RedfishStatusStatus) DeepCopy() *RedfishStatusStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RedfishStatusStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHBasicInfo) DeepCopyInto(out *SSHBasicInfo) {
+	*out = *in
+	if in.SubnetName != nil {
+		in, out := &in.SubnetName, &out.SubnetName
+		*out = new(string)
+		**out = **in
+	}
+	if in.JumpHost != nil {
+		in, out := &in.JumpHost, &out.JumpHost
+		*out = new(SSHJumpHost)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHBasicInfo.
+func (in *SSHBasicInfo) DeepCopy() *SSHBasicInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHBasicInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHBlockDevice) DeepCopyInto(out *SSHBlockDevice) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHBlockDevice.
+func (in *SSHBlockDevice) DeepCopy() *SSHBlockDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHBlockDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHCollector) DeepCopyInto(out *SSHCollector) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHCollector.
+func (in *SSHCollector) DeepCopy() *SSHCollector {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHCollector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSHCollector) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHCollectorList) DeepCopyInto(out *SSHCollectorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SSHCollector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHCollectorList.
+func (in *SSHCollectorList) DeepCopy() *SSHCollectorList {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHCollectorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSHCollectorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHCollectorSpec) DeepCopyInto(out *SSHCollectorSpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHCollectorSpec.
+func (in *SSHCollectorSpec) DeepCopy() *SSHCollectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHCollectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHGpuInfo) DeepCopyInto(out *SSHGpuInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHGpuInfo.
+func (in *SSHGpuInfo) DeepCopy() *SSHGpuInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHGpuInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHInventory) DeepCopyInto(out *SSHInventory) {
+	*out = *in
+	if in.Nics != nil {
+		in, out := &in.Nics, &out.Nics
+		*out = make([]SSHNicInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RdmaDevices != nil {
+		in, out := &in.RdmaDevices, &out.RdmaDevices
+		*out = make([]SSHRdmaDevice, len(*in))
+		copy(*out, *in)
+	}
+	if in.NumaNodes != nil {
+		in, out := &in.NumaNodes, &out.NumaNodes
+		*out = make([]SSHNumaNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.Gpus != nil {
+		in, out := &in.Gpus, &out.Gpus
+		*out = make([]SSHGpuInfo, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockDevices != nil {
+		in, out := &in.BlockDevices, &out.BlockDevices
+		*out = make([]SSHBlockDevice, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHInventory.
+func (in *SSHInventory) DeepCopy() *SSHInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHJumpHost) DeepCopyInto(out *SSHJumpHost) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHJumpHost.
+func (in *SSHJumpHost) DeepCopy() *SSHJumpHost {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHJumpHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHLogStruct) DeepCopyInto(out *SSHLogStruct) {
+	*out = *in
+	in.LogStruct.DeepCopyInto(&out.LogStruct)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHLogStruct.
+func (in *SSHLogStruct) DeepCopy() *SSHLogStruct {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHLogStruct)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHNicInfo) DeepCopyInto(out *SSHNicInfo) {
+	*out = *in
+	if in.NumaNode != nil {
+		in, out := &in.NumaNode, &out.NumaNode
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHNicInfo.
+func (in *SSHNicInfo) DeepCopy() *SSHNicInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHNicInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHNumaNode) DeepCopyInto(out *SSHNumaNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHNumaNode.
+func (in *SSHNumaNode) DeepCopy() *SSHNumaNode {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHNumaNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHRdmaDevice) DeepCopyInto(out *SSHRdmaDevice) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHRdmaDevice.
+func (in *SSHRdmaDevice) DeepCopy() *SSHRdmaDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHRdmaDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHStatus) DeepCopyInto(out *SSHStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHStatus.
+func (in *SSHStatus) DeepCopy() *SSHStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSHStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHStatusList) DeepCopyInto(out *SSHStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SSHStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHStatusList.
+func (in *SSHStatusList) DeepCopy() *SSHStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSHStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHStatusStatus) DeepCopyInto(out *SSHStatusStatus) {
+	*out = *in
+	in.Basic.DeepCopyInto(&out.Basic)
+	if in.Info != nil {
+		in, out := &in.Info, &out.Info
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = new(SSHInventory)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Log.DeepCopyInto(&out.Log)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHStatusStatus.
+func (in *SSHStatusStatus) DeepCopy() *SSHStatusStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHStatusStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Subnet) DeepCopyInto(out *Subnet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subnet.
+func (in *Subnet) DeepCopy() *Subnet {
+	if in == nil {
+		return nil
+	}
+	out := new(Subnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Subnet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SubnetList) DeepCopyInto(out *SubnetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Subnet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetList.
+func (in *SubnetList) DeepCopy() *SubnetList {
+	if in == nil {
+		return nil
+	}
+	out := new(SubnetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
 func (in *SubnetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
@@ -617,6 +1937,21 @@ func (in *SubnetStatus) DeepCopyInto(out *SubnetStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DiscoveredHosts != nil {
+		in, out := &in.DiscoveredHosts, &out.DiscoveredHosts
+		*out = make([]DiscoveredHost, len(*in))
+		copy(*out, *in)
+	}
+	if in.OutOfRangeLeases != nil {
+		in, out := &in.OutOfRangeLeases, &out.OutOfRangeLeases
+		*out = make([]OutOfRangeLease, len(*in))
+		copy(*out, *in)
+	}
+	if in.PxeClientArchs != nil {
+		in, out := &in.PxeClientArchs, &out.PxeClientArchs
+		*out = make([]PxeClientArch, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetStatus.
@@ -629,6 +1964,193 @@ func (in *SubnetStatus) DeepCopy() *SubnetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchStatus) DeepCopyInto(out *SwitchStatus) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchStatus.
+func (in *SwitchStatus) DeepCopy() *SwitchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwitchStatus) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchStatusList) DeepCopyInto(out *SwitchStatusList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SwitchStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchStatusList.
+func (in *SwitchStatusList) DeepCopy() *SwitchStatusList {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchStatusList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwitchStatusList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchStatusStatus) DeepCopyInto(out *SwitchStatusStatus) {
+	*out = *in
+	in.Basic.DeepCopyInto(&out.Basic)
+	if in.Neighbors != nil {
+		in, out := &in.Neighbors, &out.Neighbors
+		*out = make([]LldpNeighbor, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchStatusStatus.
+func (in *SwitchStatusStatus) DeepCopy() *SwitchStatusStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchStatusStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchZtp) DeepCopyInto(out *SwitchZtp) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchZtp.
+func (in *SwitchZtp) DeepCopy() *SwitchZtp {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchZtp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwitchZtp) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchZtpList) DeepCopyInto(out *SwitchZtpList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SwitchZtp, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchZtpList.
+func (in *SwitchZtpList) DeepCopy() *SwitchZtpList {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchZtpList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SwitchZtpList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchZtpSpec) DeepCopyInto(out *SwitchZtpSpec) {
+	*out = *in
+	if in.Switches != nil {
+		in, out := &in.Switches, &out.Switches
+		*out = make([]ZtpSwitch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchZtpSpec.
+func (in *SwitchZtpSpec) DeepCopy() *SwitchZtpSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchZtpSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SwitchZtpStatus) DeepCopyInto(out *SwitchZtpStatus) {
+	*out = *in
+	if in.Switches != nil {
+		in, out := &in.Switches, &out.Switches
+		*out = make([]ZtpSwitchStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SwitchZtpStatus.
+func (in *SwitchZtpStatus) DeepCopy() *SwitchZtpStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SwitchZtpStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncRedfishstatusSpec) DeepCopyInto(out *SyncRedfishstatusSpec) {
 	*out = *in
@@ -637,6 +2159,16 @@ func (in *SyncRedfishstatusSpec) DeepCopyInto(out *SyncRedfishstatusSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(RedfishStatusGCSpec)
+		**out = **in
+	}
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(RedfishStatusNamingSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncRedfishstatusSpec.
@@ -648,3 +2180,40 @@ func (in *SyncRedfishstatusSpec) DeepCopy() *SyncRedfishstatusSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZtpSwitch) DeepCopyInto(out *ZtpSwitch) {
+	*out = *in
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZtpSwitch.
+func (in *ZtpSwitch) DeepCopy() *ZtpSwitch {
+	if in == nil {
+		return nil
+	}
+	out := new(ZtpSwitch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZtpSwitchStatus) DeepCopyInto(out *ZtpSwitchStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZtpSwitchStatus.
+func (in *ZtpSwitchStatus) DeepCopy() *ZtpSwitchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZtpSwitchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -15,18 +15,42 @@ type FakeTopohubV1beta1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTopohubV1beta1) CompliancePolicies() v1beta1.CompliancePolicyInterface {
+	return newFakeCompliancePolicies(c)
+}
+
+func (c *FakeTopohubV1beta1) DhcpLeases() v1beta1.DhcpLeaseInterface {
+	return newFakeDhcpLeases(c)
+}
+
+func (c *FakeTopohubV1beta1) Hosts() v1beta1.HostInterface {
+	return newFakeHosts(c)
+}
+
 func (c *FakeTopohubV1beta1) HostEndpoints() v1beta1.HostEndpointInterface {
 	return newFakeHostEndpoints(c)
 }
 
+func (c *FakeTopohubV1beta1) HostGroups() v1beta1.HostGroupInterface {
+	return newFakeHostGroups(c)
+}
+
 func (c *FakeTopohubV1beta1) HostOperations() v1beta1.HostOperationInterface {
 	return newFakeHostOperations(c)
 }
 
+func (c *FakeTopohubV1beta1) ProvisionProfiles() v1beta1.ProvisionProfileInterface {
+	return newFakeProvisionProfiles(c)
+}
+
 func (c *FakeTopohubV1beta1) RedfishStatuses() v1beta1.RedfishStatusInterface {
 	return newFakeRedfishStatuses(c)
 }
 
+func (c *FakeTopohubV1beta1) SSHCollectors() v1beta1.SSHCollectorInterface {
+	return newFakeSSHCollectors(c)
+}
+
 func (c *FakeTopohubV1beta1) SSHStatuses() v1beta1.SSHStatusInterface {
 	return newFakeSSHStatuses(c)
 }
@@ -35,6 +59,14 @@ func (c *FakeTopohubV1beta1) Subnets() v1beta1.SubnetInterface {
 	return newFakeSubnets(c)
 }
 
+func (c *FakeTopohubV1beta1) SwitchStatuses() v1beta1.SwitchStatusInterface {
+	return newFakeSwitchStatuses(c)
+}
+
+func (c *FakeTopohubV1beta1) SwitchZtps() v1beta1.SwitchZtpInterface {
+	return newFakeSwitchZtps(c)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeTopohubV1beta1) RESTClient() rest.Interface {
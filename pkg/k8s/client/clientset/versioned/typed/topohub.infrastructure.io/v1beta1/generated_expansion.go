@@ -5,12 +5,28 @@
 
 package v1beta1
 
+type CompliancePolicyExpansion interface{}
+
+type DhcpLeaseExpansion interface{}
+
+type HostExpansion interface{}
+
 type HostEndpointExpansion interface{}
 
+type HostGroupExpansion interface{}
+
 type HostOperationExpansion interface{}
 
+type ProvisionProfileExpansion interface{}
+
 type RedfishStatusExpansion interface{}
 
+type SSHCollectorExpansion interface{}
+
 type SSHStatusExpansion interface{}
 
 type SubnetExpansion interface{}
+
+type SwitchStatusExpansion interface{}
+
+type SwitchZtpExpansion interface{}
@@ -15,11 +15,19 @@ import (
 
 type TopohubV1beta1Interface interface {
 	RESTClient() rest.Interface
+	CompliancePoliciesGetter
+	DhcpLeasesGetter
+	HostsGetter
 	HostEndpointsGetter
+	HostGroupsGetter
 	HostOperationsGetter
+	ProvisionProfilesGetter
 	RedfishStatusesGetter
+	SSHCollectorsGetter
 	SSHStatusesGetter
 	SubnetsGetter
+	SwitchStatusesGetter
+	SwitchZtpsGetter
 }
 
 // TopohubV1beta1Client is used to interact with features provided by the topohub.infrastructure.io group.
@@ -27,18 +35,42 @@ type TopohubV1beta1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TopohubV1beta1Client) CompliancePolicies() CompliancePolicyInterface {
+	return newCompliancePolicies(c)
+}
+
+func (c *TopohubV1beta1Client) DhcpLeases() DhcpLeaseInterface {
+	return newDhcpLeases(c)
+}
+
+func (c *TopohubV1beta1Client) Hosts() HostInterface {
+	return newHosts(c)
+}
+
 func (c *TopohubV1beta1Client) HostEndpoints() HostEndpointInterface {
 	return newHostEndpoints(c)
 }
 
+func (c *TopohubV1beta1Client) HostGroups() HostGroupInterface {
+	return newHostGroups(c)
+}
+
 func (c *TopohubV1beta1Client) HostOperations() HostOperationInterface {
 	return newHostOperations(c)
 }
 
+func (c *TopohubV1beta1Client) ProvisionProfiles() ProvisionProfileInterface {
+	return newProvisionProfiles(c)
+}
+
 func (c *TopohubV1beta1Client) RedfishStatuses() RedfishStatusInterface {
 	return newRedfishStatuses(c)
 }
 
+func (c *TopohubV1beta1Client) SSHCollectors() SSHCollectorInterface {
+	return newSSHCollectors(c)
+}
+
 func (c *TopohubV1beta1Client) SSHStatuses() SSHStatusInterface {
 	return newSSHStatuses(c)
 }
@@ -47,6 +79,14 @@ func (c *TopohubV1beta1Client) Subnets() SubnetInterface {
 	return newSubnets(c)
 }
 
+func (c *TopohubV1beta1Client) SwitchStatuses() SwitchStatusInterface {
+	return newSwitchStatuses(c)
+}
+
+func (c *TopohubV1beta1Client) SwitchZtps() SwitchZtpInterface {
+	return newSwitchZtps(c)
+}
+
 // NewForConfig creates a new TopohubV1beta1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
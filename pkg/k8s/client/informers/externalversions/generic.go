@@ -40,16 +40,32 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=topohub.infrastructure.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("compliancepolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().CompliancePolicies().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("dhcpleases"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().DhcpLeases().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("hosts"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().Hosts().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("hostendpoints"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().HostEndpoints().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("hostgroups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().HostGroups().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("hostoperations"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().HostOperations().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("provisionprofiles"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().ProvisionProfiles().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("redfishstatuses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().RedfishStatuses().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("sshcollectors"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().SSHCollectors().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("sshstatuses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().SSHStatuses().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("subnets"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().Subnets().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("switchstatuses"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().SwitchStatuses().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("switchztps"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Topohub().V1beta1().SwitchZtps().Informer()}, nil
 
 	}
 
@@ -11,16 +11,32 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// CompliancePolicies returns a CompliancePolicyInformer.
+	CompliancePolicies() CompliancePolicyInformer
+	// DhcpLeases returns a DhcpLeaseInformer.
+	DhcpLeases() DhcpLeaseInformer
+	// Hosts returns a HostInformer.
+	Hosts() HostInformer
 	// HostEndpoints returns a HostEndpointInformer.
 	HostEndpoints() HostEndpointInformer
+	// HostGroups returns a HostGroupInformer.
+	HostGroups() HostGroupInformer
 	// HostOperations returns a HostOperationInformer.
 	HostOperations() HostOperationInformer
+	// ProvisionProfiles returns a ProvisionProfileInformer.
+	ProvisionProfiles() ProvisionProfileInformer
 	// RedfishStatuses returns a RedfishStatusInformer.
 	RedfishStatuses() RedfishStatusInformer
+	// SSHCollectors returns a SSHCollectorInformer.
+	SSHCollectors() SSHCollectorInformer
 	// SSHStatuses returns a SSHStatusInformer.
 	SSHStatuses() SSHStatusInformer
 	// Subnets returns a SubnetInformer.
 	Subnets() SubnetInformer
+	// SwitchStatuses returns a SwitchStatusInformer.
+	SwitchStatuses() SwitchStatusInformer
+	// SwitchZtps returns a SwitchZtpInformer.
+	SwitchZtps() SwitchZtpInformer
 }
 
 type version struct {
@@ -34,21 +50,51 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// CompliancePolicies returns a CompliancePolicyInformer.
+func (v *version) CompliancePolicies() CompliancePolicyInformer {
+	return &compliancePolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// DhcpLeases returns a DhcpLeaseInformer.
+func (v *version) DhcpLeases() DhcpLeaseInformer {
+	return &dhcpLeaseInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Hosts returns a HostInformer.
+func (v *version) Hosts() HostInformer {
+	return &hostInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // HostEndpoints returns a HostEndpointInformer.
 func (v *version) HostEndpoints() HostEndpointInformer {
 	return &hostEndpointInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// HostGroups returns a HostGroupInformer.
+func (v *version) HostGroups() HostGroupInformer {
+	return &hostGroupInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // HostOperations returns a HostOperationInformer.
 func (v *version) HostOperations() HostOperationInformer {
 	return &hostOperationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ProvisionProfiles returns a ProvisionProfileInformer.
+func (v *version) ProvisionProfiles() ProvisionProfileInformer {
+	return &provisionProfileInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // RedfishStatuses returns a RedfishStatusInformer.
 func (v *version) RedfishStatuses() RedfishStatusInformer {
 	return &redfishStatusInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// SSHCollectors returns a SSHCollectorInformer.
+func (v *version) SSHCollectors() SSHCollectorInformer {
+	return &sSHCollectorInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // SSHStatuses returns a SSHStatusInformer.
 func (v *version) SSHStatuses() SSHStatusInformer {
 	return &sSHStatusInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -58,3 +104,13 @@ func (v *version) SSHStatuses() SSHStatusInformer {
 func (v *version) Subnets() SubnetInformer {
 	return &subnetInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// SwitchStatuses returns a SwitchStatusInformer.
+func (v *version) SwitchStatuses() SwitchStatusInformer {
+	return &switchStatusInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// SwitchZtps returns a SwitchZtpInformer.
+func (v *version) SwitchZtps() SwitchZtpInformer {
+	return &switchZtpInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
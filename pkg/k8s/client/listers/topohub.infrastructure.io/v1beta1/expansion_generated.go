@@ -5,18 +5,42 @@
 
 package v1beta1
 
+// CompliancePolicyListerExpansion allows custom methods to be added to
+// CompliancePolicyLister.
+type CompliancePolicyListerExpansion interface{}
+
+// DhcpLeaseListerExpansion allows custom methods to be added to
+// DhcpLeaseLister.
+type DhcpLeaseListerExpansion interface{}
+
+// HostListerExpansion allows custom methods to be added to
+// HostLister.
+type HostListerExpansion interface{}
+
 // HostEndpointListerExpansion allows custom methods to be added to
 // HostEndpointLister.
 type HostEndpointListerExpansion interface{}
 
+// HostGroupListerExpansion allows custom methods to be added to
+// HostGroupLister.
+type HostGroupListerExpansion interface{}
+
 // HostOperationListerExpansion allows custom methods to be added to
 // HostOperationLister.
 type HostOperationListerExpansion interface{}
 
+// ProvisionProfileListerExpansion allows custom methods to be added to
+// ProvisionProfileLister.
+type ProvisionProfileListerExpansion interface{}
+
 // RedfishStatusListerExpansion allows custom methods to be added to
 // RedfishStatusLister.
 type RedfishStatusListerExpansion interface{}
 
+// SSHCollectorListerExpansion allows custom methods to be added to
+// SSHCollectorLister.
+type SSHCollectorListerExpansion interface{}
+
 // SSHStatusListerExpansion allows custom methods to be added to
 // SSHStatusLister.
 type SSHStatusListerExpansion interface{}
@@ -24,3 +48,11 @@ type SSHStatusListerExpansion interface{}
 // SubnetListerExpansion allows custom methods to be added to
 // SubnetLister.
 type SubnetListerExpansion interface{}
+
+// SwitchStatusListerExpansion allows custom methods to be added to
+// SwitchStatusLister.
+type SwitchStatusListerExpansion interface{}
+
+// SwitchZtpListerExpansion allows custom methods to be added to
+// SwitchZtpLister.
+type SwitchZtpListerExpansion interface{}
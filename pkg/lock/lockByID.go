@@ -14,8 +14,9 @@ var LockManagerInstance = &LockManager{
 // GetLock retrieves a lock for the given ID, creating one if it doesn't exist
 func (lm *LockManager) GetLock(name string) *Mutex {
 
-	lm.mu.RLock()
-	defer lm.mu.RUnlock()
+	// the map may be written, so the read lock is not enough
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
 
 	if lock, ok := lm.locks[name]; ok {
 		return lock
@@ -77,6 +77,14 @@ func (i *internalMutex) Lock() {
 	i.Time = time.Now()
 }
 
+func (i *internalMutex) TryLock() bool {
+	if !i.Mutex.TryLock() {
+		return false
+	}
+	i.Time = time.Now()
+	return true
+}
+
 func (i *internalMutex) Unlock() {
 	if sec := time.Since(i.Time).Seconds(); sec >= selfishThresholdSec {
 		printStackTo(sec, debug.Stack(), os.Stderr)
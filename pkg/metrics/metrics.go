@@ -0,0 +1,151 @@
+// Package metrics defines the prometheus metrics of topohub, which are exposed by the metrics server of the controller manager
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespace = "topohub"
+
+	labelSubnet = "subnet"
+	labelFile   = "file"
+	labelResult = "result"
+)
+
+var (
+	// SubnetIPTotal is the total amount of ip in the ip range of the subnet
+	SubnetIPTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "subnet",
+		Name:      "ip_total",
+		Help:      "Total amount of ip in the ip range of the subnet",
+	}, []string{labelSubnet})
+
+	// SubnetIPAvailable is the amount of available ip of the subnet
+	SubnetIPAvailable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "subnet",
+		Name:      "ip_available",
+		Help:      "Amount of available ip of the subnet",
+	}, []string{labelSubnet})
+
+	// SubnetIPActive is the amount of ip in the lease file of the subnet
+	SubnetIPActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "subnet",
+		Name:      "ip_active",
+		Help:      "Amount of ip in the lease file of the subnet",
+	}, []string{labelSubnet})
+
+	// SubnetIPBind is the amount of ip bound to mac of the subnet
+	SubnetIPBind = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "subnet",
+		Name:      "ip_bind",
+		Help:      "Amount of ip bound to mac of the subnet",
+	}, []string{labelSubnet})
+
+	// SubnetIPUtilization is the ratio of the used ip of the subnet, from 0 to 1
+	SubnetIPUtilization = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "subnet",
+		Name:      "ip_utilization_ratio",
+		Help:      "Ratio of the used ip of the subnet, from 0 to 1",
+	}, []string{labelSubnet})
+
+	// HttpBytesServed is the bytes of the file served by the http server, including the partial content of the range requests
+	HttpBytesServed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "httpserver",
+		Name:      "bytes_served_total",
+		Help:      "Bytes of the file served by the http server",
+	}, []string{labelFile})
+
+	// HttpRequests is the amount of the successful requests of the file
+	HttpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "httpserver",
+		Name:      "requests_total",
+		Help:      "Amount of the successful requests of the file served by the http server",
+	}, []string{labelFile})
+
+	// TftpTransfers is the amount of the transfers of the built-in tftp server, the result is success or failure
+	TftpTransfers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tftp",
+		Name:      "transfers_total",
+		Help:      "Amount of the transfers of the built-in tftp server",
+	}, []string{labelSubnet, labelResult})
+
+	// TftpBytesSent is the bytes of the file sent by the built-in tftp server
+	TftpBytesSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tftp",
+		Name:      "bytes_sent_total",
+		Help:      "Bytes of the file sent by the built-in tftp server",
+	}, []string{labelSubnet, labelFile})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		SubnetIPTotal,
+		SubnetIPAvailable,
+		SubnetIPActive,
+		SubnetIPBind,
+		SubnetIPUtilization,
+		HttpBytesServed,
+		HttpRequests,
+		TftpTransfers,
+		TftpBytesSent,
+	)
+}
+
+// SetSubnetIPAmount updates the ip metrics of the subnet
+func SetSubnetIPAmount(subnet string, total, available, active, bind uint64) {
+	SubnetIPTotal.WithLabelValues(subnet).Set(float64(total))
+	SubnetIPAvailable.WithLabelValues(subnet).Set(float64(available))
+	SubnetIPActive.WithLabelValues(subnet).Set(float64(active))
+	SubnetIPBind.WithLabelValues(subnet).Set(float64(bind))
+	utilization := float64(0)
+	if total > 0 {
+		utilization = float64(total-available) / float64(total)
+	}
+	SubnetIPUtilization.WithLabelValues(subnet).Set(utilization)
+}
+
+// DeleteSubnet removes the metrics of the deleted subnet
+func DeleteSubnet(subnet string) {
+	SubnetIPTotal.DeleteLabelValues(subnet)
+	SubnetIPAvailable.DeleteLabelValues(subnet)
+	SubnetIPActive.DeleteLabelValues(subnet)
+	SubnetIPBind.DeleteLabelValues(subnet)
+	SubnetIPUtilization.DeleteLabelValues(subnet)
+	TftpTransfers.DeletePartialMatch(prometheus.Labels{labelSubnet: subnet})
+	TftpBytesSent.DeletePartialMatch(prometheus.Labels{labelSubnet: subnet})
+}
+
+// AddHttpBytesServed records a request of the file served by the http server
+func AddHttpBytesServed(file string, bytes int64) {
+	HttpBytesServed.WithLabelValues(file).Add(float64(bytes))
+	HttpRequests.WithLabelValues(file).Inc()
+}
+
+// DeleteHttpFile removes the metrics of the deleted file
+func DeleteHttpFile(file string) {
+	HttpBytesServed.DeleteLabelValues(file)
+	HttpRequests.DeleteLabelValues(file)
+}
+
+// AddTftpTransfer records a transfer of the built-in tftp server of the subnet
+func AddTftpTransfer(subnet, file string, bytes int64, success bool) {
+	result := "success"
+	if !success {
+		result = "failure"
+	}
+	TftpTransfers.WithLabelValues(subnet, result).Inc()
+	if bytes > 0 {
+		TftpBytesSent.WithLabelValues(subnet, file).Add(float64(bytes))
+	}
+}
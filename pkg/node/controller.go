@@ -0,0 +1,207 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+)
+
+// all the nodes are mapped together, so the events are merged into a single request
+var nodeRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "nodes"}}
+
+// NodeController finds the kubernetes node of each RedfishStatus, labels the node with the hardware inventory,
+// and uncordons the nodes which return Ready after the disruptive HostOperations
+type NodeController struct {
+	client  client.Client
+	drainer *Drainer
+	matchBy []string
+	log     *zap.SugaredLogger
+}
+
+func NewNodeController(mgr ctrl.Manager, kubeClient kubernetes.Interface, agentConfig *config.AgentConfig) *NodeController {
+	return &NodeController{
+		client:  mgr.GetClient(),
+		drainer: NewDrainer(kubeClient),
+		matchBy: agentConfig.NodeMatchBy,
+		log:     log.Logger.Named("nodeReconcile"),
+	}
+}
+
+// 只有 leader 才会执行 Reconcile
+func (r *NodeController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if err := r.syncAll(ctx); err != nil {
+		r.log.Errorf("failed to sync nodes: %v", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// listCandidates returns the RedfishStatus with the Host and SSHStatus linked to it
+func (r *NodeController) listCandidates(ctx context.Context) ([]candidate, error) {
+	redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+	if err := r.client.List(ctx, redfishStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list redfishstatus: %v", err)
+	}
+	sshStatusList := &topohubv1beta1.SSHStatusList{}
+	if err := r.client.List(ctx, sshStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list sshstatus: %v", err)
+	}
+	hostList := &topohubv1beta1.HostList{}
+	if err := r.client.List(ctx, hostList); err != nil {
+		return nil, fmt.Errorf("failed to list hosts: %v", err)
+	}
+
+	hosts := map[string]*topohubv1beta1.Host{}
+	for i := range hostList.Items {
+		if bmc := hostList.Items[i].Status.BMC; bmc != nil {
+			hosts[bmc.Name] = &hostList.Items[i]
+		}
+	}
+	sshStatus := map[string]*topohubv1beta1.SSHStatus{}
+	for i := range sshStatusList.Items {
+		sshStatus[sshStatusList.Items[i].Name] = &sshStatusList.Items[i]
+	}
+	candidates := make([]candidate, 0, len(redfishStatusList.Items))
+	for i := range redfishStatusList.Items {
+		c := candidate{redfishStatus: &redfishStatusList.Items[i], host: hosts[redfishStatusList.Items[i].Name]}
+		if c.host != nil && c.host.Status.SSH != nil {
+			c.sshStatus = sshStatus[c.host.Status.SSH.Name]
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, nil
+}
+
+// syncAll maps the nodes to the RedfishStatus, and updates the annotations of the RedfishStatus and the labels
+// of the nodes
+func (r *NodeController) syncAll(ctx context.Context) error {
+	candidates, err := r.listCandidates(ctx)
+	if err != nil {
+		return err
+	}
+	nodeList := &corev1.NodeList{}
+	if err := r.client.List(ctx, nodeList); err != nil {
+		return fmt.Errorf("failed to list nodes: %v", err)
+	}
+
+	mapping := resolve(candidates, nodeList.Items, r.matchBy)
+	nodeNames := map[string]string{}
+	for nodeName, c := range mapping {
+		nodeNames[c.redfishStatus.Name] = nodeName
+	}
+
+	var errs []string
+	for _, c := range candidates {
+		if err := r.updateRedfishStatus(ctx, c.redfishStatus, nodeNames[c.redfishStatus.Name]); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		c, ok := mapping[node.Name]
+		if err := r.updateNode(ctx, node, c, ok); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// updateRedfishStatus records the node of the RedfishStatus in the annotation
+func (r *NodeController) updateRedfishStatus(ctx context.Context, item *topohubv1beta1.RedfishStatus, nodeName string) error {
+	if item.Annotations[topohubv1beta1.AnnotationNodeName] == nodeName {
+		return nil
+	}
+	updated := item.DeepCopy()
+	if nodeName == "" {
+		delete(updated.Annotations, topohubv1beta1.AnnotationNodeName)
+	} else {
+		if updated.Annotations == nil {
+			updated.Annotations = map[string]string{}
+		}
+		updated.Annotations[topohubv1beta1.AnnotationNodeName] = nodeName
+	}
+	if err := r.client.Patch(ctx, updated, client.MergeFrom(item)); err != nil {
+		return fmt.Errorf("failed to update the node of redfishstatus %s: %v", item.Name, err)
+	}
+	r.log.Infof("the node of redfishstatus %s changes from %q to %q", item.Name, item.Annotations[topohubv1beta1.AnnotationNodeName], nodeName)
+	return nil
+}
+
+// updateNode sets the labels and annotations of the hardware inventory, and uncordons the node which has rebooted
+func (r *NodeController) updateNode(ctx context.Context, node *corev1.Node, c candidate, mapped bool) error {
+	updated := node.DeepCopy()
+	var labels, annotations map[string]string
+	if mapped {
+		labels, annotations = c.inventory()
+	}
+	changed := applyInventory(updated, labels, annotations)
+	uncordon := shouldUncordon(node)
+	if uncordon {
+		clearCordon(updated)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.drainer.patchNode(ctx, node, updated); err != nil {
+		return fmt.Errorf("failed to update node %s: %v", node.Name, err)
+	}
+	if uncordon {
+		r.log.Infof("uncordoned node %s, which returns Ready after HostOperation %s", node.Name, node.Annotations[annotationCordonedBy])
+	} else {
+		r.log.Debugf("updated the inventory labels of node %s", node.Name)
+	}
+	return nil
+}
+
+// nodeChanged ignores the heartbeats of the nodes, which update the status frequently
+func nodeChanged(e event.UpdateEvent) bool {
+	oldNode, ok := e.ObjectOld.(*corev1.Node)
+	if !ok {
+		return true
+	}
+	newNode, ok := e.ObjectNew.(*corev1.Node)
+	if !ok {
+		return true
+	}
+	return !reflect.DeepEqual(oldNode.Labels, newNode.Labels) ||
+		!reflect.DeepEqual(oldNode.Annotations, newNode.Annotations) ||
+		!reflect.DeepEqual(oldNode.Spec, newNode.Spec) ||
+		!reflect.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses) ||
+		oldNode.Status.NodeInfo != newNode.Status.NodeInfo ||
+		isReady(oldNode) != isReady(newNode)
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *NodeController) SetupWithManager(mgr ctrl.Manager) error {
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{nodeRequest}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("node").
+		Watches(&corev1.Node{}, enqueue, builder.WithPredicates(predicate.Funcs{UpdateFunc: nodeChanged})).
+		Watches(&topohubv1beta1.RedfishStatus{}, enqueue).
+		Watches(&topohubv1beta1.SSHStatus{}, enqueue).
+		Watches(&topohubv1beta1.Host{}, enqueue).
+		Complete(r)
+}
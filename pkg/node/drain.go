@@ -0,0 +1,179 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+)
+
+const (
+	// annotationCordonedBy is the HostOperation which cordons the node
+	annotationCordonedBy = topohubv1beta1.GroupName + "/cordoned-by"
+	// annotationCordonBootID is the boot id of the node when it is cordoned, the node is uncordoned after it boots again
+	annotationCordonBootID = topohubv1beta1.GroupName + "/cordon-boot-id"
+)
+
+// Drainer cordons, drains and uncordons the nodes for the disruptive HostOperations
+type Drainer struct {
+	kubeClient kubernetes.Interface
+	log        *zap.SugaredLogger
+}
+
+func NewDrainer(kubeClient kubernetes.Interface) *Drainer {
+	return &Drainer{
+		kubeClient: kubeClient,
+		log:        log.Logger.Named("nodeDrainer"),
+	}
+}
+
+// patchNode patches the changes of the node by the merge patch, which does not conflict with the status updates
+// of the kubelet
+func (d *Drainer) patchNode(ctx context.Context, old, updated *corev1.Node) error {
+	data, err := client.MergeFrom(old).Data(updated)
+	if err != nil {
+		return err
+	}
+	_, err = d.kubeClient.CoreV1().Nodes().Patch(ctx, old.Name, types.MergePatchType, data, metav1.PatchOptions{})
+	return err
+}
+
+// Cordon marks the node unschedulable for the HostOperation. It returns true when the node is cordoned by the
+// operation, and false when it has been cordoned by others, which is not uncordoned by topohub
+func (d *Drainer) Cordon(ctx context.Context, nodeName, owner string) (bool, error) {
+	node, err := d.kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if by, ok := node.Annotations[annotationCordonedBy]; ok {
+		// the operation is retried after it cordons the node
+		return by == owner, nil
+	}
+	if node.Spec.Unschedulable {
+		d.log.Infof("node %s has been cordoned by others, HostOperation %s does not uncordon it", nodeName, owner)
+		return false, nil
+	}
+
+	updated := node.DeepCopy()
+	updated.Spec.Unschedulable = true
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	updated.Annotations[annotationCordonedBy] = owner
+	updated.Annotations[annotationCordonBootID] = node.Status.NodeInfo.BootID
+	if err := d.patchNode(ctx, node, updated); err != nil {
+		return false, err
+	}
+	d.log.Infof("cordoned node %s for HostOperation %s", nodeName, owner)
+	return true, nil
+}
+
+// clearCordon makes the node schedulable, and removes the annotations of the cordon
+func clearCordon(node *corev1.Node) {
+	node.Spec.Unschedulable = false
+	delete(node.Annotations, annotationCordonedBy)
+	delete(node.Annotations, annotationCordonBootID)
+}
+
+// Uncordon makes the node schedulable again when it is cordoned by the HostOperation, such as when the action fails
+func (d *Drainer) Uncordon(ctx context.Context, nodeName, owner string) error {
+	node, err := d.kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if node.Annotations[annotationCordonedBy] != owner {
+		return nil
+	}
+	updated := node.DeepCopy()
+	clearCordon(updated)
+	if err := d.patchNode(ctx, node, updated); err != nil {
+		return err
+	}
+	d.log.Infof("uncordoned node %s for HostOperation %s", nodeName, owner)
+	return nil
+}
+
+// isEvictable checks if the pod should be evicted from the draining node. The pods of the DaemonSets and the static
+// pods are kept, like the kubectl drain
+func isEvictable(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller != nil && *owner.Controller && owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+	return true
+}
+
+// Evict evicts the pods of the node once, and returns the pods which are not gone. The evictions respect the
+// PodDisruptionBudgets, so the blocked ones are retried by the next call
+func (d *Drainer) Evict(ctx context.Context, nodeName string) ([]string, error) {
+	podList, err := d.kubeClient.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %v", err)
+	}
+	var pending []string
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !isEvictable(pod) {
+			continue
+		}
+		pending = append(pending, pod.Namespace+"/"+pod.Name)
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		err := d.kubeClient.PolicyV1().Evictions(pod.Namespace).Evict(ctx, &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		})
+		switch {
+		case err == nil:
+			d.log.Debugf("evicted pod %s/%s from node %s", pod.Namespace, pod.Name, nodeName)
+		case errors.IsNotFound(err):
+		case errors.IsTooManyRequests(err):
+			d.log.Debugf("the eviction of pod %s/%s is blocked by the PodDisruptionBudget, retry later", pod.Namespace, pod.Name)
+		default:
+			d.log.Warnf("failed to evict pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+	if len(pending) == 0 {
+		d.log.Infof("drained node %s", nodeName)
+	}
+	return pending, nil
+}
+
+// isReady checks the Ready condition of the node
+func isReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// shouldUncordon checks if the node cordoned by the HostOperation has rebooted and returned Ready
+func shouldUncordon(node *corev1.Node) bool {
+	if _, ok := node.Annotations[annotationCordonedBy]; !ok {
+		return false
+	}
+	bootID := node.Annotations[annotationCordonBootID]
+	return isReady(node) && bootID != "" && node.Status.NodeInfo.BootID != "" && node.Status.NodeInfo.BootID != bootID
+}
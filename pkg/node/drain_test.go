@@ -0,0 +1,60 @@
+package node
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestIsEvictable tests the pods of the DaemonSets, the static pods and the completed pods are not evicted
+func TestIsEvictable(t *testing.T) {
+	controller := true
+	tests := []struct {
+		name     string
+		pod      corev1.Pod
+		expected bool
+	}{
+		{name: "replicaset", pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Controller: &controller}}}}, expected: true},
+		{name: "daemonset", pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Controller: &controller}}}}},
+		{name: "static", pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{corev1.MirrorPodAnnotationKey: "x"}}}},
+		{name: "succeeded", pod: corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodSucceeded}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isEvictable(&tt.pod); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestShouldUncordon tests the node is uncordoned only after it reboots and returns Ready
+func TestShouldUncordon(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{annotationCordonedBy: "reboot-worker1", annotationCordonBootID: "boot-1"}},
+		Spec:       corev1.NodeSpec{Unschedulable: true},
+		Status: corev1.NodeStatus{
+			NodeInfo:   corev1.NodeSystemInfo{BootID: "boot-1"},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	if shouldUncordon(node) {
+		t.Errorf("expected the node not to be uncordoned before the reboot")
+	}
+
+	node.Status.NodeInfo.BootID = "boot-2"
+	node.Status.Conditions[0].Status = corev1.ConditionUnknown
+	if shouldUncordon(node) {
+		t.Errorf("expected the node not to be uncordoned before it is Ready")
+	}
+
+	node.Status.Conditions[0].Status = corev1.ConditionTrue
+	if !shouldUncordon(node) {
+		t.Errorf("expected the node to be uncordoned")
+	}
+	clearCordon(node)
+	if node.Spec.Unschedulable || len(node.Annotations) != 0 {
+		t.Errorf("unexpected node after uncordon: %+v", node)
+	}
+}
@@ -0,0 +1,315 @@
+package node
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/redfish"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+// the labels and annotations of the node sourced from the hardware inventory
+const (
+	labelGpuModel           = topohubv1beta1.GroupName + "/gpu-model"
+	labelGpuCount           = topohubv1beta1.GroupName + "/gpu-count"
+	labelCpuModel           = topohubv1beta1.GroupName + "/cpu-model"
+	labelMemoryGiB          = topohubv1beta1.GroupName + "/memory-gib"
+	annotationRedfishStatus = topohubv1beta1.GroupName + "/redfishstatus"
+	annotationBmcIP         = topohubv1beta1.GroupName + "/bmc-ip"
+	// the label values are sanitized, so the raw models are kept in the annotations of the same keys
+	annotationGpuModel = labelGpuModel
+	annotationCpuModel = labelCpuModel
+)
+
+// the labels and annotations set by topohub, the others on the node are kept
+var (
+	managedLabels      = []string{labelGpuModel, labelGpuCount, labelCpuModel, labelMemoryGiB}
+	managedAnnotations = []string{annotationRedfishStatus, annotationBmcIP, annotationGpuModel, annotationCpuModel}
+)
+
+// candidate is a RedfishStatus with the Host and SSHStatus linked to it
+type candidate struct {
+	redfishStatus *topohubv1beta1.RedfishStatus
+	// host and sshStatus are nil when they are not linked
+	host      *topohubv1beta1.Host
+	sshStatus *topohubv1beta1.SSHStatus
+}
+
+// hostnames returns the hostnames of the operating system reported by the redfish and the ssh
+func (c candidate) hostnames() []string {
+	var names []string
+	if name := strings.TrimSpace(c.redfishStatus.Status.Info["HostName"]); name != "" {
+		names = append(names, strings.ToLower(name))
+	}
+	if c.sshStatus != nil {
+		if name := strings.TrimSpace(c.sshStatus.Status.Info["Hostname"]); name != "" {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+	return names
+}
+
+// identities returns the system uuids and the serial numbers of the host
+func (c candidate) identities() []string {
+	info := c.redfishStatus.Status.Info
+	uuids := []string{info["SystemUUID"]}
+	serials := []string{info["SystemSerialNumber"], info["ChassisSerialNumber"]}
+	if c.host != nil {
+		uuids = append(uuids, c.host.Spec.SystemUUID)
+		serials = append(serials, c.host.Spec.SerialNumber)
+	}
+	var ids []string
+	for _, uuid := range uuids {
+		if uuid = tools.NormalizeSystemUUID(uuid); uuid != "" {
+			ids = append(ids, uuid)
+		}
+	}
+	for _, serial := range serials {
+		if serial = tools.NormalizeSerialNumber(serial); serial != "" {
+			ids = append(ids, strings.ToLower(serial))
+		}
+	}
+	return ids
+}
+
+// addresses returns the ip addresses leased or bound to the mac addresses of the host, except the BMC
+func (c candidate) addresses() map[string]bool {
+	ips := map[string]bool{}
+	if c.host == nil {
+		return ips
+	}
+	for _, item := range append(append([]topohubv1beta1.HostAddressRef{}, c.host.Status.DhcpLeases...), c.host.Status.BindingIps...) {
+		if item.IpAddr != "" && item.IpAddr != c.redfishStatus.Status.Basic.IpAddr {
+			ips[item.IpAddr] = true
+		}
+	}
+	return ips
+}
+
+// shortName returns the hostname without the domain
+func shortName(name string) string {
+	return strings.SplitN(name, ".", 2)[0]
+}
+
+// sameHostname compares the hostnames, the short names are compared only when one of them has no domain, so
+// node1.rack-a does not match node1.rack-b
+func sameHostname(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if strings.Contains(a, ".") && strings.Contains(b, ".") {
+		return false
+	}
+	return shortName(a) == shortName(b)
+}
+
+func matchHostname(c candidate, node *corev1.Node) bool {
+	nodeNames := []string{node.Name, node.Labels[corev1.LabelHostname]}
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == corev1.NodeHostName {
+			nodeNames = append(nodeNames, addr.Address)
+		}
+	}
+	for _, name := range c.hostnames() {
+		for _, nodeName := range nodeNames {
+			nodeName = strings.ToLower(nodeName)
+			if nodeName != "" && sameHostname(nodeName, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchProviderID checks the segments of the provider id, such as metal3://ns/name/<uuid>, and the uuid reported
+// by the kubelet
+func matchProviderID(c candidate, node *corev1.Node) bool {
+	segments := strings.FieldsFunc(strings.ToLower(node.Spec.ProviderID), func(r rune) bool { return r == '/' || r == ':' })
+	for _, id := range c.identities() {
+		if strings.ToLower(node.Status.NodeInfo.SystemUUID) == id {
+			return true
+		}
+		for _, segment := range segments {
+			if segment == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchMac(c candidate, node *corev1.Node) bool {
+	ips := c.addresses()
+	for _, addr := range node.Status.Addresses {
+		if (addr.Type == corev1.NodeInternalIP || addr.Type == corev1.NodeExternalIP) && ips[addr.Address] {
+			return true
+		}
+	}
+	return false
+}
+
+// matchNode returns the name of the node of the candidate by the ways in order. The way matching more than one
+// node is ambiguous, so it is skipped
+func matchNode(c candidate, nodes []corev1.Node, matchBy []string) string {
+	for _, way := range matchBy {
+		var found []string
+		for i := range nodes {
+			var matched bool
+			switch way {
+			case config.NodeMatchByHostname:
+				matched = matchHostname(c, &nodes[i])
+			case config.NodeMatchByProviderID:
+				matched = matchProviderID(c, &nodes[i])
+			case config.NodeMatchByMac:
+				matched = matchMac(c, &nodes[i])
+			}
+			if matched {
+				found = append(found, nodes[i].Name)
+			}
+		}
+		if len(found) == 1 {
+			return found[0]
+		}
+	}
+	return ""
+}
+
+// preferred checks if the candidate a is preferred over b for the same node, such as the RedfishStatus of the
+// previous BMC IP
+func preferred(a, b candidate) bool {
+	if a.redfishStatus.Status.Healthy != b.redfishStatus.Status.Healthy {
+		return a.redfishStatus.Status.Healthy
+	}
+	if a.redfishStatus.Status.LastUpdateTime != b.redfishStatus.Status.LastUpdateTime {
+		return a.redfishStatus.Status.LastUpdateTime > b.redfishStatus.Status.LastUpdateTime
+	}
+	return a.redfishStatus.Name < b.redfishStatus.Name
+}
+
+// resolve maps the nodes to the candidates, each node is mapped to one RedfishStatus at most
+func resolve(candidates []candidate, nodes []corev1.Node, matchBy []string) map[string]candidate {
+	result := map[string]candidate{}
+	for _, c := range candidates {
+		nodeName := matchNode(c, nodes, matchBy)
+		if nodeName == "" {
+			continue
+		}
+		if existing, ok := result[nodeName]; ok && !preferred(c, existing) {
+			continue
+		}
+		result[nodeName] = c
+	}
+	return result
+}
+
+// gpuModels returns the models of the gpus reported by the nvidia-smi, or the redfish when the ssh is not available
+func (c candidate) gpuModels() []string {
+	var models []string
+	if c.sshStatus != nil && c.sshStatus.Status.Inventory != nil {
+		for _, gpu := range c.sshStatus.Status.Inventory.Gpus {
+			models = append(models, gpu.Model)
+		}
+	}
+	if len(models) > 0 {
+		return models
+	}
+	info := c.redfishStatus.Status.Info
+	for key, value := range info {
+		if !strings.HasPrefix(key, "PCIeDevices[") || !strings.HasSuffix(key, "].DeviceType") || value != redfish.DeviceType_GPU {
+			continue
+		}
+		prefix := strings.TrimSuffix(key, "DeviceType")
+		model := info[prefix+"Model"]
+		if model == "" {
+			model = info[prefix+"Name"]
+		}
+		models = append(models, model)
+	}
+	return models
+}
+
+// mostCommon returns the most common value, the smaller one wins the tie
+func mostCommon(values []string) string {
+	counts := map[string]int{}
+	for _, v := range values {
+		if v != "" {
+			counts[v]++
+		}
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	result := ""
+	for _, k := range keys {
+		if result == "" || counts[k] > counts[result] {
+			result = k
+		}
+	}
+	return result
+}
+
+// inventory returns the labels and annotations of the node sourced from the hardware inventory of the candidate
+func (c candidate) inventory() (map[string]string, map[string]string) {
+	labels := map[string]string{}
+	annotations := map[string]string{
+		annotationRedfishStatus: c.redfishStatus.Name,
+		annotationBmcIP:         c.redfishStatus.Status.Basic.IpAddr,
+	}
+	set := func(labelKey, annotationKey, value string) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return
+		}
+		if v := tools.SanitizeLabelValue(value); v != "" {
+			labels[labelKey] = v
+		}
+		if annotationKey != "" {
+			annotations[annotationKey] = value
+		}
+	}
+
+	cpuModel := c.redfishStatus.Status.Info["CpuModel"]
+	if cpuModel == "" && c.sshStatus != nil {
+		cpuModel = c.sshStatus.Status.Info["CPU"]
+	}
+	set(labelCpuModel, annotationCpuModel, cpuModel)
+	if memory := c.redfishStatus.Status.Info["MemoryTotalGiB"]; memory != "0" {
+		set(labelMemoryGiB, "", memory)
+	}
+	if models := c.gpuModels(); len(models) > 0 {
+		set(labelGpuModel, annotationGpuModel, mostCommon(models))
+		labels[labelGpuCount] = strconv.Itoa(len(models))
+	}
+	return labels, annotations
+}
+
+// applyInventory sets the managed labels and annotations of the node, it returns true when the node changes
+func applyInventory(node *corev1.Node, labels, annotations map[string]string) bool {
+	changed := false
+	apply := func(current map[string]string, keys []string, desired map[string]string) map[string]string {
+		for _, key := range keys {
+			value, ok := desired[key]
+			if old, exists := current[key]; ok && (!exists || old != value) {
+				if current == nil {
+					current = map[string]string{}
+				}
+				current[key] = value
+				changed = true
+			} else if !ok && exists {
+				delete(current, key)
+				changed = true
+			}
+		}
+		return current
+	}
+	node.Labels = apply(node.Labels, managedLabels, labels)
+	node.Annotations = apply(node.Annotations, managedAnnotations, annotations)
+	return changed
+}
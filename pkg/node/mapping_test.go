@@ -0,0 +1,171 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+var allMatchBy = []string{config.NodeMatchByHostname, config.NodeMatchByProviderID, config.NodeMatchByMac}
+
+func newRedfishStatus(name, ip string, info map[string]string) *topohubv1beta1.RedfishStatus {
+	return &topohubv1beta1.RedfishStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: topohubv1beta1.RedfishStatusStatus{
+			Healthy: true,
+			Basic:   topohubv1beta1.BasicInfo{IpAddr: ip},
+			Info:    info,
+		},
+	}
+}
+
+func newNode(name, providerID, ip string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelHostname: name}},
+		Spec:       corev1.NodeSpec{ProviderID: providerID},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: ip}},
+		},
+	}
+}
+
+// TestMatchNode tests the node of the RedfishStatus is found by the ways in order
+func TestMatchNode(t *testing.T) {
+	nodes := []corev1.Node{
+		newNode("worker1", "metal3://ns/worker1/4c4c4544-0042-3610-8057-b4c04f4b4d32", "10.0.0.11"),
+		newNode("worker2.example.com", "", "10.0.0.12"),
+		newNode("worker3", "", "10.0.0.13"),
+		newNode("worker3.rack2", "", "10.0.1.13"),
+		newNode("worker4.rack-a", "", "10.0.0.14"),
+	}
+	host := &topohubv1beta1.Host{
+		Status: topohubv1beta1.HostStatus{
+			DhcpLeases: []topohubv1beta1.HostAddressRef{{IpAddr: "192.168.0.13"}, {IpAddr: "10.0.0.13"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		candidate candidate
+		matchBy   []string
+		expected  string
+	}{
+		{
+			name:      "hostname",
+			candidate: candidate{redfishStatus: newRedfishStatus("bmc2", "192.168.0.12", map[string]string{"HostName": "Worker2"})},
+			matchBy:   allMatchBy,
+			expected:  "worker2.example.com",
+		},
+		{
+			name:      "provider id",
+			candidate: candidate{redfishStatus: newRedfishStatus("bmc1", "192.168.0.11", map[string]string{"SystemUUID": "4C4C4544-0042-3610-8057-B4C04F4B4D32"})},
+			matchBy:   allMatchBy,
+			expected:  "worker1",
+		},
+		{
+			name:      "mac",
+			candidate: candidate{redfishStatus: newRedfishStatus("bmc3", "192.168.0.13", nil), host: host},
+			matchBy:   allMatchBy,
+			expected:  "worker3",
+		},
+		{
+			name:      "disabled way",
+			candidate: candidate{redfishStatus: newRedfishStatus("bmc3", "192.168.0.13", nil), host: host},
+			matchBy:   []string{config.NodeMatchByHostname},
+			expected:  "",
+		},
+		{
+			name:      "hostname without domain",
+			candidate: candidate{redfishStatus: newRedfishStatus("bmc5", "192.168.0.15", map[string]string{"HostName": "worker4"})},
+			matchBy:   []string{config.NodeMatchByHostname},
+			expected:  "worker4.rack-a",
+		},
+		{
+			name:      "hostname of another domain",
+			candidate: candidate{redfishStatus: newRedfishStatus("bmc5", "192.168.0.15", map[string]string{"HostName": "worker4.rack-b"})},
+			matchBy:   []string{config.NodeMatchByHostname},
+			expected:  "",
+		},
+		{
+			name:      "ambiguous hostname",
+			candidate: candidate{redfishStatus: newRedfishStatus("bmc4", "192.168.0.14", map[string]string{"HostName": "worker3"})},
+			matchBy:   allMatchBy,
+			expected:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchNode(tt.candidate, nodes, tt.matchBy); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestResolve tests the node is mapped to the healthy RedfishStatus when the BMC IP changes
+func TestResolve(t *testing.T) {
+	nodes := []corev1.Node{newNode("worker1", "", "10.0.0.11")}
+	old := newRedfishStatus("192-168-0-11", "192.168.0.11", map[string]string{"HostName": "worker1"})
+	old.Status.Healthy = false
+	current := newRedfishStatus("192-168-0-21", "192.168.0.21", map[string]string{"HostName": "worker1"})
+
+	mapping := resolve([]candidate{{redfishStatus: current}, {redfishStatus: old}}, nodes, allMatchBy)
+	if c, ok := mapping["worker1"]; !ok || c.redfishStatus.Name != current.Name {
+		t.Errorf("expected worker1 to be mapped to %s, got %+v", current.Name, mapping)
+	}
+}
+
+// TestInventory tests the labels and annotations of the node are sourced from the hardware inventory
+func TestInventory(t *testing.T) {
+	c := candidate{
+		redfishStatus: newRedfishStatus("bmc1", "192.168.0.11", map[string]string{
+			"CpuModel":                  "Intel(R) Xeon(R) Gold 6348 CPU @ 2.60GHz",
+			"MemoryTotalGiB":            "512",
+			"PCIeDevices[0].DeviceType": "GPU",
+			"PCIeDevices[0].Model":      "GH100 [H100 SXM5 80GB]",
+		}),
+		sshStatus: &topohubv1beta1.SSHStatus{
+			Status: topohubv1beta1.SSHStatusStatus{
+				Inventory: &topohubv1beta1.SSHInventory{
+					Gpus: []topohubv1beta1.SSHGpuInfo{{Model: "NVIDIA H100 80GB HBM3"}, {Model: "NVIDIA H100 80GB HBM3"}},
+				},
+			},
+		},
+	}
+
+	labels, annotations := c.inventory()
+	expectedLabels := map[string]string{
+		labelCpuModel:  "Intel-R-Xeon-R-Gold-6348-CPU-2.60GHz",
+		labelMemoryGiB: "512",
+		labelGpuModel:  "NVIDIA-H100-80GB-HBM3",
+		labelGpuCount:  "2",
+	}
+	if !reflect.DeepEqual(labels, expectedLabels) {
+		t.Errorf("unexpected labels: %v", labels)
+	}
+	if annotations[annotationGpuModel] != "NVIDIA H100 80GB HBM3" || annotations[annotationRedfishStatus] != "bmc1" {
+		t.Errorf("unexpected annotations: %v", annotations)
+	}
+
+	// the labels set by others are kept, and the stale inventory labels are removed
+	node := newNode("worker1", "", "10.0.0.11")
+	node.Labels[labelGpuModel] = "NVIDIA-A100"
+	node.Labels["gpu"] = "true"
+	if !applyInventory(&node, labels, annotations) {
+		t.Fatalf("expected the node to change")
+	}
+	if node.Labels[labelGpuModel] != "NVIDIA-H100-80GB-HBM3" || node.Labels["gpu"] != "true" {
+		t.Errorf("unexpected labels of the node: %v", node.Labels)
+	}
+	if applyInventory(&node, labels, annotations) {
+		t.Errorf("expected the node not to change")
+	}
+	if !applyInventory(&node, nil, nil) || node.Labels[labelGpuModel] != "" || len(node.Annotations) != 0 {
+		t.Errorf("expected the inventory to be removed, got %v %v", node.Labels, node.Annotations)
+	}
+}
@@ -0,0 +1,62 @@
+package provision
+
+import (
+	"sort"
+	"strings"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// HostMacAddrs returns the mac addresses of the pxe interfaces of the host
+func HostMacAddrs(item *topohubv1beta1.RedfishStatus) []string {
+	result := []string{}
+	if v, ok := item.Annotations[topohubv1beta1.AnnotationProvisionMac]; ok && v != "" {
+		for _, mac := range strings.Split(v, ",") {
+			if mac = strings.TrimSpace(mac); mac != "" {
+				result = append(result, strings.ToLower(mac))
+			}
+		}
+		return result
+	}
+	for key, value := range item.Status.Info {
+		if strings.HasSuffix(key, ".MACAddress") && value != "" {
+			result = append(result, strings.ToLower(value))
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// FindRedfishStatus returns the name of the redfishstatus which the in-band mac addresses or ip addresses belong to.
+// The host in provisioning is matched first, then the host with the same nic mac addresses reported by the bmc
+func FindRedfishStatus(items []topohubv1beta1.RedfishStatus, macAddrs, ipAddrs []string) string {
+	macs := make(map[string]struct{}, len(macAddrs))
+	for _, mac := range macAddrs {
+		macs[strings.ToLower(mac)] = struct{}{}
+	}
+	ips := make(map[string]struct{}, len(ipAddrs))
+	for _, ip := range ipAddrs {
+		ips[ip] = struct{}{}
+	}
+
+	for _, item := range items {
+		p := item.Status.Provision
+		if p == nil {
+			continue
+		}
+		if _, ok := macs[p.MacAddr]; ok && p.MacAddr != "" {
+			return item.Name
+		}
+		if _, ok := ips[p.IpAddr]; ok && p.IpAddr != "" {
+			return item.Name
+		}
+	}
+	for i := range items {
+		for _, mac := range HostMacAddrs(&items[i]) {
+			if _, ok := macs[mac]; ok {
+				return items[i].Name
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,135 @@
+// 跟踪主机从 PXE 重启到操作系统就绪的装机过程
+
+package provision
+
+import (
+	"strings"
+	"time"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// Event is reported by the components which observe the provisioning of a host
+type Event struct {
+	Phase string
+	// RedfishStatusName identifies the host for the PowerCycled phase
+	RedfishStatusName string
+	// HostOperation is the name of the HostOperation for the PowerCycled phase
+	HostOperation string
+	// MacAddr or IpAddr identifies the host for the other phases
+	MacAddr string
+	IpAddr  string
+	Message string
+	Time    time.Time
+}
+
+// the order of the phases, the provisioning only moves forward
+var phaseOrder = map[string]int{
+	topohubv1beta1.ProvisionPhasePowerCycled:       1,
+	topohubv1beta1.ProvisionPhaseDhcpSeen:          2,
+	topohubv1beta1.ProvisionPhaseBootFileFetched:   3,
+	topohubv1beta1.ProvisionPhaseInstallerCallback: 4,
+	topohubv1beta1.ProvisionPhaseOsReady:           5,
+}
+
+// SendEvent reports the event without blocking, the event is dropped when the tracker is busy or not running
+func SendEvent(ch chan Event, event Event) bool {
+	if ch == nil {
+		return false
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	select {
+	case ch <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// newProvisionStatus starts the provisioning of the host
+func newProvisionStatus(event Event) *topohubv1beta1.ProvisionStatus {
+	now := event.Time.UTC().Format(time.RFC3339)
+	return &topohubv1beta1.ProvisionStatus{
+		Phase:         topohubv1beta1.ProvisionPhasePowerCycled,
+		HostOperation: event.HostOperation,
+		StartTime:     now,
+		Phases: []topohubv1beta1.ProvisionPhaseRecord{
+			{
+				Phase:   topohubv1beta1.ProvisionPhasePowerCycled,
+				Time:    now,
+				Message: event.Message,
+			},
+		},
+	}
+}
+
+// matchHost checks whether the event belongs to the ongoing provisioning of the host
+func matchHost(item *topohubv1beta1.RedfishStatus, event Event) bool {
+	p := item.Status.Provision
+	if p == nil || p.Phase == topohubv1beta1.ProvisionPhaseOsReady {
+		return false
+	}
+	if event.MacAddr != "" {
+		mac := strings.ToLower(event.MacAddr)
+		if p.MacAddr != "" {
+			return p.MacAddr == mac
+		}
+		for _, m := range HostMacAddrs(item) {
+			if m == mac {
+				return true
+			}
+		}
+		return false
+	}
+	return event.IpAddr != "" && p.IpAddr == event.IpAddr
+}
+
+// advancePhase moves the provisioning to the phase of the event,
+// it returns false when the event happened before the provisioning or does not move it forward
+func advancePhase(p *topohubv1beta1.ProvisionStatus, event Event) bool {
+	start, err := time.Parse(time.RFC3339, p.StartTime)
+	if err != nil || event.Time.Before(start) {
+		return false
+	}
+	current := phaseOrder[p.Phase]
+	next, ok := phaseOrder[event.Phase]
+	if !ok || next <= current {
+		return false
+	}
+	// the ssh login to the old os may succeed before the host reboots
+	if event.Phase == topohubv1beta1.ProvisionPhaseOsReady && current < phaseOrder[topohubv1beta1.ProvisionPhaseDhcpSeen] {
+		return false
+	}
+
+	if p.MacAddr == "" && event.MacAddr != "" {
+		p.MacAddr = strings.ToLower(event.MacAddr)
+	}
+	if event.IpAddr != "" {
+		p.IpAddr = event.IpAddr
+	}
+	p.Phase = event.Phase
+	p.Phases = append(p.Phases, topohubv1beta1.ProvisionPhaseRecord{
+		Phase:          event.Phase,
+		Time:           event.Time.UTC().Format(time.RFC3339),
+		ElapsedSeconds: int64(event.Time.Sub(start).Seconds()),
+		Message:        event.Message,
+	})
+	return true
+}
+
+// WaitingForOsReady checks if a host with the ip is provisioning and waits for the new os to be ready. The ssh
+// login reports the OsReady phase only for such a host, so the healthy polls of the other hosts do not flood the tracker
+func WaitingForOsReady(items []topohubv1beta1.RedfishStatus, ip string) bool {
+	for i := range items {
+		p := items[i].Status.Provision
+		if p == nil || p.IpAddr != ip {
+			continue
+		}
+		if current := phaseOrder[p.Phase]; current >= phaseOrder[topohubv1beta1.ProvisionPhaseDhcpSeen] && current < phaseOrder[topohubv1beta1.ProvisionPhaseOsReady] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package provision
+
+import (
+	"testing"
+	"time"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestAdvancePhase tests that the provisioning only moves forward after the pxe reboot
+func TestAdvancePhase(t *testing.T) {
+	start := time.Unix(1735689600, 0)
+	p := newProvisionStatus(Event{Phase: topohubv1beta1.ProvisionPhasePowerCycled, HostOperation: "op1", Time: start})
+
+	if advancePhase(p, Event{Phase: topohubv1beta1.ProvisionPhaseDhcpSeen, MacAddr: "00:11:22:33:44:55", Time: start.Add(-time.Minute)}) {
+		t.Errorf("expected the event before the pxe reboot to be ignored")
+	}
+	if advancePhase(p, Event{Phase: topohubv1beta1.ProvisionPhaseOsReady, IpAddr: "192.168.1.10", Time: start.Add(time.Second)}) {
+		t.Errorf("expected the ssh login before the dhcp request to be ignored")
+	}
+	if !advancePhase(p, Event{Phase: topohubv1beta1.ProvisionPhaseDhcpSeen, MacAddr: "00:11:22:33:44:AA", IpAddr: "192.168.1.10", Time: start.Add(time.Minute)}) {
+		t.Fatalf("expected the dhcp request to advance the provisioning")
+	}
+	if !advancePhase(p, Event{Phase: topohubv1beta1.ProvisionPhaseInstallerCallback, Time: start.Add(10 * time.Minute)}) {
+		t.Fatalf("expected the installer callback to advance the provisioning")
+	}
+	if advancePhase(p, Event{Phase: topohubv1beta1.ProvisionPhaseBootFileFetched, Time: start.Add(11 * time.Minute)}) {
+		t.Errorf("expected the earlier phase to be ignored")
+	}
+
+	if p.Phase != topohubv1beta1.ProvisionPhaseInstallerCallback || p.MacAddr != "00:11:22:33:44:aa" || p.IpAddr != "192.168.1.10" {
+		t.Errorf("unexpected provisioning status: %+v", p)
+	}
+	if len(p.Phases) != 3 || p.Phases[2].ElapsedSeconds != 600 {
+		t.Errorf("unexpected phases: %+v", p.Phases)
+	}
+}
+
+// TestMatchHost tests the matching of the events by the mac and the ip
+func TestMatchHost(t *testing.T) {
+	item := &topohubv1beta1.RedfishStatus{}
+	item.Status.Info = map[string]string{
+		"PCIeDevices[0].Functions[0].EthernetInterfaces[0].MACAddress": "00:11:22:33:44:AA",
+	}
+	if matchHost(item, Event{MacAddr: "00:11:22:33:44:aa"}) {
+		t.Errorf("expected no match for the host without provisioning")
+	}
+
+	item.Status.Provision = &topohubv1beta1.ProvisionStatus{Phase: topohubv1beta1.ProvisionPhasePowerCycled}
+	if !matchHost(item, Event{MacAddr: "00:11:22:33:44:aa"}) {
+		t.Errorf("expected a match by the mac reported by the bmc")
+	}
+	if matchHost(item, Event{MacAddr: "00:11:22:33:44:bb"}) {
+		t.Errorf("expected no match for the other mac")
+	}
+
+	item.Annotations = map[string]string{topohubv1beta1.AnnotationProvisionMac: "00:11:22:33:44:bb"}
+	if !matchHost(item, Event{MacAddr: "00:11:22:33:44:BB"}) {
+		t.Errorf("expected a match by the mac in the annotation")
+	}
+
+	item.Status.Provision.IpAddr = "192.168.1.10"
+	if !matchHost(item, Event{IpAddr: "192.168.1.10"}) {
+		t.Errorf("expected a match by the ip")
+	}
+}
+
+// TestFindRedfishStatus tests the correlation of the in-band addresses with the redfishstatus
+func TestFindRedfishStatus(t *testing.T) {
+	items := []topohubv1beta1.RedfishStatus{{}, {}}
+	items[0].Name = "host1"
+	items[0].Status.Info = map[string]string{"PCIeDevices[0].Functions[0].EthernetInterfaces[0].MACAddress": "00:11:22:33:44:AA"}
+	items[1].Name = "host2"
+	items[1].Status.Provision = &topohubv1beta1.ProvisionStatus{IpAddr: "192.168.1.20"}
+
+	if name := FindRedfishStatus(items, []string{"00:11:22:33:44:aa"}, nil); name != "host1" {
+		t.Errorf("expected host1 matched by the nic mac, got %q", name)
+	}
+	if name := FindRedfishStatus(items, nil, []string{"10.0.0.1", "192.168.1.20"}); name != "host2" {
+		t.Errorf("expected host2 matched by the provisioning ip, got %q", name)
+	}
+	if name := FindRedfishStatus(items, []string{"00:11:22:33:44:bb"}, []string{"10.0.0.1"}); name != "" {
+		t.Errorf("expected no match, got %q", name)
+	}
+}
+
+// TestWaitingForOsReady tests only the host in provisioning after the dhcp request waits for the os ready
+func TestWaitingForOsReady(t *testing.T) {
+	items := []topohubv1beta1.RedfishStatus{{}, {}}
+	items[0].Status.Provision = &topohubv1beta1.ProvisionStatus{Phase: topohubv1beta1.ProvisionPhaseInstallerCallback, IpAddr: "192.168.1.10"}
+	items[1].Status.Provision = &topohubv1beta1.ProvisionStatus{Phase: topohubv1beta1.ProvisionPhaseOsReady, IpAddr: "192.168.1.20"}
+
+	if !WaitingForOsReady(items, "192.168.1.10") {
+		t.Errorf("expected the host to wait for the os ready")
+	}
+	if WaitingForOsReady(items, "192.168.1.20") || WaitingForOsReady(items, "192.168.1.30") {
+		t.Errorf("expected the finished and unknown hosts not to wait for the os ready")
+	}
+	items[0].Status.Provision.Phase = topohubv1beta1.ProvisionPhasePowerCycled
+	if WaitingForOsReady(items, "192.168.1.10") {
+		t.Errorf("expected the host before the dhcp request not to wait for the os ready")
+	}
+}
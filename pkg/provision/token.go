@@ -0,0 +1,25 @@
+package provision
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// HostToken returns the token of the host to call back the provisioning, which is derived from the phone-home token
+// by the mac address of the pxe interface. The token is rendered into the install files of the host, so a leaked one
+// could only report the provisioning of the same host
+func HostToken(key []byte, mac string) string {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(strings.ToLower(mac)))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyHostToken checks the token against the token of the host
+func VerifyHostToken(key []byte, mac, token string) bool {
+	if len(key) == 0 || token == "" {
+		return false
+	}
+	return hmac.Equal([]byte(HostToken(key, mac)), []byte(token))
+}
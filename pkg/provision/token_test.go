@@ -0,0 +1,18 @@
+package provision
+
+import "testing"
+
+// TestHostToken tests the token of a host is not accepted for the other hosts
+func TestHostToken(t *testing.T) {
+	key := []byte("phone-home-token")
+	token := HostToken(key, "00:11:22:33:44:AA")
+	if !VerifyHostToken(key, "00:11:22:33:44:aa", token) {
+		t.Errorf("expected the token to be accepted for the same host")
+	}
+	if VerifyHostToken(key, "00:11:22:33:44:bb", token) {
+		t.Errorf("expected the token to be rejected for the other host")
+	}
+	if VerifyHostToken([]byte("other"), "00:11:22:33:44:aa", token) || VerifyHostToken(nil, "00:11:22:33:44:aa", HostToken(nil, "00:11:22:33:44:aa")) {
+		t.Errorf("expected the token to be rejected for the other key")
+	}
+}
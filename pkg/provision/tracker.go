@@ -0,0 +1,156 @@
+package provision
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+)
+
+// ProvisionTracker records the provisioning lifecycle on the status of the redfishstatus
+type ProvisionTracker interface {
+	Stop()
+	SetupWithManager(ctrl.Manager) error
+	// the channel to report the provisioning events
+	GetEventChan() chan Event
+}
+
+type provisionTracker struct {
+	client   client.Client
+	config   *config.AgentConfig
+	eventCh  chan Event
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+	recorder record.EventRecorder
+	log      *zap.SugaredLogger
+}
+
+// NewProvisionTracker creates a new provisioning tracker
+func NewProvisionTracker(kubeClient kubernetes.Interface, config *config.AgentConfig, mgr ctrl.Manager) ProvisionTracker {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "provision-tracker"})
+
+	return &provisionTracker{
+		client:   mgr.GetClient(),
+		config:   config,
+		eventCh:  make(chan Event, 1000),
+		stopCh:   make(chan struct{}),
+		recorder: recorder,
+		log:      log.Logger.Named("provisionTracker"),
+	}
+}
+
+func (t *provisionTracker) GetEventChan() chan Event {
+	return t.eventCh
+}
+
+// Stop shuts down the tracker
+func (t *provisionTracker) Stop() {
+	close(t.stopCh)
+	t.wg.Wait()
+	t.log.Info("provision tracker stopped")
+}
+
+// SetupWithManager starts to process the events after elected as the leader,
+// the events on the other replicas are dropped when the channel is full
+func (t *provisionTracker) SetupWithManager(mgr ctrl.Manager) error {
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		select {
+		case <-mgr.Elected():
+		case <-t.stopCh:
+			return
+		}
+		t.log.Info("Elected as leader, begin to track the provisioning")
+		for {
+			select {
+			case <-t.stopCh:
+				return
+			case event := <-t.eventCh:
+				if err := t.processEvent(event); err != nil {
+					t.log.Errorf("Failed to process provisioning event %+v: %v", event, err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// processEvent updates the provisioning status of the hosts which the event belongs to
+func (t *provisionTracker) processEvent(event Event) error {
+	if event.Phase == topohubv1beta1.ProvisionPhasePowerCycled {
+		return t.updateProvision(event.RedfishStatusName, event)
+	}
+
+	list := &topohubv1beta1.RedfishStatusList{}
+	if err := t.client.List(context.Background(), list); err != nil {
+		return fmt.Errorf("failed to list redfishstatus: %v", err)
+	}
+	for i := range list.Items {
+		if !matchHost(&list.Items[i], event) {
+			continue
+		}
+		if err := t.updateProvision(list.Items[i].Name, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateProvision applies the event to the redfishstatus with retries for conflicts
+func (t *provisionTracker) updateProvision(name string, event Event) error {
+	maxRetries := 3
+	for i := 0; i < maxRetries; i++ {
+		existing := &topohubv1beta1.RedfishStatus{}
+		if err := t.client.Get(context.Background(), client.ObjectKey{Name: name}, existing); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to get redfishstatus %s: %v", name, err)
+		}
+		updated := existing.DeepCopy()
+
+		if event.Phase == topohubv1beta1.ProvisionPhasePowerCycled {
+			updated.Status.Provision = newProvisionStatus(event)
+		} else if updated.Status.Provision == nil || !matchHost(updated, event) || !advancePhase(updated.Status.Provision, event) {
+			return nil
+		}
+
+		if err := t.client.Status().Update(context.Background(), updated); err != nil {
+			if errors.IsConflict(err) {
+				t.log.Debugf("Conflict updating redfishstatus %s, will retry", name)
+				continue
+			}
+			return fmt.Errorf("failed to update redfishstatus %s: %v", name, err)
+		}
+
+		msg := fmt.Sprintf("the provisioning reaches the phase %s", event.Phase)
+		if event.Message != "" {
+			msg += ": " + event.Message
+		}
+		t.log.Infof("redfishstatus %s: %s", name, msg)
+		t.recorder.Event(&corev1.ObjectReference{
+			Kind:       topohubv1beta1.KindredfishStatus,
+			Name:       name,
+			Namespace:  t.config.PodNamespace,
+			APIVersion: topohubv1beta1.APIVersion,
+		}, corev1.EventTypeNormal, "Provision", msg)
+		return nil
+	}
+	return fmt.Errorf("failed to update redfishstatus %s after %d retries", name, maxRetries)
+}
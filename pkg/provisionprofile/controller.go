@@ -0,0 +1,416 @@
+package provisionprofile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+	"github.com/infrastructure-io/topohub/pkg/provision"
+	"github.com/infrastructure-io/topohub/pkg/tftp"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+// all the profiles are rendered together, so the events are merged into a single request
+var renderRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "provisionprofiles"}}
+
+// ProvisionProfileController renders the pxe boot configs for the hosts assigned to the ProvisionProfiles
+type ProvisionProfileController struct {
+	client      client.Client
+	agentConfig *config.AgentConfig
+	log         *zap.SugaredLogger
+	// the grub configs of the hosts in the subnets with the built-in tftp server are served from the memory,
+	// and they are not written to the disk
+	tftpFiles *tftp.MemoryFiles
+	// the phone-home token, which the callback tokens of the hosts are derived from
+	callbackKey []byte
+}
+
+func NewProvisionProfileController(mgr ctrl.Manager, agentConfig *config.AgentConfig, tftpFiles *tftp.MemoryFiles) *ProvisionProfileController {
+	return &ProvisionProfileController{
+		client:      mgr.GetClient(),
+		agentConfig: agentConfig,
+		tftpFiles:   tftpFiles,
+		log:         log.Logger.Named("provisionprofileReconcile"),
+	}
+}
+
+// candidateHost is a host found from the BindingIp or DhcpLease, with the labels of the object
+type candidateHost struct {
+	host   topohubv1beta1.ProvisionHost
+	labels labels.Set
+}
+
+// 只有 leader 才会执行 Reconcile
+func (r *ProvisionProfileController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if err := r.renderAll(ctx); err != nil {
+		r.log.Errorf("failed to render provisionprofiles: %v", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// renderAll renders the boot configs of all profiles, and removes the configs of the hosts which are not assigned
+func (r *ProvisionProfileController) renderAll(ctx context.Context) error {
+	profileList := &topohubv1beta1.ProvisionProfileList{}
+	if err := r.client.List(ctx, profileList); err != nil {
+		return fmt.Errorf("failed to list provisionprofiles: %v", err)
+	}
+	subnetList := &topohubv1beta1.SubnetList{}
+	if err := r.client.List(ctx, subnetList); err != nil {
+		return fmt.Errorf("failed to list subnets: %v", err)
+	}
+	subnets := make(map[string]*topohubv1beta1.Subnet)
+	for i := range subnetList.Items {
+		subnets[subnetList.Items[i].Name] = &subnetList.Items[i]
+	}
+	candidates, err := r.listCandidateHosts(ctx)
+	if err != nil {
+		return err
+	}
+	if r.callbackKey, err = r.getCallbackKey(ctx); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(r.agentConfig.StoragePathHttpPxe, ipxeBootScript), []byte(ipxeBootContent), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", ipxeBootScript, err)
+	}
+
+	// the profile with the smaller name wins when a host is assigned to multiple profiles
+	sort.Slice(profileList.Items, func(i, j int) bool {
+		return profileList.Items[i].Name < profileList.Items[j].Name
+	})
+	assigned := make(map[string]string)
+	memoryFiles := make(map[string]map[string][]byte)
+	for i := range profileList.Items {
+		profile := &profileList.Items[i]
+		hosts, msgs := r.renderProfile(profile, candidates, subnets, assigned, memoryFiles)
+		if err := r.updateProfileStatus(ctx, profile, hosts, msgs); err != nil {
+			r.log.Errorf("failed to update status of provisionprofile %s: %v", profile.Name, err)
+		}
+	}
+
+	if r.tftpFiles != nil {
+		r.tftpFiles.Replace(memoryFiles)
+	}
+	r.cleanupStaleFiles(assigned)
+	return nil
+}
+
+// getCallbackKey returns the token of the phone-home secret, or nil when the phone-home is disabled
+func (r *ProvisionProfileController) getCallbackKey(ctx context.Context) ([]byte, error) {
+	if r.agentConfig.PhoneHomeSecretName == "" {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Name: r.agentConfig.PhoneHomeSecretName, Namespace: r.agentConfig.PhoneHomeSecretNamespace}
+	if err := r.client.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the phone-home secret %s: %v", key, err)
+	}
+	return secret.Data["token"], nil
+}
+
+// listCandidateHosts returns the hosts from the BindingIp and DhcpLease objects, the key is the lowercase mac address
+func (r *ProvisionProfileController) listCandidateHosts(ctx context.Context) (map[string][]candidateHost, error) {
+	result := make(map[string][]candidateHost)
+
+	bindingIPList := &topohubv1beta1.BindingIpList{}
+	if err := r.client.List(ctx, bindingIPList); err != nil {
+		return nil, fmt.Errorf("failed to list bindingips: %v", err)
+	}
+	for _, item := range bindingIPList.Items {
+		mac := strings.ToLower(item.Spec.MacAddr)
+		result[mac] = append(result[mac], candidateHost{
+			host: topohubv1beta1.ProvisionHost{
+				MacAddr:  mac,
+				IpAddr:   item.Spec.IpAddr,
+				Hostname: item.Name,
+				Subnet:   item.Spec.Subnet,
+			},
+			labels: labels.Set(item.Labels),
+		})
+	}
+
+	// the binding ip is preferred, so the leases are appended after them
+	leaseList := &topohubv1beta1.DhcpLeaseList{}
+	if err := r.client.List(ctx, leaseList); err != nil {
+		return nil, fmt.Errorf("failed to list dhcpleases: %v", err)
+	}
+	for _, item := range leaseList.Items {
+		mac := strings.ToLower(item.Spec.MacAddr)
+		hostname := item.Spec.Hostname
+		if hostname == "" {
+			hostname = item.Name
+		}
+		result[mac] = append(result[mac], candidateHost{
+			host: topohubv1beta1.ProvisionHost{
+				MacAddr:  mac,
+				IpAddr:   item.Spec.IpAddr,
+				Hostname: hostname,
+				Subnet:   item.Spec.Subnet,
+			},
+			labels: labels.Set(item.Labels),
+		})
+	}
+
+	return result, nil
+}
+
+// selectHosts returns the hosts assigned to the profile, and the macs which are not found
+func selectHosts(profile *topohubv1beta1.ProvisionProfile, candidates map[string][]candidateHost) ([]topohubv1beta1.ProvisionHost, []string, error) {
+	selected := make(map[string]topohubv1beta1.ProvisionHost)
+	var notFound []string
+
+	for _, mac := range profile.Spec.Hosts.MacAddrs {
+		mac = strings.ToLower(mac)
+		if items, ok := candidates[mac]; ok {
+			selected[mac] = items[0].host
+		} else {
+			notFound = append(notFound, mac)
+		}
+	}
+
+	if profile.Spec.Hosts.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(profile.Spec.Hosts.Selector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid selector: %v", err)
+		}
+		for mac, items := range candidates {
+			if _, ok := selected[mac]; ok {
+				continue
+			}
+			for _, item := range items {
+				if selector.Matches(item.labels) {
+					selected[mac] = items[0].host
+					break
+				}
+			}
+		}
+	}
+
+	hosts := make([]topohubv1beta1.ProvisionHost, 0, len(selected))
+	for _, host := range selected {
+		hosts = append(hosts, host)
+	}
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].MacAddr < hosts[j].MacAddr
+	})
+	return hosts, notFound, nil
+}
+
+// renderProfile renders the boot configs of the hosts of the profile, and returns the rendered hosts and the problems
+func (r *ProvisionProfileController) renderProfile(profile *topohubv1beta1.ProvisionProfile, candidates map[string][]candidateHost,
+	subnets map[string]*topohubv1beta1.Subnet, assigned map[string]string, memoryFiles map[string]map[string][]byte) ([]topohubv1beta1.ProvisionHost, []string) {
+	var msgs []string
+
+	for _, file := range []string{profile.Spec.Kernel, profile.Spec.Initrd} {
+		// the webhook rejects the paths escaping from the http/iso directory, it is checked again for the old objects
+		if !tools.IsRelativePath(file) {
+			return nil, []string{fmt.Sprintf("%s is not a relative path under the http/iso directory", file)}
+		}
+		if _, err := os.Stat(filepath.Join(r.agentConfig.StoragePathHttpIso, file)); err != nil {
+			return nil, []string{fmt.Sprintf("%s is not found in the http/iso directory", file)}
+		}
+	}
+
+	hosts, notFound, err := selectHosts(profile, candidates)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	if len(notFound) > 0 {
+		msgs = append(msgs, fmt.Sprintf("no BindingIp or DhcpLease is found for the macs: %s", strings.Join(notFound, ",")))
+	}
+
+	var rendered []topohubv1beta1.ProvisionHost
+	for _, host := range hosts {
+		if owner, ok := assigned[host.MacAddr]; ok {
+			msgs = append(msgs, fmt.Sprintf("host %s has been assigned to the provisionprofile %s", host.MacAddr, owner))
+			continue
+		}
+		subnet, ok := subnets[host.Subnet]
+		if !ok || subnet.Spec.Feature == nil || !subnet.Spec.Feature.EnablePxe {
+			msgs = append(msgs, fmt.Sprintf("the pxe of the subnet %s is not enabled for host %s", host.Subnet, host.MacAddr))
+			continue
+		}
+
+		httpServer := tools.FormatHttpServer(strings.Split(subnet.Spec.Interface.IPv4, "/")[0], r.agentConfig.HttpPort)
+		inMemory := r.tftpFiles != nil && subnet.Spec.Feature.BuiltinTftp != nil && subnet.Spec.Feature.BuiltinTftp.Enabled
+		grubConfig, err := r.renderHost(profile, host, httpServer, inMemory)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("failed to render host %s: %v", host.MacAddr, err))
+			continue
+		}
+		assigned[host.MacAddr] = profile.Name
+		if inMemory {
+			// the grub config of the host is returned for both the config of the mac and the default grub.cfg
+			memoryFiles[host.MacAddr] = map[string][]byte{
+				tftpGrubDir + "/" + grubMacConfigPrefix + formatMacFileName(host.MacAddr): []byte(grubConfig),
+				tftpGrubConfig: []byte(grubConfig),
+			}
+		}
+		rendered = append(rendered, host)
+	}
+
+	return rendered, msgs
+}
+
+// renderHost writes the auto install files, the ipxe script and the grub config of the host, and returns the grub config.
+// The grub config is not written to the disk when it is served from the memory
+func (r *ProvisionProfileController) renderHost(profile *topohubv1beta1.ProvisionProfile, host topohubv1beta1.ProvisionHost, httpServer string, inMemory bool) (string, error) {
+	data := newTemplateData(profile, host, httpServer)
+	if len(r.callbackKey) > 0 {
+		data.CallbackToken = provision.HostToken(r.callbackKey, host.MacAddr)
+	}
+	kernelArgs, err := renderText("kernelArgs", profile.Spec.KernelArgs, data)
+	if err != nil {
+		return "", err
+	}
+	kernelArgs = strings.Join(strings.Fields(kernelArgs), " ")
+
+	files, err := renderAutoInstallFiles(profile, data)
+	if err != nil {
+		return "", err
+	}
+	macName := formatMacFileName(host.MacAddr)
+	hostDir := filepath.Join(r.agentConfig.StoragePathHttpPxe, macName)
+	if err := os.RemoveAll(hostDir); err != nil {
+		return "", fmt.Errorf("failed to clean %s: %v", hostDir, err)
+	}
+	if len(files) > 0 {
+		if err := os.MkdirAll(hostDir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create %s: %v", hostDir, err)
+		}
+		for name, content := range files {
+			if err := os.WriteFile(filepath.Join(hostDir, name), []byte(content), 0644); err != nil {
+				return "", fmt.Errorf("failed to write %s: %v", name, err)
+			}
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(r.agentConfig.StoragePathHttpPxe, macName+".ipxe"), []byte(renderIpxeScript(profile.Name, kernelArgs, data)), 0644); err != nil {
+		return "", fmt.Errorf("failed to write ipxe script: %v", err)
+	}
+	grubConfig := renderGrubConfig(profile.Name, kernelArgs, data)
+	grubFile := filepath.Join(r.agentConfig.StoragePathTftpGrub, grubMacConfigPrefix+macName)
+	if inMemory {
+		// the config written before the built-in tftp server is enabled is removed
+		if err := os.Remove(grubFile); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to remove grub config: %v", err)
+		}
+	} else if err := os.WriteFile(grubFile, []byte(grubConfig), 0644); err != nil {
+		return "", fmt.Errorf("failed to write grub config: %v", err)
+	}
+
+	r.log.Debugf("rendered the boot configs of host %s for provisionprofile %s", host.MacAddr, profile.Name)
+	return grubConfig, nil
+}
+
+// cleanupStaleFiles removes the boot configs of the hosts which are not assigned to any profile. Only the files
+// named by the mac addresses are written by topohub, the others in the directories are placed by the operators
+func (r *ProvisionProfileController) cleanupStaleFiles(assigned map[string]string) {
+	macNames := make(map[string]struct{}, len(assigned))
+	for mac := range assigned {
+		macNames[formatMacFileName(mac)] = struct{}{}
+	}
+
+	if entries, err := os.ReadDir(r.agentConfig.StoragePathTftpGrub); err == nil {
+		for _, entry := range entries {
+			name := entry.Name()
+			if !strings.HasPrefix(name, grubMacConfigPrefix) {
+				continue
+			}
+			if _, ok := macNames[strings.TrimPrefix(name, grubMacConfigPrefix)]; !ok {
+				r.log.Infof("remove stale grub config %s", name)
+				_ = os.Remove(filepath.Join(r.agentConfig.StoragePathTftpGrub, name))
+			}
+		}
+	}
+
+	if entries, err := os.ReadDir(r.agentConfig.StoragePathHttpPxe); err == nil {
+		for _, entry := range entries {
+			name := entry.Name()
+			macName := name
+			if entry.IsDir() {
+				if !isMacFileName(macName) {
+					continue
+				}
+			} else {
+				macName = strings.TrimSuffix(name, ".ipxe")
+				if macName == name || !isMacFileName(macName) {
+					continue
+				}
+			}
+			if _, ok := macNames[macName]; !ok {
+				r.log.Infof("remove stale pxe file %s", name)
+				_ = os.RemoveAll(filepath.Join(r.agentConfig.StoragePathHttpPxe, name))
+			}
+		}
+	}
+}
+
+// updateProfileStatus updates the rendered hosts and the Rendered condition of the profile
+func (r *ProvisionProfileController) updateProfileStatus(ctx context.Context, profile *topohubv1beta1.ProvisionProfile, hosts []topohubv1beta1.ProvisionHost, msgs []string) error {
+	updated := profile.DeepCopy()
+	updated.Status.Hosts = hosts
+	updated.Status.HostAmount = int32(len(hosts))
+
+	condition := metav1.Condition{
+		Type:    topohubv1beta1.ProvisionProfileConditionRendered,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Rendered",
+		Message: fmt.Sprintf("boot configs are rendered for %d hosts", len(hosts)),
+	}
+	if len(msgs) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "RenderFailed"
+		condition.Message = strings.Join(msgs, "; ")
+	}
+	meta.SetStatusCondition(&updated.Status.Conditions, condition)
+
+	if reflect.DeepEqual(profile.Status, updated.Status) {
+		return nil
+	}
+	if err := r.client.Status().Update(ctx, updated); err != nil {
+		return err
+	}
+	r.log.Infof("updated status of provisionprofile %s: %d hosts", profile.Name, len(hosts))
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ProvisionProfileController) SetupWithManager(mgr ctrl.Manager) error {
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{renderRequest}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("provisionprofile").
+		// the status updates of the profiles and subnets are ignored
+		Watches(&topohubv1beta1.ProvisionProfile{}, enqueue, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&topohubv1beta1.Subnet{}, enqueue, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&topohubv1beta1.BindingIp{}, enqueue).
+		Watches(&topohubv1beta1.DhcpLease{}, enqueue).
+		// the callback tokens are rendered again when the phone-home token changes
+		Watches(&corev1.Secret{}, enqueue, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == r.agentConfig.PhoneHomeSecretName && obj.GetNamespace() == r.agentConfig.PhoneHomeSecretNamespace
+		}))).
+		Complete(r)
+}
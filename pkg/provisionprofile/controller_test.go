@@ -0,0 +1,72 @@
+package provisionprofile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestCleanupStaleFiles tests only the stale files written by topohub are removed, the files of the operators are kept
+func TestCleanupStaleFiles(t *testing.T) {
+	pxeDir := t.TempDir()
+	r := &ProvisionProfileController{
+		agentConfig: &config.AgentConfig{StoragePathHttpPxe: pxeDir, StoragePathTftpGrub: t.TempDir()},
+		log:         zap.NewNop().Sugar(),
+	}
+
+	for _, name := range []string{"00-11-22-aa-bb-cc.ipxe", "00-11-22-aa-bb-dd.ipxe", ipxeBootScript, "custom.ipxe", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(pxeDir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"00-11-22-aa-bb-cc", "00-11-22-aa-bb-dd", "images"} {
+		if err := os.Mkdir(filepath.Join(pxeDir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r.cleanupStaleFiles(map[string]string{"00:11:22:aa:bb:cc": "ubuntu"})
+
+	for _, name := range []string{"00-11-22-aa-bb-cc.ipxe", "00-11-22-aa-bb-cc", ipxeBootScript, "custom.ipxe", "notes.txt", "images"} {
+		if _, err := os.Stat(filepath.Join(pxeDir, name)); err != nil {
+			t.Errorf("expected %s to be kept: %v", name, err)
+		}
+	}
+	for _, name := range []string{"00-11-22-aa-bb-dd.ipxe", "00-11-22-aa-bb-dd"} {
+		if _, err := os.Stat(filepath.Join(pxeDir, name)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed", name)
+		}
+	}
+}
+
+// TestRenderHostGrubConfig tests the grub config is written to the disk only when it is not served from the memory
+func TestRenderHostGrubConfig(t *testing.T) {
+	grubDir := t.TempDir()
+	r := &ProvisionProfileController{
+		agentConfig: &config.AgentConfig{StoragePathHttpPxe: t.TempDir(), StoragePathTftpGrub: grubDir},
+		log:         zap.NewNop().Sugar(),
+	}
+	profile := &topohubv1beta1.ProvisionProfile{Spec: topohubv1beta1.ProvisionProfileSpec{Kernel: "ubuntu/vmlinuz", Initrd: "ubuntu/initrd"}}
+	host := topohubv1beta1.ProvisionHost{MacAddr: "00:11:22:aa:bb:cc", IpAddr: "192.168.1.10", Subnet: "net0"}
+	grubFile := filepath.Join(grubDir, grubMacConfigPrefix+"00-11-22-aa-bb-cc")
+
+	grubConfig, err := r.renderHost(profile, host, "http://192.168.1.2", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content, err := os.ReadFile(grubFile); err != nil || string(content) != grubConfig {
+		t.Errorf("expected the grub config to be written: %v", err)
+	}
+
+	if _, err := r.renderHost(profile, host, "http://192.168.1.2", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(grubFile); !os.IsNotExist(err) {
+		t.Errorf("expected the grub config served from the memory to be removed from the disk")
+	}
+}
@@ -0,0 +1,151 @@
+// 生成 PXE 引导所需的 GRUB、iPXE 和自动安装配置
+
+package provisionprofile
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+const (
+	// the http directory of the iso, kernel and initrd
+	httpIsoDir = "iso"
+	// the http directory of the rendered ipxe scripts and auto install files
+	httpPxeDir = "pxe"
+
+	// the ipxe script which chains to the script of the mac address, it is the boot file for the ipxe clients
+	ipxeBootScript  = "boot.ipxe"
+	ipxeBootContent = `#!ipxe
+# generated by topohub, do not edit
+chain ${net0/mac:hexhyp}.ipxe || exit
+`
+
+	// the prefix of the grub config file for a mac address, which is searched by the grub netboot image
+	grubMacConfigPrefix = "grub.cfg-01-"
+	// the grub directory relative to the tftp root
+	tftpGrubDir = "boot/grub"
+	// the default grub config relative to the tftp root, which is generated in memory for each host when the built-in tftp server is used
+	tftpGrubConfig = tftpGrubDir + "/grub.cfg"
+)
+
+// templateData holds the variables for the kernel args and the auto install template
+type templateData struct {
+	// the address of the http server, like http://192.168.1.2
+	HttpServer     string
+	KernelUrl      string
+	InitrdUrl      string
+	IsoUrl         string
+	AutoInstallUrl string
+	MacAddr        string
+	IpAddr         string
+	Hostname       string
+	Subnet         string
+	// the token of the host to call back the provisioning, which is empty when the phone-home is disabled
+	CallbackToken string
+}
+
+// formatMacFileName returns the file name of a mac address
+// Example:
+//   - Input: "00:11:22:AA:BB:CC"
+//   - Returns: "00-11-22-aa-bb-cc"
+func formatMacFileName(mac string) string {
+	return strings.ToLower(strings.ReplaceAll(mac, ":", "-"))
+}
+
+// macFileNamePattern matches the names returned by formatMacFileName
+var macFileNamePattern = regexp.MustCompile(`^[0-9a-f]{2}(-[0-9a-f]{2}){5,19}$`)
+
+// isMacFileName checks if the file name is generated by formatMacFileName, which is written by topohub
+func isMacFileName(name string) bool {
+	return macFileNamePattern.MatchString(name)
+}
+
+// newTemplateData builds the template variables of a host for the profile
+func newTemplateData(profile *topohubv1beta1.ProvisionProfile, host topohubv1beta1.ProvisionHost, httpServer string) templateData {
+	data := templateData{
+		HttpServer: httpServer,
+		KernelUrl:  httpServer + "/" + path.Join(httpIsoDir, profile.Spec.Kernel),
+		InitrdUrl:  httpServer + "/" + path.Join(httpIsoDir, profile.Spec.Initrd),
+		MacAddr:    strings.ToLower(host.MacAddr),
+		IpAddr:     host.IpAddr,
+		Hostname:   host.Hostname,
+		Subnet:     host.Subnet,
+	}
+	if profile.Spec.Iso != nil {
+		data.IsoUrl = httpServer + "/" + path.Join(httpIsoDir, *profile.Spec.Iso)
+	}
+	if profile.Spec.AutoInstall != nil {
+		data.AutoInstallUrl = httpServer + "/" + path.Join(httpPxeDir, formatMacFileName(host.MacAddr)) + "/"
+		if profile.Spec.AutoInstall.Type == topohubv1beta1.AutoInstallTypeKickstart {
+			data.AutoInstallUrl += "ks.cfg"
+		}
+	}
+	return data
+}
+
+// renderText executes the go template with the data
+func renderText(name, text string, data templateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %v", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %v", name, err)
+	}
+	return buf.String(), nil
+}
+
+// renderAutoInstallFiles returns the auto install files of the host, the key is the file name
+func renderAutoInstallFiles(profile *topohubv1beta1.ProvisionProfile, data templateData) (map[string]string, error) {
+	if profile.Spec.AutoInstall == nil {
+		return nil, nil
+	}
+	content, err := renderText("autoInstall", profile.Spec.AutoInstall.Template, data)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Spec.AutoInstall.Type == topohubv1beta1.AutoInstallTypeKickstart {
+		return map[string]string{"ks.cfg": content}, nil
+	}
+	// the nocloud datasource requires the meta-data besides the user-data
+	instanceID := data.Hostname
+	if instanceID == "" {
+		instanceID = formatMacFileName(data.MacAddr)
+	}
+	return map[string]string{
+		"user-data": content,
+		"meta-data": fmt.Sprintf("instance-id: %s\nlocal-hostname: %s\n", instanceID, instanceID),
+	}, nil
+}
+
+// renderGrubConfig returns the grub config of the host, which loads the kernel and initrd by http
+func renderGrubConfig(profileName, kernelArgs string, data templateData) string {
+	server := strings.TrimPrefix(data.HttpServer, "http://")
+	kernel := strings.TrimPrefix(data.KernelUrl, data.HttpServer)
+	initrd := strings.TrimPrefix(data.InitrdUrl, data.HttpServer)
+	return fmt.Sprintf(`# generated by topohub for the provisionprofile %s, do not edit
+set timeout=3
+set default=0
+menuentry "install %s" {
+    linux (http,%s)%s %s
+    initrd (http,%s)%s
+}
+`, profileName, profileName, server, kernel, kernelArgs, server, initrd)
+}
+
+// renderIpxeScript returns the ipxe script of the host
+func renderIpxeScript(profileName, kernelArgs string, data templateData) string {
+	return fmt.Sprintf(`#!ipxe
+# generated by topohub for the provisionprofile %s, do not edit
+kernel %s initrd=%s %s
+initrd %s
+boot
+`, profileName, data.KernelUrl, path.Base(data.InitrdUrl), kernelArgs, data.InitrdUrl)
+}
@@ -0,0 +1,87 @@
+package provisionprofile
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+// TestRenderHostConfigs tests the kernel args, the auto install files and the boot configs of a host
+func TestRenderHostConfigs(t *testing.T) {
+	profile := &topohubv1beta1.ProvisionProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "ubuntu"},
+		Spec: topohubv1beta1.ProvisionProfileSpec{
+			Kernel:     "ubuntu/vmlinuz",
+			Initrd:     "ubuntu/initrd",
+			KernelArgs: "ip=dhcp autoinstall ds=nocloud-net;s={{ .AutoInstallUrl }}",
+			AutoInstall: &topohubv1beta1.AutoInstallSpec{
+				Type:     topohubv1beta1.AutoInstallTypeAutoinstall,
+				Template: "hostname: {{ .Hostname }}",
+			},
+		},
+	}
+	host := topohubv1beta1.ProvisionHost{MacAddr: "00:11:22:AA:BB:CC", IpAddr: "192.168.1.10", Hostname: "host1", Subnet: "net0"}
+
+	data := newTemplateData(profile, host, tools.FormatHttpServer("192.168.1.2", "8080"))
+	kernelArgs, err := renderText("kernelArgs", profile.Spec.KernelArgs, data)
+	if err != nil {
+		t.Fatalf("failed to render kernel args: %v", err)
+	}
+	if kernelArgs != "ip=dhcp autoinstall ds=nocloud-net;s=http://192.168.1.2:8080/pxe/00-11-22-aa-bb-cc/" {
+		t.Errorf("unexpected kernel args: %s", kernelArgs)
+	}
+
+	files, err := renderAutoInstallFiles(profile, data)
+	if err != nil {
+		t.Fatalf("failed to render auto install files: %v", err)
+	}
+	if files["user-data"] != "hostname: host1" || !strings.Contains(files["meta-data"], "instance-id: host1") {
+		t.Errorf("unexpected auto install files: %+v", files)
+	}
+
+	grub := renderGrubConfig(profile.Name, kernelArgs, data)
+	if !strings.Contains(grub, "linux (http,192.168.1.2:8080)/iso/ubuntu/vmlinuz ip=dhcp") {
+		t.Errorf("unexpected grub config: %s", grub)
+	}
+	ipxe := renderIpxeScript(profile.Name, kernelArgs, data)
+	if !strings.Contains(ipxe, "kernel http://192.168.1.2:8080/iso/ubuntu/vmlinuz initrd=initrd ip=dhcp") {
+		t.Errorf("unexpected ipxe script: %s", ipxe)
+	}
+
+	if _, err := renderText("kernelArgs", "{{ .Unknown }}", data); err == nil {
+		t.Errorf("expected error for unknown variable")
+	}
+}
+
+// TestSelectHosts tests the hosts selected by the mac addresses and the label selector
+func TestSelectHosts(t *testing.T) {
+	candidates := map[string][]candidateHost{
+		"00:00:00:00:00:01": {{host: topohubv1beta1.ProvisionHost{MacAddr: "00:00:00:00:00:01", Subnet: "net0"}, labels: labels.Set{"os": "ubuntu"}}},
+		"00:00:00:00:00:02": {{host: topohubv1beta1.ProvisionHost{MacAddr: "00:00:00:00:00:02", Subnet: "net0"}, labels: labels.Set{"os": "rocky"}}},
+		"00:00:00:00:00:03": {{host: topohubv1beta1.ProvisionHost{MacAddr: "00:00:00:00:00:03", Subnet: "net0"}}},
+	}
+	profile := &topohubv1beta1.ProvisionProfile{
+		Spec: topohubv1beta1.ProvisionProfileSpec{
+			Hosts: topohubv1beta1.ProvisionHostSelector{
+				MacAddrs: []string{"00:00:00:00:00:03", "00:00:00:00:00:09"},
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"os": "ubuntu"}},
+			},
+		},
+	}
+
+	hosts, notFound, err := selectHosts(profile, candidates)
+	if err != nil {
+		t.Fatalf("failed to select hosts: %v", err)
+	}
+	if len(hosts) != 2 || hosts[0].MacAddr != "00:00:00:00:00:01" || hosts[1].MacAddr != "00:00:00:00:00:03" {
+		t.Errorf("unexpected hosts: %+v", hosts)
+	}
+	if len(notFound) != 1 || notFound[0] != "00:00:00:00:00:09" {
+		t.Errorf("unexpected not found macs: %v", notFound)
+	}
+}
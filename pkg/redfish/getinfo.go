@@ -68,6 +68,8 @@ func (c *redfishClient) GetInfo() (map[string]string, error) {
 	system := ss[0]
 	// basic info
 	setData(result, "BiosVerison", system.BIOSVersion)
+	setData(result, "SystemSerialNumber", system.SerialNumber)
+	setData(result, "SystemUUID", system.UUID)
 	setData(result, "HostName", system.HostName)
 	setData(result, "Manufacturer", system.Manufacturer)
 	setData(result, "PowerState", string(system.PowerState))
@@ -204,6 +206,9 @@ func (c *redfishClient) GetInfo() (map[string]string, error) {
 	}
 
 	c.logger.Debugf("chassis amount: %d", len(cs))
+	if len(cs) > 0 {
+		setData(result, "ChassisSerialNumber", cs[0].SerialNumber)
+	}
 	for count, chassis := range cs {
 		pcieList, err := chassis.PCIeDevices()
 		if err != nil {
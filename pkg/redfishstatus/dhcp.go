@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 )
 
 const (
@@ -60,8 +61,9 @@ func (c *redfishStatusController) processDHCPEvents() {
 	}
 }
 
-func (c *redfishStatusController) createBindingIpForredfishstatus(client dhcpserver.DhcpClientInfo, ownerUid types.UID) (retry bool) {
-	name := formatRedfishStatusName(client.IP)
+func (c *redfishStatusController) createBindingIpForredfishstatus(client dhcpserver.DhcpClientInfo, name string, ownerUid types.UID) (retry bool) {
+	// the bindingIp is always named by the ip address
+	bindingIpName := formatRedfishStatusName(client.IP)
 
 	// creat bindingIp for the redfishstatus
 	if client.EnableBindIpForRedfishstatus == nil || !*client.EnableBindIpForRedfishstatus {
@@ -69,11 +71,11 @@ func (c *redfishStatusController) createBindingIpForredfishstatus(client dhcpser
 		return false
 	}
 
-	c.log.Debugf("checking to create bindip %s for redfishstatus %s", name, name)
+	c.log.Debugf("checking to create bindip %s for redfishstatus %s", bindingIpName, name)
 	setTrue := true
 	bindingIP := topohubv1beta1.BindingIp{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
+			Name: bindingIpName,
 			Labels: map[string]string{
 				topohubv1beta1.LabelRedfishStatus: name,
 			},
@@ -130,62 +132,132 @@ func (c *redfishStatusController) createBindingIpForredfishstatus(client dhcpser
 	return false
 }
 
+// syncDhcpClient updates the status of the redfishstatus by the dhcp client, and then the ip label. The label
+// is written after the status, because the webhook sets the label by the ip in the status
+func (c *redfishStatusController) syncDhcpClient(updated *topohubv1beta1.RedfishStatus, client dhcpserver.DhcpClientInfo) error {
+	name := updated.Name
+	status := updated.Status.DeepCopy()
+
+	// redfishstatus exists, check if MAC changed,  or if failed to update status after creating
+	if status.Basic.Mac != client.MAC {
+		// MAC changed, update the object
+		c.log.Infof("Updating redfishstatus %s: MAC changed from %s to %s",
+			name, status.Basic.Mac, client.MAC)
+		status.Basic.Mac = client.MAC
+	}
+	// the redfishstatus not named by the ip address follows the ip change of the dhcp client
+	if status.Basic.IpAddr != client.IP {
+		c.log.Infof("Updating redfishstatus %s: IP changed from %s to %s",
+			name, status.Basic.IpAddr, client.IP)
+		recordIpChange(status, client.IP, time.Now())
+	}
+	expireTimeStr := client.DhcpExpireTime.Format(time.RFC3339)
+	if status.Basic.DhcpExpireTime == nil || *status.Basic.DhcpExpireTime != expireTimeStr {
+		oldTime := ""
+		if status.Basic.DhcpExpireTime != nil {
+			oldTime = *status.Basic.DhcpExpireTime
+		}
+		// DHCP expire time changed, update the object
+		c.log.Infof("Updating redfishstatus %s: DHCP ip %s expire time changed from %s to %s",
+			name, &client.IP, oldTime, expireTimeStr)
+		status.Basic.DhcpExpireTime = &expireTimeStr
+	}
+
+	if !reflect.DeepEqual(updated.Status, *status) {
+		status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+		updated.Status = *status
+		if err := c.client.Status().Update(context.Background(), updated); err != nil {
+			return err
+		}
+		c.log.Infof("Successfully updated redfishstatus %s", name)
+	}
+
+	if updated.Labels[topohubv1beta1.LabelIPAddr] != client.IP {
+		if updated.Labels == nil {
+			updated.Labels = make(map[string]string)
+		}
+		updated.Labels[topohubv1beta1.LabelIPAddr] = client.IP
+		if err := c.client.Update(context.Background(), updated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // create the redfishstatus for the dhcp client
 func (c *redfishStatusController) handleDHCPAdd(client dhcpserver.DhcpClientInfo) error {
 
-	name := formatRedfishStatusName(client.IP)
 	c.log.Debugf("Processing DHCP add event: %+v ", client)
 
 	// Try to get existing redfishstatus
-	existing := &topohubv1beta1.RedfishStatus{}
-	err := c.client.Get(context.Background(), types.NamespacedName{Name: name}, existing)
-	if err == nil {
+	existing, err := c.findDhcpRedfishStatus(context.Background(), client)
+	if err != nil {
+		c.log.Errorf("Failed to get redfishstatus of dhcp client %s: %v", client.IP, err)
+		return err
+	}
+	if existing != nil {
+		name := existing.Name
 		// Create a copy of the existing object to avoid modifying the cache
 		updated := existing.DeepCopy()
-
-		// redfishstatus exists, check if MAC changed,  or if failed to update status after creating
-		if updated.Status.Basic.Mac != client.MAC {
-			// MAC changed, update the object
-			c.log.Infof("Updating redfishstatus %s: MAC changed from %s to %s",
-				name, updated.Status.Basic.Mac, client.MAC)
-			updated.Status.Basic.Mac = client.MAC
+		ipChanged := updated.Labels[topohubv1beta1.LabelIPAddr] != client.IP
+
+		// the status and the ip label are retried together with the latest object, so the label does not stay
+		// at the previous ip when one of the writes fails
+		err := retry.OnError(retry.DefaultBackoff, func(err error) bool { return !errors.IsNotFound(err) }, func() error {
+			if err := c.syncDhcpClient(updated, client); err != nil {
+				c.log.Debugf("Failed to update redfishstatus %s, will retry: %v", name, err)
+				latest := &topohubv1beta1.RedfishStatus{}
+				if getErr := c.client.Get(context.Background(), types.NamespacedName{Name: name}, latest); getErr != nil {
+					return getErr
+				}
+				updated = latest
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			c.log.Errorf("Failed to update redfishstatus %s: %v", name, err)
+			return err
 		}
-		expireTimeStr := client.DhcpExpireTime.Format(time.RFC3339)
-		if updated.Status.Basic.DhcpExpireTime == nil || *updated.Status.Basic.DhcpExpireTime != expireTimeStr {
-			oldTime := ""
-			if updated.Status.Basic.DhcpExpireTime != nil {
-				oldTime = *updated.Status.Basic.DhcpExpireTime
+		if ipChanged {
+			if err := c.deleteStaleBindingIps(name, client.IP); err != nil {
+				c.log.Errorf("Failed to delete the stale bindingIp of redfishstatus %s: %v", name, err)
+				return err
 			}
-			// DHCP expire time changed, update the object
-			c.log.Infof("Updating redfishstatus %s: DHCP ip %s expire time changed from %s to %s",
-				name, &client.IP, oldTime, expireTimeStr)
-			updated.Status.Basic.DhcpExpireTime = &expireTimeStr
 		}
 
-		if !reflect.DeepEqual(existing.Status, updated.Status) {
-			updated.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
-			if err := c.client.Status().Update(context.Background(), updated); err != nil {
-				if errors.IsConflict(err) {
-					c.log.Debugf("Conflict updating redfishstatus %s, will retry", name)
-					return err
-				}
-				c.log.Errorf("Failed to update redfishstatus %s: %v", name, err)
-				return err
+		// rename the redfishstatus when the naming strategy changes, or the serial number is known after the first redfish contact
+		current := updated
+		if desired, err := desiredRedfishStatusName(client.Naming, newNameVars(client, updated.Status.Info)); err != nil {
+			c.log.Warnf("Failed to name redfishstatus %s: %v", name, err)
+		} else if desired != name {
+			// the redfishstatus keeps the old name when the rename fails, and the rename is retried by the next update
+			if renamed, err := c.renameRedfishStatus(updated, desired); err != nil {
+				c.log.Errorf("Failed to rename redfishstatus %s to %s: %v", name, desired, err)
+			} else {
+				current = renamed
 			}
-			c.log.Infof("Successfully updated redfishstatus %s", name)
 		}
 
 		// make sure the binding ip
-		if c.createBindingIpForredfishstatus(client, existing.GetUID()) {
-			return fmt.Errorf("failed to create binding ip for redfishstatus %s: %+v", name, client)
+		if c.createBindingIpForredfishstatus(client, current.Name, current.GetUID()) {
+			return fmt.Errorf("failed to create binding ip for redfishstatus %s: %+v", current.Name, client)
 		}
 
 		return nil
 	}
 
-	if !errors.IsNotFound(err) {
-		c.log.Errorf("Failed to get redfishstatus %s: %v", name, err)
-		return err
+	name, err := desiredRedfishStatusName(client.Naming, newNameVars(client, nil))
+	if err != nil {
+		c.log.Warnf("Failed to name the redfishstatus of dhcp client %s, it is named by the mac address: %v", client.IP, err)
+		name = formatMacName(client.MAC)
+	}
+	if namingStrategy(client.Naming) != topohubv1beta1.NamingStrategyIP {
+		// the name such as the hostname may be taken by another host
+		if err := c.client.Get(context.Background(), types.NamespacedName{Name: name}, &topohubv1beta1.RedfishStatus{}); err == nil {
+			c.log.Warnf("redfishstatus %s exists for another host, the dhcp client %s is named by the mac address", name, client.IP)
+			name = formatMacName(client.MAC)
+		}
 	}
 
 	// check connecting to the host
@@ -296,7 +368,7 @@ func (c *redfishStatusController) handleDHCPAdd(client dhcpserver.DhcpClientInfo
 	c.log.Infof("Successfully created redfishstatus %s", name)
 	c.log.Debugf("DHCP client details - %+v", client)
 
-	if c.createBindingIpForredfishstatus(client, redfishstatus.GetUID()) {
+	if c.createBindingIpForredfishstatus(client, name, redfishstatus.GetUID()) {
 		return fmt.Errorf("failed to create binding ip for redfishstatus %s: %+v", name, client)
 	}
 
@@ -304,20 +376,24 @@ func (c *redfishStatusController) handleDHCPAdd(client dhcpserver.DhcpClientInfo
 }
 
 func (c *redfishStatusController) handleDHCPDelete(client dhcpserver.DhcpClientInfo) error {
-	name := formatRedfishStatusName(client.IP)
 	c.log.Debugf("Processing DHCP delete event - %+v", client)
 
 	// 获取现有的 redfishstatus
-	existing := &topohubv1beta1.RedfishStatus{}
-	err := c.client.Get(context.Background(), types.NamespacedName{Name: name}, existing)
+	existing, err := c.findDhcpRedfishStatus(context.Background(), client)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			c.log.Debugf("redfishstatus %s not found, skip labeling", name)
-			return nil
-		}
-		c.log.Errorf("Failed to get redfishstatus %s: %v", name, err)
+		c.log.Errorf("Failed to get redfishstatus of dhcp client %s: %v", client.IP, err)
 		return err
 	}
+	if existing == nil {
+		c.log.Debugf("redfishstatus of dhcp client %s not found, skip labeling", client.IP)
+		return nil
+	}
+	name := existing.Name
+	if existing.Status.Basic.IpAddr != client.IP {
+		// the lease of the previous ip expires
+		c.log.Debugf("redfishstatus %s has changed to ip %s, skip labeling", name, existing.Status.Basic.IpAddr)
+		return nil
+	}
 
 	// 创建更新对象的副本
 	updated := existing.DeepCopy()
@@ -0,0 +1,244 @@
+// 回收 dhcp 自动创建的、长期失效的 redfishstatus
+
+package redfishstatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+const (
+	// the interval between two garbage collections
+	gcInterval = time.Hour
+	// the default hours to retain the redfishstatus after its dhcp lease expires
+	defaultGCRetentionHours = 168
+
+	gcEventReason       = "GarbageCollected"
+	gcDryRunEventReason = "GarbageCollectDryRun"
+)
+
+// garbageCollectAtInterval collects the stale redfishstatus of the dhcp clients at an interval
+func (c *redfishStatusController) garbageCollectAtInterval() {
+	timer := time.NewTimer(time.Minute)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			c.log.Info("Stopping garbageCollectAtInterval")
+			return
+		case <-timer.C:
+			if err := c.garbageCollect(time.Now()); err != nil {
+				c.log.Errorf("Failed to collect the stale redfishstatus: %v", err)
+			}
+			timer.Reset(gcInterval)
+		}
+	}
+}
+
+// garbageCollect deletes or archives the stale redfishstatus, following the policy of their subnets
+func (c *redfishStatusController) garbageCollect(now time.Time) error {
+	ctx := context.Background()
+
+	subnetList := &topohubv1beta1.SubnetList{}
+	if err := c.client.List(ctx, subnetList); err != nil {
+		return fmt.Errorf("failed to list subnets: %v", err)
+	}
+	policies := make(map[string]*topohubv1beta1.RedfishStatusGCSpec)
+	for i := range subnetList.Items {
+		item := &subnetList.Items[i]
+		if item.Spec.Feature == nil || item.Spec.Feature.SyncRedfishstatus.GarbageCollection == nil {
+			continue
+		}
+		if item.Spec.Feature.SyncRedfishstatus.GarbageCollection.Enabled {
+			policies[item.Name] = item.Spec.Feature.SyncRedfishstatus.GarbageCollection
+		}
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+	if err := c.client.List(ctx, redfishStatusList, client.MatchingLabels{topohubv1beta1.LabelClientMode: topohubv1beta1.HostTypeDHCP}); err != nil {
+		return fmt.Errorf("failed to list redfishstatus: %v", err)
+	}
+
+	// the stale redfishstatus of each subnet
+	staleList := make(map[string][]string)
+	for i := range redfishStatusList.Items {
+		item := &redfishStatusList.Items[i]
+		subnetName := item.Labels[topohubv1beta1.LabelSubnetName]
+		if item.Status.Basic.SubnetName != nil {
+			subnetName = *item.Status.Basic.SubnetName
+		}
+		policy, ok := policies[subnetName]
+		if !ok {
+			continue
+		}
+
+		stale, reason := isStaleRedfishStatus(item, policy, now)
+		if !stale {
+			continue
+		}
+
+		if policy.DryRun {
+			c.log.Infof("dry-run: redfishstatus %s would be collected, %s", item.Name, reason)
+			c.recordRedfishStatusEvent(item.Name, corev1.EventTypeNormal, gcDryRunEventReason, "it would be collected: "+reason)
+			staleList[subnetName] = append(staleList[subnetName], item.Name)
+			continue
+		}
+
+		if err := c.collectRedfishStatus(ctx, item, policy.Action == topohubv1beta1.GCActionArchive); err != nil {
+			c.log.Errorf("Failed to collect redfishstatus %s: %v", item.Name, err)
+			continue
+		}
+		c.log.Infof("collected the stale redfishstatus %s, %s", item.Name, reason)
+		staleList[subnetName] = append(staleList[subnetName], item.Name)
+	}
+
+	// report the result by the events of subnets
+	for subnetName, names := range staleList {
+		sort.Strings(names)
+		reason := gcEventReason
+		msg := fmt.Sprintf("collected %d stale redfishstatus: %s", len(names), strings.Join(names, ","))
+		if policies[subnetName].DryRun {
+			reason = gcDryRunEventReason
+			msg = fmt.Sprintf("dry-run: %d stale redfishstatus would be collected: %s", len(names), strings.Join(names, ","))
+		}
+		c.recorder.Event(&corev1.ObjectReference{
+			Kind:       topohubv1beta1.KindSubnet,
+			Name:       subnetName,
+			Namespace:  c.config.PodNamespace,
+			APIVersion: topohubv1beta1.APIVersion,
+		}, corev1.EventTypeNormal, reason, msg)
+	}
+
+	return nil
+}
+
+// isStaleRedfishStatus checks whether the redfishstatus should be collected, and returns the reason
+func isStaleRedfishStatus(item *topohubv1beta1.RedfishStatus, policy *topohubv1beta1.RedfishStatusGCSpec, now time.Time) (bool, string) {
+	if item.Annotations[topohubv1beta1.AnnotationGCProtect] == "true" {
+		return false, ""
+	}
+	if item.Status.Basic.ActiveDhcpClient {
+		return false, ""
+	}
+
+	// the lease expire time is unknown for the redfishstatus created by the old version, so use the last update time
+	expireTime := item.Status.LastUpdateTime
+	if item.Status.Basic.DhcpExpireTime != nil && *item.Status.Basic.DhcpExpireTime != "" {
+		expireTime = *item.Status.Basic.DhcpExpireTime
+	}
+	expire, err := time.Parse(time.RFC3339, expireTime)
+	if err != nil {
+		return false, ""
+	}
+	// the retentionHours is at least 1 hour, it is not set for the subnet created before the default
+	retention := time.Duration(defaultGCRetentionHours) * time.Hour
+	if policy.RetentionHours > 0 {
+		retention = time.Duration(policy.RetentionHours) * time.Hour
+	}
+	if now.Sub(expire) < retention {
+		return false, ""
+	}
+	reason := fmt.Sprintf("the dhcp lease expired at %s", expireTime)
+
+	if policy.UnhealthyDays > 0 {
+		if item.Status.Healthy || item.Status.UnhealthySince == nil {
+			return false, ""
+		}
+		since, err := time.Parse(time.RFC3339, *item.Status.UnhealthySince)
+		if err != nil || now.Sub(since) < time.Duration(policy.UnhealthyDays)*24*time.Hour {
+			return false, ""
+		}
+		reason += fmt.Sprintf(", and it is unhealthy since %s", *item.Status.UnhealthySince)
+	}
+
+	return true, reason
+}
+
+// collectRedfishStatus deletes the redfishstatus and its bindingIp, and archives them to a configmap if required
+func (c *redfishStatusController) collectRedfishStatus(ctx context.Context, item *topohubv1beta1.RedfishStatus, archive bool) error {
+	bindingIPList := &topohubv1beta1.BindingIpList{}
+	if err := c.client.List(ctx, bindingIPList, client.MatchingLabels{topohubv1beta1.LabelRedfishStatus: item.Name}); err != nil {
+		return fmt.Errorf("failed to list bindingIp: %v", err)
+	}
+
+	if archive {
+		if err := c.archiveRedfishStatus(ctx, item, bindingIPList.Items); err != nil {
+			return err
+		}
+	}
+
+	for i := range bindingIPList.Items {
+		if err := c.client.Delete(ctx, &bindingIPList.Items[i]); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete bindingIp %s: %v", bindingIPList.Items[i].Name, err)
+		}
+	}
+	if err := c.client.Delete(ctx, item); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete redfishstatus: %v", err)
+	}
+	return nil
+}
+
+// archiveRedfishStatus saves the redfishstatus and its bindingIp to a configmap in the namespace of the agent
+func (c *redfishStatusController) archiveRedfishStatus(ctx context.Context, item *topohubv1beta1.RedfishStatus, bindingIps []topohubv1beta1.BindingIp) error {
+	data := map[string]string{}
+	redfishStatusData, err := json.Marshal(item.Status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal redfishstatus: %v", err)
+	}
+	data["redfishstatus"] = string(redfishStatusData)
+	for _, bindingIp := range bindingIps {
+		bindingIpData, err := json.Marshal(bindingIp.Spec)
+		if err != nil {
+			return fmt.Errorf("failed to marshal bindingIp: %v", err)
+		}
+		data["bindingip-"+bindingIp.Name] = string(bindingIpData)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "redfishstatus-archive-" + item.Name,
+			Namespace: c.config.PodNamespace,
+			Labels: map[string]string{
+				topohubv1beta1.LabelArchivedRedfishStatus: item.Name,
+			},
+			Annotations: map[string]string{
+				"archiveTime": time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+		Data: data,
+	}
+	if _, err := c.kubeClient.CoreV1().ConfigMaps(c.config.PodNamespace).Create(ctx, configMap, metav1.CreateOptions{}); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to archive redfishstatus to configmap: %v", err)
+		}
+		if _, err := c.kubeClient.CoreV1().ConfigMaps(c.config.PodNamespace).Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to archive redfishstatus to configmap: %v", err)
+		}
+	}
+	return nil
+}
+
+// recordRedfishStatusEvent records an event for the redfishstatus
+func (c *redfishStatusController) recordRedfishStatusEvent(name, eventType, reason, msg string) {
+	c.recorder.Event(&corev1.ObjectReference{
+		Kind:       topohubv1beta1.KindredfishStatus,
+		Name:       name,
+		Namespace:  c.config.PodNamespace,
+		APIVersion: topohubv1beta1.APIVersion,
+	}, eventType, reason, msg)
+}
@@ -0,0 +1,57 @@
+package redfishstatus
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestIsStaleRedfishStatus tests the garbage collection policy for the redfishstatus
+func TestIsStaleRedfishStatus(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	expire := now.Add(-48 * time.Hour).Format(time.RFC3339)
+	unhealthySince := now.Add(-72 * time.Hour).Format(time.RFC3339)
+
+	newItem := func() *topohubv1beta1.RedfishStatus {
+		return &topohubv1beta1.RedfishStatus{
+			ObjectMeta: metav1.ObjectMeta{Name: "192-168-1-10"},
+			Status: topohubv1beta1.RedfishStatusStatus{
+				UnhealthySince: &unhealthySince,
+				Basic: topohubv1beta1.BasicInfo{
+					DhcpExpireTime: &expire,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*topohubv1beta1.RedfishStatus)
+		policy topohubv1beta1.RedfishStatusGCSpec
+		stale  bool
+	}{
+		{"expired", func(*topohubv1beta1.RedfishStatus) {}, topohubv1beta1.RedfishStatusGCSpec{RetentionHours: 24}, true},
+		{"in retention", func(*topohubv1beta1.RedfishStatus) {}, topohubv1beta1.RedfishStatusGCSpec{RetentionHours: 72}, false},
+		{"active client", func(i *topohubv1beta1.RedfishStatus) { i.Status.Basic.ActiveDhcpClient = true }, topohubv1beta1.RedfishStatusGCSpec{RetentionHours: 24}, false},
+		{"protected", func(i *topohubv1beta1.RedfishStatus) {
+			i.Annotations = map[string]string{topohubv1beta1.AnnotationGCProtect: "true"}
+		}, topohubv1beta1.RedfishStatusGCSpec{RetentionHours: 24}, false},
+		{"unhealthy long enough", func(*topohubv1beta1.RedfishStatus) {}, topohubv1beta1.RedfishStatusGCSpec{RetentionHours: 24, UnhealthyDays: 2}, true},
+		{"unhealthy not long enough", func(*topohubv1beta1.RedfishStatus) {}, topohubv1beta1.RedfishStatusGCSpec{RetentionHours: 24, UnhealthyDays: 5}, false},
+		{"healthy", func(i *topohubv1beta1.RedfishStatus) {
+			i.Status.Healthy = true
+			i.Status.UnhealthySince = nil
+		}, topohubv1beta1.RedfishStatusGCSpec{RetentionHours: 24, UnhealthyDays: 2}, false},
+	}
+
+	for _, tt := range tests {
+		item := newItem()
+		tt.modify(item)
+		if stale, reason := isStaleRedfishStatus(item, &tt.policy, now); stale != tt.stale {
+			t.Errorf("%s: expected stale %v, got %v (%s)", tt.name, tt.stale, stale, reason)
+		}
+	}
+}
@@ -83,6 +83,8 @@ func (c *redfishStatusController) SetupWithManager(mgr ctrl.Manager) error {
 		go c.processDHCPEvents()
 		// 启动 redfishstatus spec.info 的	周期更新
 		go c.UpdateRedfishStatusAtInterval()
+		// 回收失效的 redfishstatus
+		go c.garbageCollectAtInterval()
 	}()
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -0,0 +1,285 @@
+package redfishstatus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/lock"
+	"github.com/infrastructure-io/topohub/pkg/subnet/dhcpserver"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+const (
+	// the max number of the previous ip addresses recorded in the status of the redfishstatus
+	maxPreviousIpAddrs = 10
+
+	renamedEventReason = "Renamed"
+)
+
+// nameVars are the variables of the naming template
+type nameVars struct {
+	IP          string
+	MAC         string
+	Hostname    string
+	Subnet      string
+	ClusterName string
+	// Serial is empty before the first redfish contact
+	Serial string
+}
+
+func namingStrategy(naming *topohubv1beta1.RedfishStatusNamingSpec) string {
+	if naming == nil || naming.Strategy == "" {
+		return topohubv1beta1.NamingStrategyIP
+	}
+	return naming.Strategy
+}
+
+// formatMacName returns the name of the redfishstatus named by the mac address, for example: b8-3f-d2-00-00-0a
+func formatMacName(mac string) string {
+	return strings.ToLower(strings.ReplaceAll(mac, ":", "-"))
+}
+
+func newNameVars(info dhcpserver.DhcpClientInfo, redfishInfo map[string]string) nameVars {
+	return nameVars{
+		IP:          info.IP,
+		MAC:         info.MAC,
+		Hostname:    info.Hostname,
+		Subnet:      info.SubnetName,
+		ClusterName: info.ClusterName,
+		Serial:      redfishSerialNumber(redfishInfo),
+	}
+}
+
+// redfishSerialNumber returns the serial number of the system, or the chassis when the system does not report it
+func redfishSerialNumber(info map[string]string) string {
+	if serial := tools.NormalizeSerialNumber(info["SystemSerialNumber"]); serial != "" {
+		return serial
+	}
+	return tools.NormalizeSerialNumber(info["ChassisSerialNumber"])
+}
+
+// desiredRedfishStatusName returns the name of the redfishstatus of the dhcp client by the naming strategy
+func desiredRedfishStatusName(naming *topohubv1beta1.RedfishStatusNamingSpec, vars nameVars) (string, error) {
+	switch namingStrategy(naming) {
+	case topohubv1beta1.NamingStrategyMAC:
+		return formatMacName(vars.MAC), nil
+	case topohubv1beta1.NamingStrategyHostname:
+		if name := tools.SanitizeObjectName(vars.Hostname); name != "" {
+			return name, nil
+		}
+		return formatMacName(vars.MAC), nil
+	case topohubv1beta1.NamingStrategySerial:
+		if name := tools.SanitizeObjectName(vars.Serial); name != "" {
+			return name, nil
+		}
+		// the serial number is unknown before the first redfish contact
+		return formatMacName(vars.MAC), nil
+	case topohubv1beta1.NamingStrategyTemplate:
+		tmpl, err := tools.ParseNamingTemplate(naming.Template)
+		if err != nil {
+			return "", fmt.Errorf("invalid naming template: %v", err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, vars); err != nil {
+			return "", fmt.Errorf("failed to render naming template: %v", err)
+		}
+		name := tools.SanitizeObjectName(buf.String())
+		if name == "" {
+			return "", fmt.Errorf("naming template renders an invalid name %q", buf.String())
+		}
+		return name, nil
+	default:
+		return formatRedfishStatusName(vars.IP), nil
+	}
+}
+
+// recordIpChange records the current ip address of the redfishstatus as a previous one, and changes it to the new ip
+func recordIpChange(status *topohubv1beta1.RedfishStatusStatus, ip string, now time.Time) {
+	if status.Basic.IpAddr == ip {
+		return
+	}
+	previous := []topohubv1beta1.PreviousIpAddr{}
+	if status.Basic.IpAddr != "" {
+		previous = append(previous, topohubv1beta1.PreviousIpAddr{
+			IpAddr:     status.Basic.IpAddr,
+			ChangeTime: now.UTC().Format(time.RFC3339),
+		})
+	}
+	for _, item := range status.PreviousIpAddrs {
+		if item.IpAddr != ip && item.IpAddr != status.Basic.IpAddr {
+			previous = append(previous, item)
+		}
+	}
+	if len(previous) > maxPreviousIpAddrs {
+		previous = previous[:maxPreviousIpAddrs]
+	}
+	status.PreviousIpAddrs = previous
+	status.Basic.IpAddr = ip
+}
+
+// findDhcpRedfishStatus returns the redfishstatus of the dhcp client, or nil when it does not exist. The redfishstatus
+// named by the ip address is got by the name, and the others are found by the mac address in the subnet
+func (c *redfishStatusController) findDhcpRedfishStatus(ctx context.Context, info dhcpserver.DhcpClientInfo) (*topohubv1beta1.RedfishStatus, error) {
+	if namingStrategy(info.Naming) == topohubv1beta1.NamingStrategyIP {
+		existing := &topohubv1beta1.RedfishStatus{}
+		if err := c.client.Get(ctx, types.NamespacedName{Name: formatRedfishStatusName(info.IP)}, existing); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+	if err := c.client.List(ctx, redfishStatusList, client.MatchingLabels{
+		topohubv1beta1.LabelClientMode: topohubv1beta1.HostTypeDHCP,
+		topohubv1beta1.LabelSubnetName: info.SubnetName,
+	}); err != nil {
+		return nil, err
+	}
+	var found *topohubv1beta1.RedfishStatus
+	for i := range redfishStatusList.Items {
+		item := &redfishStatusList.Items[i]
+		if !strings.EqualFold(item.Status.Basic.Mac, info.MAC) {
+			continue
+		}
+		if found == nil {
+			found = item
+			continue
+		}
+		// the objects created by the ip naming before may share the mac address, the one of the same ip
+		// is preferred, and then the latest one
+		sameIP, foundSameIP := item.Status.Basic.IpAddr == info.IP, found.Status.Basic.IpAddr == info.IP
+		if sameIP != foundSameIP {
+			if sameIP {
+				found = item
+			}
+			continue
+		}
+		if item.Status.LastUpdateTime > found.Status.LastUpdateTime {
+			found = item
+		}
+	}
+	return found, nil
+}
+
+// renameRedfishStatus moves the redfishstatus to the new name, its status, bindingIps and HostOperations are kept.
+// The rename is a one-time migration when the naming strategy changes or the serial number is known, the old object
+// is deleted, so the references to the old name outside topohub, such as the scripts and the dashboards, are not
+// migrated. The old name is recorded in the status of the new object. Only the pending and running HostOperations
+// are moved, the finished ones keep the name they operated
+func (c *redfishStatusController) renameRedfishStatus(old *topohubv1beta1.RedfishStatus, name string) (*topohubv1beta1.RedfishStatus, error) {
+	ctx := context.Background()
+	// wait for the periodic update of the old one
+	l := lock.LockManagerInstance.GetLock(old.Name)
+	l.Lock()
+	defer l.Unlock()
+
+	// the HostOperations are checked before the new object is created, the webhook only lets topohub move them,
+	// so the rename fails without leaving two objects when the identity of topohub is unknown
+	hostOpList := &topohubv1beta1.HostOperationList{}
+	if err := c.client.List(ctx, hostOpList); err != nil {
+		return nil, fmt.Errorf("failed to list hostOperation: %v", err)
+	}
+	var hostOps []*topohubv1beta1.HostOperation
+	for i := range hostOpList.Items {
+		item := &hostOpList.Items[i]
+		if item.Spec.RedfishStatusName == old.Name && tools.IsUnfinishedOperation(item) {
+			hostOps = append(hostOps, item)
+		}
+	}
+	if len(hostOps) > 0 && c.config.ControllerUsername() == "" {
+		return nil, fmt.Errorf("the service account of topohub is unknown, the %d hostOperations of redfishstatus %s could not be moved", len(hostOps), old.Name)
+	}
+
+	renamed := &topohubv1beta1.RedfishStatus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      old.Labels,
+			Annotations: old.Annotations,
+		},
+	}
+	if err := c.client.Create(ctx, renamed); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return nil, err
+		}
+		// continue the rename which is interrupted after the creation
+		if err := c.client.Get(ctx, types.NamespacedName{Name: name}, renamed); err != nil {
+			return nil, err
+		}
+		// the status of the new object is already moved from the old one when the rename is interrupted after it
+		if len(renamed.Status.Basic.IpAddr) != 0 && !slices.Contains(renamed.Status.PreviousNames, old.Name) {
+			return nil, fmt.Errorf("redfishstatus %s already exists for another host", name)
+		}
+	}
+	renamed.Status = *old.Status.DeepCopy()
+	renamed.Status.PreviousNames = append([]string{old.Name}, old.Status.PreviousNames...)
+	if err := c.client.Status().Update(ctx, renamed); err != nil {
+		return nil, err
+	}
+
+	bindingIPList := &topohubv1beta1.BindingIpList{}
+	if err := c.client.List(ctx, bindingIPList, client.MatchingLabels{topohubv1beta1.LabelRedfishStatus: old.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list bindingIp: %v", err)
+	}
+	for i := range bindingIPList.Items {
+		item := bindingIPList.Items[i].DeepCopy()
+		item.Labels[topohubv1beta1.LabelRedfishStatus] = name
+		for j := range item.OwnerReferences {
+			if item.OwnerReferences[j].Kind == topohubv1beta1.KindredfishStatus && item.OwnerReferences[j].Name == old.Name {
+				item.OwnerReferences[j].Name = name
+				item.OwnerReferences[j].UID = renamed.UID
+			}
+		}
+		if err := c.client.Update(ctx, item); err != nil {
+			return nil, fmt.Errorf("failed to move bindingIp %s: %v", item.Name, err)
+		}
+	}
+
+	for _, hostOp := range hostOps {
+		item := hostOp.DeepCopy()
+		item.Spec.RedfishStatusName = name
+		if err := c.client.Update(ctx, item); err != nil {
+			return nil, fmt.Errorf("failed to move hostOperation %s: %v", item.Name, err)
+		}
+	}
+
+	if err := c.client.Delete(ctx, old); err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to delete redfishstatus %s: %v", old.Name, err)
+	}
+	c.log.Infof("renamed redfishstatus %s to %s", old.Name, name)
+	c.recordRedfishStatusEvent(name, corev1.EventTypeNormal, renamedEventReason, fmt.Sprintf("renamed from %s by the naming strategy", old.Name))
+	return renamed, nil
+}
+
+// deleteStaleBindingIps deletes the bindingIps of the redfishstatus which bind the previous ip addresses
+func (c *redfishStatusController) deleteStaleBindingIps(name, ip string) error {
+	ctx := context.Background()
+	bindingIPList := &topohubv1beta1.BindingIpList{}
+	if err := c.client.List(ctx, bindingIPList, client.MatchingLabels{topohubv1beta1.LabelRedfishStatus: name}); err != nil {
+		return fmt.Errorf("failed to list bindingIp: %v", err)
+	}
+	for i := range bindingIPList.Items {
+		item := &bindingIPList.Items[i]
+		if item.Spec.IpAddr == ip {
+			continue
+		}
+		if err := c.client.Delete(ctx, item); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete bindingIp %s: %v", item.Name, err)
+		}
+		c.log.Infof("deleted bindingIp %s of the previous ip %s of redfishstatus %s", item.Name, item.Spec.IpAddr, name)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package redfishstatus
+
+import (
+	"testing"
+	"time"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestDesiredRedfishStatusName tests the redfishstatus of the dhcp client is named by the naming strategy
+func TestDesiredRedfishStatusName(t *testing.T) {
+	vars := nameVars{IP: "192.168.1.10", MAC: "B8:3F:D2:00:00:0A", Hostname: "Node_01", Subnet: "bmc-net", ClusterName: "gpu"}
+	withSerial := vars
+	withSerial.Serial = "CN7016"
+
+	tests := []struct {
+		name     string
+		naming   *topohubv1beta1.RedfishStatusNamingSpec
+		vars     nameVars
+		expected string
+		err      bool
+	}{
+		{name: "default", vars: vars, expected: "192-168-1-10"},
+		{name: "mac", naming: &topohubv1beta1.RedfishStatusNamingSpec{Strategy: "mac"}, vars: vars, expected: "b8-3f-d2-00-00-0a"},
+		{name: "hostname", naming: &topohubv1beta1.RedfishStatusNamingSpec{Strategy: "hostname"}, vars: vars, expected: "node-01"},
+		{name: "empty hostname", naming: &topohubv1beta1.RedfishStatusNamingSpec{Strategy: "hostname"}, vars: nameVars{MAC: vars.MAC}, expected: "b8-3f-d2-00-00-0a"},
+		{name: "serial before the redfish contact", naming: &topohubv1beta1.RedfishStatusNamingSpec{Strategy: "serial"}, vars: vars, expected: "b8-3f-d2-00-00-0a"},
+		{name: "serial", naming: &topohubv1beta1.RedfishStatusNamingSpec{Strategy: "serial"}, vars: withSerial, expected: "cn7016"},
+		{name: "template", naming: &topohubv1beta1.RedfishStatusNamingSpec{Strategy: "template", Template: "{{ .ClusterName }}-{{ .Hostname }}"}, vars: vars, expected: "gpu-node-01"},
+		{name: "invalid template", naming: &topohubv1beta1.RedfishStatusNamingSpec{Strategy: "template", Template: "{{ .Unknown }}"}, vars: vars, err: true},
+		{name: "empty result", naming: &topohubv1beta1.RedfishStatusNamingSpec{Strategy: "template", Template: "{{ .Serial }}"}, vars: vars, err: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := desiredRedfishStatusName(tt.naming, tt.vars)
+			if (err != nil) != tt.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestRecordIpChange tests the previous ip addresses are recorded with the latest one first
+func TestRecordIpChange(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	status := &topohubv1beta1.RedfishStatusStatus{Basic: topohubv1beta1.BasicInfo{IpAddr: "192.168.1.10"}}
+
+	recordIpChange(status, "192.168.1.10", now)
+	if len(status.PreviousIpAddrs) != 0 {
+		t.Fatalf("expected no change, got %+v", status.PreviousIpAddrs)
+	}
+
+	recordIpChange(status, "192.168.1.20", now)
+	recordIpChange(status, "192.168.1.10", now.Add(time.Hour))
+	if status.Basic.IpAddr != "192.168.1.10" || len(status.PreviousIpAddrs) != 1 || status.PreviousIpAddrs[0].IpAddr != "192.168.1.20" {
+		t.Errorf("unexpected previous ip addresses: %+v", status.PreviousIpAddrs)
+	}
+	if status.PreviousIpAddrs[0].ChangeTime != "2025-01-10T01:00:00Z" {
+		t.Errorf("unexpected change time: %s", status.PreviousIpAddrs[0].ChangeTime)
+	}
+}
@@ -160,6 +160,14 @@ func (c *redfishStatusController) UpdateRedfishStatusInfo(name string, d *redfis
 		}
 	}
 
+	// record the time since when the host is unhealthy, which is used by the garbage collection
+	if updated.Status.Healthy {
+		updated.Status.UnhealthySince = nil
+	} else if updated.Status.UnhealthySince == nil {
+		now := time.Now().UTC().Format(time.RFC3339)
+		updated.Status.UnhealthySince = &now
+	}
+
 	// 更新 RedfishStatus
 	if !compareRedfishStatus(updated.Status, existing.Status, c.log) {
 		c.log.Debugf("status changed, existing: %v, updated: %v", existing.Status, updated.Status)
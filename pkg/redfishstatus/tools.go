@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/tools"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"go.uber.org/zap"
@@ -47,6 +48,13 @@ func compareRedfishStatus(a, b topohubv1beta1.RedfishStatusStatus, logger *zap.S
 		return false
 	}
 
+	if !tools.StringPtrEqual(a.UnhealthySince, b.UnhealthySince) {
+		if logger != nil {
+			logger.Debugf("compareRedfishStatus UnhealthySince changed: %v -> %v", b.UnhealthySince, a.UnhealthySince)
+		}
+		return false
+	}
+
 	// 比较Basic字段
 	if a.Basic.Type != b.Basic.Type {
 		if logger != nil {
@@ -0,0 +1,137 @@
+package sshstatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/sshstatus/ssh"
+)
+
+const defaultCollectorTimeout = 30 * time.Second
+
+// collectorRun records the last run of a collector on a host, the collector runs again when its spec changes
+type collectorRun struct {
+	time       time.Time
+	generation int64
+}
+
+// parseCollectorOutput converts the output of the collector to the info keys prefixed by the name of the collector
+func parseCollectorOutput(name, parser, output string) (map[string]string, error) {
+	result := make(map[string]string)
+	switch parser {
+	case "", topohubv1beta1.SSHCollectorParserRaw:
+		result[name+"/output"] = strings.TrimSpace(output)
+	case topohubv1beta1.SSHCollectorParserJson:
+		fields := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(output), &fields); err != nil {
+			return nil, fmt.Errorf("the output is not a json object: %v", err)
+		}
+		for k, v := range fields {
+			if s, ok := v.(string); ok {
+				result[name+"/"+k] = s
+				continue
+			}
+			data, _ := json.Marshal(v)
+			result[name+"/"+k] = string(data)
+		}
+	case topohubv1beta1.SSHCollectorParserKeyValue:
+		for _, line := range strings.Split(output, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			k, v, ok := strings.Cut(line, "=")
+			if !ok || strings.TrimSpace(k) == "" {
+				return nil, fmt.Errorf("invalid line %q, expected key=value", line)
+			}
+			result[name+"/"+strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	default:
+		return nil, fmt.Errorf("unknown parser %s", parser)
+	}
+	return result, nil
+}
+
+// runCollectors runs the SSHCollectors which select the host and are due, and writes the results into the info.
+// The results of the collectors which are not due are kept from the existing info
+func (c *sshStatusController) runCollectors(name string, client *ssh.Client, existing *topohubv1beta1.SSHStatus, info map[string]string) {
+	collectorList := &topohubv1beta1.SSHCollectorList{}
+	if err := c.client.List(context.Background(), collectorList); err != nil {
+		c.log.Warnf("Failed to list sshcollectors: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, collector := range collectorList.Items {
+		if collector.Spec.Selector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(collector.Spec.Selector)
+			if err != nil {
+				c.log.Warnf("Invalid selector of sshcollector %s: %v", collector.Name, err)
+				continue
+			}
+			if !selector.Matches(labels.Set(existing.Labels)) {
+				continue
+			}
+		}
+
+		key := name + "/" + collector.Name
+		c.collectorLock.Lock()
+		last, ok := c.collectorRuns[key]
+		c.collectorLock.Unlock()
+		interval := time.Duration(collector.Spec.IntervalSeconds) * time.Second
+		if ok && last.generation == collector.Generation && now.Sub(last.time) < interval {
+			for k, v := range existing.Status.Info {
+				if strings.HasPrefix(k, collector.Name+"/") {
+					info[k] = v
+				}
+			}
+			continue
+		}
+
+		for k, v := range c.runCollector(client, &collector) {
+			info[k] = v
+		}
+		c.collectorLock.Lock()
+		c.collectorRuns[key] = collectorRun{time: now, generation: collector.Generation}
+		c.collectorLock.Unlock()
+	}
+}
+
+// runCollector runs the command of the collector, the error is written into the key <name>/error
+func (c *sshStatusController) runCollector(client *ssh.Client, collector *topohubv1beta1.SSHCollector) map[string]string {
+	timeout := defaultCollectorTimeout
+	if collector.Spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(collector.Spec.TimeoutSeconds) * time.Second
+	}
+	result, err := client.Run(collector.Spec.Command, nil, timeout)
+	if err == nil && result.ExitCode != 0 {
+		err = fmt.Errorf("exit code %d: %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+	var fields map[string]string
+	if err == nil {
+		fields, err = parseCollectorOutput(collector.Name, collector.Spec.Parser, result.Stdout)
+	}
+	if err != nil {
+		c.log.Warnf("Failed to run sshcollector %s: %v", collector.Name, err)
+		return map[string]string{collector.Name + "/error": err.Error()}
+	}
+	return fields
+}
+
+// forgetCollectorRuns removes the runs of the deleted host
+func (c *sshStatusController) forgetCollectorRuns(name string) {
+	c.collectorLock.Lock()
+	defer c.collectorLock.Unlock()
+	for key := range c.collectorRuns {
+		if strings.HasPrefix(key, name+"/") {
+			delete(c.collectorRuns, key)
+		}
+	}
+}
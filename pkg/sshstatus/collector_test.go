@@ -0,0 +1,31 @@
+package sshstatus
+
+import (
+	"testing"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestParseCollectorOutput tests the keys and the values of each parser
+func TestParseCollectorOutput(t *testing.T) {
+	fields, err := parseCollectorOutput("bios", topohubv1beta1.SSHCollectorParserRaw, " 2.3.4\n")
+	if err != nil || len(fields) != 1 || fields["bios/output"] != "2.3.4" {
+		t.Errorf("unexpected raw fields: %v, %v", fields, err)
+	}
+
+	fields, err = parseCollectorOutput("ofed", topohubv1beta1.SSHCollectorParserJson, `{"version":"24.01","devices":2,"ports":["p0","p1"]}`)
+	if err != nil || fields["ofed/version"] != "24.01" || fields["ofed/devices"] != "2" || fields["ofed/ports"] != `["p0","p1"]` {
+		t.Errorf("unexpected json fields: %v, %v", fields, err)
+	}
+	if _, err := parseCollectorOutput("ofed", topohubv1beta1.SSHCollectorParserJson, `["a"]`); err == nil {
+		t.Errorf("expected the error of the json array")
+	}
+
+	fields, err = parseCollectorOutput("drivers", topohubv1beta1.SSHCollectorParserKeyValue, "# comment\nmlx5_core = 24.01\n\nnvidia=550.54.15\n")
+	if err != nil || len(fields) != 2 || fields["drivers/mlx5_core"] != "24.01" || fields["drivers/nvidia"] != "550.54.15" {
+		t.Errorf("unexpected key value fields: %v, %v", fields, err)
+	}
+	if _, err := parseCollectorOutput("drivers", topohubv1beta1.SSHCollectorParserKeyValue, "invalid line"); err == nil {
+		t.Errorf("expected the error of the invalid line")
+	}
+}
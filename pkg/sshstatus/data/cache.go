@@ -13,6 +13,10 @@ type SSHConnectCon struct {
 	Password string
 	SSHKey   string
 	SSHKeyAuth bool
+	// SSHCertificate is the openssh certificate of the SSHKey signed by the CA
+	SSHCertificate string
+	// JumpHost is the connection information of the jump host in Info.JumpHost
+	JumpHost *SSHConnectCon
 }
 
 // SSHHostCache is used to cache SSH host connection information
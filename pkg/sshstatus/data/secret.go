@@ -0,0 +1,56 @@
+package data
+
+import (
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// the keys of the secret for the ssh authentication
+const (
+	SecretKeyUsername    = "username"
+	SecretKeyPassword    = "password"
+	SecretKeyPrivateKey  = "ssh-privatekey"
+	SecretKeyCertificate = "ssh-certificate"
+)
+
+// defaultSSHPort is the port of the jump host when it is not set
+const defaultSSHPort = 22
+
+// NewSSHConnectCon builds the connection information of the host from the data of its secret
+func NewSSHConnectCon(info *topohubv1beta1.SSHBasicInfo, secretData map[string][]byte) SSHConnectCon {
+	con := SSHConnectCon{
+		Info:           info,
+		Username:       string(secretData[SecretKeyUsername]),
+		Password:       string(secretData[SecretKeyPassword]),
+		SSHKey:         string(secretData[SecretKeyPrivateKey]),
+		SSHCertificate: string(secretData[SecretKeyCertificate]),
+	}
+	con.SSHKeyAuth = con.SSHKey != ""
+	return con
+}
+
+// JumpHostInfo returns the basic information of the jump host, the secret of the host is used when the jump host
+// has none. It returns nil when there is no jump host
+func JumpHostInfo(info *topohubv1beta1.SSHBasicInfo) *topohubv1beta1.SSHBasicInfo {
+	if info == nil || info.JumpHost == nil || info.JumpHost.IPAddr == "" {
+		return nil
+	}
+	jump := &topohubv1beta1.SSHBasicInfo{
+		ClusterName:     info.ClusterName,
+		Type:            topohubv1beta1.HostTypeSSH,
+		IpAddr:          info.JumpHost.IPAddr,
+		Port:            info.JumpHost.Port,
+		SecretName:      info.JumpHost.SecretName,
+		SecretNamespace: info.JumpHost.SecretNamespace,
+		SSHHostKey:      info.JumpHost.SSHHostKey,
+	}
+	if jump.Port == 0 {
+		jump.Port = defaultSSHPort
+	}
+	if jump.SecretName == "" {
+		jump.SecretName = info.SecretName
+		jump.SecretNamespace = info.SecretNamespace
+	} else if jump.SecretNamespace == "" {
+		jump.SecretNamespace = info.SecretNamespace
+	}
+	return jump
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/infrastructure-io/topohub/pkg/config"
 	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
 	"github.com/infrastructure-io/topohub/pkg/log"
+	"github.com/infrastructure-io/topohub/pkg/provision"
 )
 
 // SSHStatusController interface defines the public methods for the SSH status controller
@@ -35,10 +36,15 @@ type sshStatusController struct {
 	wg         sync.WaitGroup
 	recorder   record.EventRecorder
 	log        *zap.SugaredLogger
+	// report the ssh login to track the provisioning
+	provisionEvents chan provision.Event
+	// the last runs of the SSHCollectors, the key is <sshstatus>/<sshcollector>
+	collectorLock sync.Mutex
+	collectorRuns map[string]collectorRun
 }
 
 // NewSSHStatusController creates a new SSH status controller
-func NewSSHStatusController(kubeClient kubernetes.Interface, config *config.AgentConfig, mgr ctrl.Manager) SSHStatusController {
+func NewSSHStatusController(kubeClient kubernetes.Interface, config *config.AgentConfig, mgr ctrl.Manager, provisionEvents chan provision.Event) SSHStatusController {
 	log.Logger.Debugf("Creating new SSHStatus controller")
 
 	// Create event recorder
@@ -47,12 +53,14 @@ func NewSSHStatusController(kubeClient kubernetes.Interface, config *config.Agen
 	recorder := eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "ssh-controller"})
 
 	controller := &sshStatusController{
-		client:     mgr.GetClient(),
-		kubeClient: kubeClient,
-		config:     config,
-		stopCh:     make(chan struct{}),
-		recorder:   recorder,
-		log:        log.Logger.Named("sshstatus"),
+		client:          mgr.GetClient(),
+		kubeClient:      kubeClient,
+		config:          config,
+		stopCh:          make(chan struct{}),
+		recorder:        recorder,
+		log:             log.Logger.Named("sshstatus"),
+		provisionEvents: provisionEvents,
+		collectorRuns:   make(map[string]collectorRun),
 	}
 
 	log.Logger.Debugf("SSHStatus controller created successfully")
@@ -0,0 +1,153 @@
+package ssh
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// the tail of the output which is kept in the status of the HostOperation
+const maxOutputSize = 4096
+
+// CommandResult is the result of the command run on the host
+type CommandResult struct {
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// tailBuffer keeps the last bytes written to it
+type tailBuffer struct {
+	buf []byte
+	max int
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if len(t.buf) > t.max {
+		t.buf = t.buf[len(t.buf)-t.max:]
+	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	return string(t.buf)
+}
+
+// shellQuote quotes the string for the posix shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// sudo returns the prefix of the commands which require the root privilege, sudo must not ask for the password
+func (c *Client) sudo() string {
+	if c.hostInfo.Username == "root" {
+		return ""
+	}
+	return "sudo -n "
+}
+
+// scriptCommand returns the command which runs the script from the stdin by bash
+func scriptCommand(sudo string, args []string, env map[string]string) string {
+	cmd := sudo
+	if len(env) > 0 {
+		keys := make([]string, 0, len(env))
+		for k := range env {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		cmd += "env"
+		for _, k := range keys {
+			cmd += " " + shellQuote(k+"="+env[k])
+		}
+		cmd += " "
+	}
+	cmd += "bash -s --"
+	for _, arg := range args {
+		cmd += " " + shellQuote(arg)
+	}
+	return cmd
+}
+
+// pushFileCommand returns the command which writes the stdin to the destination, the file is replaced atomically
+func pushFileCommand(sudo, destination, mode string) string {
+	script := `mkdir -p "$(dirname "$1")" && cat > "$1.tmp" && chmod "$2" "$1.tmp" && mv -f "$1.tmp" "$1"`
+	return fmt.Sprintf("%ssh -c %s sh %s %s", sudo, shellQuote(script), shellQuote(destination), shellQuote(mode))
+}
+
+// Run runs the command with the stdin, and kills it after the timeout. The non-zero exit code is returned
+// in the result rather than the error
+func (c *Client) Run(cmd string, stdin io.Reader, timeout time.Duration) (*CommandResult, error) {
+	return c.run(cmd, stdin, timeout, maxOutputSize)
+}
+
+// run runs the command and keeps the tail of the output up to the max size
+func (c *Client) run(cmd string, stdin io.Reader, timeout time.Duration, maxOutput int) (*CommandResult, error) {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %v", err)
+	}
+	defer session.Close()
+
+	stdout := &tailBuffer{max: maxOutput}
+	stderr := &tailBuffer{max: maxOutputSize}
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	if err := session.Start(cmd); err != nil {
+		return nil, fmt.Errorf("failed to start command: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Wait()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err = <-done:
+	case <-timer.C:
+		_ = session.Signal(ssh.SIGKILL)
+		_ = session.Close()
+		return &CommandResult{ExitCode: -1, Stdout: stdout.String(), Stderr: stderr.String()}, fmt.Errorf("command timed out after %v", timeout)
+	}
+
+	result := &CommandResult{Stdout: stdout.String(), Stderr: stderr.String()}
+	if err != nil {
+		var exitErr *ssh.ExitError
+		if !errors.As(err, &exitErr) {
+			return result, fmt.Errorf("failed to run command: %v", err)
+		}
+		result.ExitCode = exitErr.ExitStatus()
+	}
+	return result, nil
+}
+
+// RunScript sends the script to the host and runs it by bash with the args and the environment variables
+func (c *Client) RunScript(script []byte, args []string, env map[string]string, timeout time.Duration) (*CommandResult, error) {
+	return c.Run(scriptCommand(c.sudo(), args, env), bytes.NewReader(script), timeout)
+}
+
+// PushFile writes the content to the destination on the host with the octal mode
+func (c *Client) PushFile(content io.Reader, destination, mode string, timeout time.Duration) (*CommandResult, error) {
+	return c.Run(pushFileCommand(c.sudo(), destination, mode), content, timeout)
+}
+
+// Power shuts down or reboots the host by the systemd. It runs in the background after a delay, so the
+// command returns before the connection is closed by the host
+func (c *Client) Power(restart bool, timeout time.Duration) (*CommandResult, error) {
+	action := "poweroff"
+	if restart {
+		action = "reboot"
+	}
+	// check the privilege at first, because the error of the background command is lost
+	cmd := fmt.Sprintf("%strue && (nohup %ssh -c %s >/dev/null 2>&1 &)", c.sudo(), c.sudo(), shellQuote("sleep 2; systemctl "+action))
+	return c.Run(cmd, nil, timeout)
+}
@@ -0,0 +1,29 @@
+package ssh
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestActionCommands tests the quoting of the commands and the tail of the output
+func TestActionCommands(t *testing.T) {
+	cmd := scriptCommand("sudo -n ", []string{"eth0", "it's"}, map[string]string{"GPU_RDMA_PRIORITY": "5", "ALL_RDMA_NICS": "true"})
+	if cmd != `sudo -n env 'ALL_RDMA_NICS=true' 'GPU_RDMA_PRIORITY=5' bash -s -- 'eth0' 'it'\''s'` {
+		t.Errorf("unexpected script command: %s", cmd)
+	}
+	if cmd := scriptCommand("", nil, nil); cmd != "bash -s --" {
+		t.Errorf("unexpected script command: %s", cmd)
+	}
+
+	cmd = pushFileCommand("", "/etc/netplan/01 config.yaml", "0600")
+	if !strings.HasPrefix(cmd, "sh -c '") || !strings.HasSuffix(cmd, ` sh '/etc/netplan/01 config.yaml' '0600'`) {
+		t.Errorf("unexpected push command: %s", cmd)
+	}
+
+	tail := &tailBuffer{max: 4}
+	_, _ = tail.Write([]byte("abc"))
+	_, _ = tail.Write([]byte("def"))
+	if tail.String() != "cdef" {
+		t.Errorf("unexpected tail: %s", tail.String())
+	}
+}
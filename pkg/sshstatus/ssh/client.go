@@ -11,69 +11,170 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/crypto/ssh"
 
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
 	sshstatusdata "github.com/infrastructure-io/topohub/pkg/sshstatus/data"
 )
 
 type Client struct {
-	conn     *ssh.Client
+	conn *ssh.Client
+	// jumpConn is the connection to the jump host which forwards the conn
+	jumpConn *ssh.Client
 	config   *ssh.ClientConfig
 	hostInfo *sshstatusdata.SSHConnectCon
 	log      *zap.SugaredLogger
+	// release returns the pooled connection to the pool rather than closing it
+	release func()
 }
 
-// NewClient creates a new SSH client
-func NewClient(hostInfo sshstatusdata.SSHConnectCon, logger *zap.SugaredLogger) (*Client, error) {
-	if hostInfo.Info == nil {
-		return nil, fmt.Errorf("host info is nil")
-	}
-
+// authMethods returns all the authentication methods the credentials support, which are tried in order
+func authMethods(hostInfo sshstatusdata.SSHConnectCon) ([]ssh.AuthMethod, error) {
 	authMethods := []ssh.AuthMethod{}
 
-	// Choose different authentication methods based on the configuration
 	if hostInfo.SSHKeyAuth && hostInfo.SSHKey != "" {
 		// Use SSH key authentication
 		signer, err := ssh.ParsePrivateKey([]byte(hostInfo.SSHKey))
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse private key: %v", err)
 		}
+		if hostInfo.SSHCertificate != "" {
+			// the certificate signed by the openssh CA is presented with the private key
+			pub, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostInfo.SSHCertificate))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse certificate: %v", err)
+			}
+			cert, ok := pub.(*ssh.Certificate)
+			if !ok {
+				return nil, fmt.Errorf("the %s is not an openssh certificate", sshstatusdata.SecretKeyCertificate)
+			}
+			signer, err = ssh.NewCertSigner(cert, signer)
+			if err != nil {
+				return nil, fmt.Errorf("failed to use certificate: %v", err)
+			}
+		}
 		authMethods = append(authMethods, ssh.PublicKeys(signer))
-	} else if hostInfo.Username != "" && hostInfo.Password != "" {
-		// Use password authentication
-		authMethods = append(authMethods, ssh.Password(hostInfo.Password))
-	} else {
+	}
+	if hostInfo.Username != "" && hostInfo.Password != "" {
+		// Use password authentication, some hosts only accept the password by the keyboard-interactive
+		password := hostInfo.Password
+		authMethods = append(authMethods, ssh.Password(password),
+			ssh.KeyboardInteractive(func(name, instruction string, questions []string, echos []bool) ([]string, error) {
+				answers := make([]string, len(questions))
+				for i := range questions {
+					answers[i] = password
+				}
+				return answers, nil
+			}))
+	}
+	if len(authMethods) == 0 {
 		return nil, fmt.Errorf("no valid authentication method provided")
 	}
+	return authMethods, nil
+}
+
+// hostKeyCallback verifies the host key when it is pinned, the mismatch is reported as the hostKeyError
+func hostKeyCallback(key string) (ssh.HostKeyCallback, error) {
+	if key == "" {
+		return ssh.InsecureIgnoreHostKey(), nil // In production, a more secure method should be used
+	}
+	// the host key reported by the installer phone-home is pinned
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse host key: %v", err)
+	}
+	fixed := ssh.FixedHostKey(hostKey)
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if err := fixed(hostname, remote, key); err != nil {
+			return &hostKeyError{err: err}
+		}
+		return nil
+	}, nil
+}
+
+// NewClient creates a new SSH client, the host is connected through the jump host when it is set. The failure
+// is returned as the ConnectError
+func NewClient(hostInfo sshstatusdata.SSHConnectCon, logger *zap.SugaredLogger) (*Client, error) {
+	if hostInfo.Info == nil {
+		return nil, &ConnectError{Reason: topohubv1beta1.SSHReasonConfigError, Err: fmt.Errorf("host info is nil")}
+	}
+
+	auth, err := authMethods(hostInfo)
+	if err != nil {
+		return nil, &ConnectError{Reason: topohubv1beta1.SSHReasonConfigError, Err: err}
+	}
+	callback, err := hostKeyCallback(hostInfo.Info.SSHHostKey)
+	if err != nil {
+		return nil, &ConnectError{Reason: topohubv1beta1.SSHReasonConfigError, Err: err}
+	}
 
 	config := &ssh.ClientConfig{
 		User:            hostInfo.Username,
-		Auth:            authMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(), // In production, a more secure method should be used
+		Auth:            auth,
+		HostKeyCallback: callback,
 		Timeout:         10 * time.Second,
 	}
 
 	// Build connection address
 	addr := net.JoinHostPort(hostInfo.Info.IpAddr, strconv.Itoa(int(hostInfo.Info.Port)))
 
-	// Establish SSH connection
-	conn, err := ssh.Dial("tcp", addr, config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to dial: %v", err)
-	}
-
-	return &Client{
-		conn:     conn,
+	c := &Client{
 		config:   config,
 		hostInfo: &hostInfo,
 		log:      logger,
-	}, nil
+	}
+	if hostInfo.Info.JumpHost == nil {
+		// Establish SSH connection
+		c.conn, err = ssh.Dial("tcp", addr, config)
+		if err != nil {
+			return nil, &ConnectError{Reason: classifyDialError(err), Err: fmt.Errorf("failed to dial: %w", err)}
+		}
+		return c, nil
+	}
+
+	if hostInfo.JumpHost == nil {
+		return nil, &ConnectError{Reason: topohubv1beta1.SSHReasonConfigError, Err: fmt.Errorf("the credentials of the jump host are not set")}
+	}
+	jump, err := NewClient(*hostInfo.JumpHost, logger)
+	if err != nil {
+		return nil, &ConnectError{Reason: ErrorReason(err), Err: fmt.Errorf("failed to connect the jump host: %w", err)}
+	}
+	conn, err := jump.conn.Dial("tcp", addr)
+	if err != nil {
+		_ = jump.closeConn()
+		return nil, &ConnectError{Reason: topohubv1beta1.SSHReasonNetworkError, Err: fmt.Errorf("failed to dial through the jump host: %w", err)}
+	}
+	// the forwarded connection does not support the deadline, so it is closed when the handshake hangs
+	timer := time.AfterFunc(config.Timeout, func() { _ = conn.Close() })
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	timer.Stop()
+	if err != nil {
+		_ = conn.Close()
+		_ = jump.closeConn()
+		return nil, &ConnectError{Reason: classifyDialError(err), Err: fmt.Errorf("failed to dial through the jump host: %w", err)}
+	}
+	c.conn = ssh.NewClient(sshConn, chans, reqs)
+	c.jumpConn = jump.conn
+	return c, nil
 }
 
-// Close terminates the SSH connection
+// Close terminates the SSH connection, or returns it to the pool when it is got from the pool
 func (c *Client) Close() error {
+	if c.release != nil {
+		c.release()
+		return nil
+	}
+	return c.closeConn()
+}
+
+// closeConn closes the connection and the connection to the jump host
+func (c *Client) closeConn() error {
+	var err error
 	if c.conn != nil {
-		return c.conn.Close()
+		err = c.conn.Close()
+	}
+	if c.jumpConn != nil {
+		_ = c.jumpConn.Close()
 	}
-	return nil
+	return err
 }
 
 // RunCommand executes a command on the remote host and returns the output
@@ -165,6 +266,14 @@ func (c *Client) GetSystemInfo() (map[string]string, error) {
 		info["Network"] = strings.TrimSpace(netInfo)
 	}
 
+	// get the mac addresses of the physical nics, which are correlated with the lldp neighbors of the switches
+	nicMacs, err := c.RunCommand(`for i in /sys/class/net/*; do if [ -e $i/device ]; then echo "$(basename $i)=$(cat $i/address)"; fi; done`)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to get nic mac addresses: %v", err))
+	} else {
+		info["NetworkMACs"] = strings.Join(strings.Fields(nicMacs), ",")
+	}
+
 	// get storage info
 	storageInfo, err := c.RunCommand(`lsblk -d -o NAME,SIZE,TYPE,TRAN | grep -E 'disk|nvme' | grep -v 'loop\|rom' | awk '{print $1,$2}'`)
 	if err != nil {
@@ -0,0 +1,108 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	sshstatusdata "github.com/infrastructure-io/topohub/pkg/sshstatus/data"
+)
+
+// TestAuthMethods tests the certificate, key and password authentication methods
+func TestAuthMethods(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, _ := ssh.NewSignerFromKey(priv)
+	_, caPriv, _ := ed25519.GenerateKey(rand.Reader)
+	caSigner, _ := ssh.NewSignerFromKey(caPriv)
+	cert := &ssh.Certificate{Key: signer.PublicKey(), CertType: ssh.UserCert, ValidPrincipals: []string{"root"}, ValidBefore: ssh.CertTimeInfinity}
+	if err := cert.SignCert(rand.Reader, caSigner); err != nil {
+		t.Fatal(err)
+	}
+
+	con := sshstatusdata.SSHConnectCon{
+		Username:       "root",
+		Password:       "passwd",
+		SSHKey:         string(pem.EncodeToMemory(block)),
+		SSHKeyAuth:     true,
+		SSHCertificate: string(ssh.MarshalAuthorizedKey(cert)),
+	}
+	methods, err := authMethods(con)
+	if err != nil || len(methods) != 3 {
+		t.Fatalf("expected publickey, password and keyboard-interactive, got %d, %v", len(methods), err)
+	}
+
+	// the certificate of another key is rejected
+	_, otherPriv, _ := ed25519.GenerateKey(rand.Reader)
+	otherBlock, _ := ssh.MarshalPrivateKey(otherPriv, "")
+	con.SSHKey = string(pem.EncodeToMemory(otherBlock))
+	if _, err := authMethods(con); err == nil {
+		t.Errorf("expected error for the certificate of another key")
+	}
+
+	if _, err := authMethods(sshstatusdata.SSHConnectCon{Username: "root"}); err == nil {
+		t.Errorf("expected error without credentials")
+	}
+}
+
+// TestErrorReason tests the categories of the connection failures
+func TestErrorReason(t *testing.T) {
+	callback, err := hostKeyCallback("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	sshPub, _ := ssh.NewPublicKey(pub)
+	keyErr := callback("10.0.0.1:22", nil, sshPub)
+	if keyErr == nil {
+		t.Fatalf("expected host key mismatch")
+	}
+
+	cases := []struct {
+		err    error
+		reason string
+	}{
+		{fmt.Errorf("ssh: handshake failed: %w", keyErr), topohubv1beta1.SSHReasonHostKeyMismatch},
+		{errors.New("ssh: handshake failed: ssh: unable to authenticate, attempted methods [none password], no supported methods remain"), topohubv1beta1.SSHReasonAuthFailed},
+		{errors.New("dial tcp 10.0.0.1:22: connect: connection refused"), topohubv1beta1.SSHReasonNetworkError},
+		{fmt.Errorf("ssh: handshake failed: %w", io.EOF), topohubv1beta1.SSHReasonNetworkError},
+	}
+	for _, c := range cases {
+		err := fmt.Errorf("failed to connect the jump host: %w", &ConnectError{Reason: classifyDialError(c.err), Err: c.err})
+		if reason := ErrorReason(err); reason != c.reason {
+			t.Errorf("expected %s for %q, got %s", c.reason, c.err, reason)
+		}
+	}
+}
+
+// TestPoolKey tests the pooled connection is dialed again when the credentials or the jump host change
+func TestPoolKey(t *testing.T) {
+	info := &topohubv1beta1.SSHBasicInfo{IpAddr: "10.0.0.1", Port: 22}
+	con := sshstatusdata.SSHConnectCon{Info: info, Username: "root", Password: "a"}
+	key := poolKey(con)
+	if poolKey(con) != key {
+		t.Errorf("expected the same key")
+	}
+
+	changed := con
+	changed.Password = "b"
+	if poolKey(changed) == key {
+		t.Errorf("expected a new key for the new password")
+	}
+
+	jumped := con
+	jumped.JumpHost = &sshstatusdata.SSHConnectCon{Info: &topohubv1beta1.SSHBasicInfo{IpAddr: "10.0.0.254", Port: 22}, Username: "jump"}
+	if poolKey(jumped) == key {
+		t.Errorf("expected a new key for the jump host")
+	}
+}
@@ -0,0 +1,54 @@
+package ssh
+
+import (
+	"errors"
+	"strings"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// ConnectError is the failure to connect the host, the Reason is one of the SSHReason of the SSHStatus
+type ConnectError struct {
+	Reason string
+	Err    error
+}
+
+func (e *ConnectError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConnectError) Unwrap() error {
+	return e.Err
+}
+
+// hostKeyError is returned by the host key callback when the host key does not match the pinned one
+type hostKeyError struct {
+	err error
+}
+
+func (e *hostKeyError) Error() string {
+	return "host key mismatch: " + e.err.Error()
+}
+
+// classifyDialError categorizes the failure of the dial and the handshake
+func classifyDialError(err error) string {
+	var keyErr *hostKeyError
+	if errors.As(err, &keyErr) {
+		return topohubv1beta1.SSHReasonHostKeyMismatch
+	}
+	if strings.Contains(err.Error(), "unable to authenticate") {
+		return topohubv1beta1.SSHReasonAuthFailed
+	}
+	// the refused, unreachable and timed out connections, and the connections closed during the handshake
+	return topohubv1beta1.SSHReasonNetworkError
+}
+
+// ErrorReason returns the reason of the Connected condition for the error, the errors of the established
+// connection are regarded as the network errors
+func ErrorReason(err error) string {
+	var connErr *ConnectError
+	if errors.As(err, &connErr) {
+		return connErr.Reason
+	}
+	return topohubv1beta1.SSHReasonNetworkError
+}
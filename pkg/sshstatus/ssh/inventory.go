@@ -0,0 +1,242 @@
+package ssh
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// the commands print the machine-readable inventory, the fields of the sysfs are separated by tab
+const (
+	dmiCommand = `for f in sys_vendor product_name product_serial product_uuid; do ` +
+		`v=$(cat /sys/class/dmi/id/$f 2>/dev/null || sudo -n cat /sys/class/dmi/id/$f 2>/dev/null); printf '%s\t%s\n' "$f" "$v"; done`
+	ipLinkCommand = "ip -j link show"
+	nicCommand    = `for i in /sys/class/net/*; do [ -e $i/device ] || continue; n=$(basename $i); ` +
+		`printf '%s\t%s\t%s\t%s\t%s\t%s\n' "$n" "$(basename "$(readlink -f $i/device/driver)" 2>/dev/null)" "$(basename "$(readlink -f $i/device)")" ` +
+		`"$(cat $i/speed 2>/dev/null)" "$(cat $i/device/numa_node 2>/dev/null)" "$(ethtool -i $n 2>/dev/null | sed -n 's/^firmware-version: //p')"; done`
+	rdmaCommand = `for d in /sys/class/infiniband/*; do [ -e $d ] || continue; ` +
+		`printf '%s\t%s\t%s\t%s\n' "$(basename $d)" "$(ls $d/device/net 2>/dev/null | head -1)" "$(cat $d/ports/1/link_layer 2>/dev/null)" "$(basename "$(readlink -f $d/device)")"; done`
+	numaCommand = `for n in /sys/devices/system/node/node[0-9]*; do [ -e $n ] || continue; ` +
+		`printf '%s\t%s\t%s\n' "$(basename $n)" "$(cat $n/cpulist)" "$(awk '/MemTotal/ {print $4}' $n/meminfo)"; done`
+	gpuCommand = `if command -v nvidia-smi >/dev/null 2>&1; then ` +
+		`nvidia-smi --query-gpu=name,uuid,driver_version,pci.bus_id,memory.total --format=csv,noheader,nounits; fi`
+	lsblkCommand = "lsblk -J -d -b -o NAME,MODEL,SERIAL,SIZE,ROTA,TRAN,TYPE"
+)
+
+// splitLines returns the fields of each non-empty line
+func splitLines(output, sep string) [][]string {
+	result := [][]string{}
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, sep)
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		result = append(result, fields)
+	}
+	return result
+}
+
+// field returns the field at the index, or empty when it does not exist
+func field(fields []string, i int) string {
+	if i < len(fields) {
+		return fields[i]
+	}
+	return ""
+}
+
+func parseDmi(inv *topohubv1beta1.SSHInventory, output string) {
+	for _, fields := range splitLines(output, "\t") {
+		switch field(fields, 0) {
+		case "sys_vendor":
+			inv.SystemVendor = field(fields, 1)
+		case "product_name":
+			inv.SystemProduct = field(fields, 1)
+		case "product_serial":
+			inv.SystemSerial = field(fields, 1)
+		case "product_uuid":
+			inv.SystemUUID = field(fields, 1)
+		}
+	}
+}
+
+// parseNics merges the mac addresses of "ip -j link" into the physical nics of the sysfs. Like the other parsers,
+// it returns nil rather than the empty slice, which is omitted in the status
+func parseNics(ipOutput, sysfsOutput string) ([]topohubv1beta1.SSHNicInfo, error) {
+	links := []struct {
+		Name    string `json:"ifname"`
+		Address string `json:"address"`
+	}{}
+	if strings.TrimSpace(ipOutput) != "" {
+		if err := json.Unmarshal([]byte(ipOutput), &links); err != nil {
+			return nil, fmt.Errorf("failed to parse the output of ip: %v", err)
+		}
+	}
+	macs := make(map[string]string, len(links))
+	for _, link := range links {
+		macs[link.Name] = strings.ToLower(link.Address)
+	}
+
+	var nics []topohubv1beta1.SSHNicInfo
+	for _, fields := range splitLines(sysfsOutput, "\t") {
+		nic := topohubv1beta1.SSHNicInfo{
+			Name:     field(fields, 0),
+			MacAddr:  macs[field(fields, 0)],
+			Driver:   field(fields, 1),
+			PciAddr:  field(fields, 2),
+			Firmware: field(fields, 5),
+		}
+		// the speed is -1 or unknown when the link is down
+		if speed, err := strconv.ParseInt(field(fields, 3), 10, 32); err == nil && speed > 0 {
+			nic.SpeedMbps = int32(speed)
+		}
+		if numa, err := strconv.ParseInt(field(fields, 4), 10, 32); err == nil && numa >= 0 {
+			n := int32(numa)
+			nic.NumaNode = &n
+		}
+		nics = append(nics, nic)
+	}
+	sort.Slice(nics, func(i, j int) bool { return nics[i].Name < nics[j].Name })
+	return nics, nil
+}
+
+func parseRdma(output string) []topohubv1beta1.SSHRdmaDevice {
+	var devices []topohubv1beta1.SSHRdmaDevice
+	for _, fields := range splitLines(output, "\t") {
+		devices = append(devices, topohubv1beta1.SSHRdmaDevice{
+			Name:      field(fields, 0),
+			Nic:       field(fields, 1),
+			LinkLayer: field(fields, 2),
+			PciAddr:   field(fields, 3),
+		})
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Name < devices[j].Name })
+	return devices
+}
+
+func parseNuma(output string) []topohubv1beta1.SSHNumaNode {
+	var nodes []topohubv1beta1.SSHNumaNode
+	for _, fields := range splitLines(output, "\t") {
+		id, err := strconv.ParseInt(strings.TrimPrefix(field(fields, 0), "node"), 10, 32)
+		if err != nil {
+			continue
+		}
+		node := topohubv1beta1.SSHNumaNode{ID: int32(id), Cpus: field(fields, 1)}
+		if kb, err := strconv.ParseInt(field(fields, 2), 10, 64); err == nil {
+			node.MemoryMiB = kb / 1024
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+func parseGpus(output string) []topohubv1beta1.SSHGpuInfo {
+	var gpus []topohubv1beta1.SSHGpuInfo
+	for _, fields := range splitLines(output, ",") {
+		gpu := topohubv1beta1.SSHGpuInfo{
+			Model:         field(fields, 0),
+			UUID:          field(fields, 1),
+			DriverVersion: field(fields, 2),
+			PciAddr:       strings.ToLower(field(fields, 3)),
+		}
+		if mib, err := strconv.ParseInt(field(fields, 4), 10, 64); err == nil {
+			gpu.MemoryMiB = mib
+		}
+		gpus = append(gpus, gpu)
+	}
+	sort.Slice(gpus, func(i, j int) bool { return gpus[i].PciAddr < gpus[j].PciAddr })
+	return gpus
+}
+
+// jsonString accepts the string, the number and the bool, because the types of the lsblk output differ by versions
+func jsonString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		if t {
+			return "1"
+		}
+		return "0"
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+func parseLsblk(output string) ([]topohubv1beta1.SSHBlockDevice, error) {
+	result := struct {
+		BlockDevices []map[string]interface{} `json:"blockdevices"`
+	}{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse the output of lsblk: %v", err)
+	}
+	var devices []topohubv1beta1.SSHBlockDevice
+	for _, item := range result.BlockDevices {
+		if jsonString(item["type"]) != "disk" {
+			continue
+		}
+		device := topohubv1beta1.SSHBlockDevice{
+			Name:       jsonString(item["name"]),
+			Model:      jsonString(item["model"]),
+			Serial:     jsonString(item["serial"]),
+			Rotational: jsonString(item["rota"]) == "1",
+			Transport:  jsonString(item["tran"]),
+		}
+		if size, err := strconv.ParseInt(jsonString(item["size"]), 10, 64); err == nil {
+			device.SizeBytes = size
+		}
+		devices = append(devices, device)
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Name < devices[j].Name })
+	return devices, nil
+}
+
+// GetInventory collects the structured inventory of the host. The collected parts are returned with the error
+// when some commands fail
+func (c *Client) GetInventory() (*topohubv1beta1.SSHInventory, error) {
+	inv := &topohubv1beta1.SSHInventory{}
+	var errs []string
+	run := func(name, cmd string) string {
+		output, err := c.RunCommand(cmd)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to get %s: %v", name, err))
+		}
+		return output
+	}
+
+	parseDmi(inv, run("dmi", dmiCommand))
+
+	ipOutput := run("links", ipLinkCommand)
+	nics, err := parseNics(ipOutput, run("nics", nicCommand))
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	inv.Nics = nics
+	inv.RdmaDevices = parseRdma(run("rdma devices", rdmaCommand))
+	inv.NumaNodes = parseNuma(run("numa nodes", numaCommand))
+	inv.Gpus = parseGpus(run("gpus", gpuCommand))
+
+	if output := run("block devices", lsblkCommand); output != "" {
+		devices, err := parseLsblk(output)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		inv.BlockDevices = devices
+	}
+
+	if len(errs) > 0 {
+		return inv, fmt.Errorf("partial inventory collected, some errors occurred:\n%s", strings.Join(errs, "\n"))
+	}
+	return inv, nil
+}
@@ -0,0 +1,53 @@
+package ssh
+
+import (
+	"testing"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestParseInventory tests the parsers of the sysfs, ip, nvidia-smi and lsblk outputs
+func TestParseInventory(t *testing.T) {
+	inv := &topohubv1beta1.SSHInventory{}
+	parseDmi(inv, "sys_vendor\tDell Inc.\nproduct_name\tPowerEdge R760\nproduct_serial\t\nproduct_uuid\t4c4c4544-0042-3610-8057-b4c04f4b4d32\n")
+	if inv.SystemVendor != "Dell Inc." || inv.SystemProduct != "PowerEdge R760" || inv.SystemSerial != "" || inv.SystemUUID != "4c4c4544-0042-3610-8057-b4c04f4b4d32" {
+		t.Errorf("unexpected dmi: %+v", inv)
+	}
+
+	ipOutput := `[{"ifindex":1,"ifname":"lo","address":"00:00:00:00:00:00"},{"ifindex":2,"ifname":"ens1f0","address":"B8:3F:D2:00:00:01"}]`
+	nics, err := parseNics(ipOutput, "ens1f0\tmlx5_core\t0000:3b:00.0\t100000\t0\t28.39.1002\neno1\tigb\t0000:01:00.0\t-1\t-1\t\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nics) != 2 || nics[0].Name != "eno1" || nics[0].SpeedMbps != 0 || nics[0].NumaNode != nil {
+		t.Errorf("unexpected nics: %+v", nics)
+	}
+	if nic := nics[1]; nic.MacAddr != "b8:3f:d2:00:00:01" || nic.Driver != "mlx5_core" || nic.SpeedMbps != 100000 || *nic.NumaNode != 0 || nic.Firmware != "28.39.1002" {
+		t.Errorf("unexpected nic: %+v", nic)
+	}
+
+	if devices := parseRdma("mlx5_0\tens1f0\tEthernet\t0000:3b:00.0\n"); len(devices) != 1 || devices[0].LinkLayer != "Ethernet" || devices[0].Nic != "ens1f0" {
+		t.Errorf("unexpected rdma devices: %+v", devices)
+	}
+	if nodes := parseNuma("node1\t32-63\t263921788\nnode0\t0-31\t263921788\n"); len(nodes) != 2 || nodes[0].ID != 0 || nodes[1].MemoryMiB != 257736 {
+		t.Errorf("unexpected numa nodes: %+v", nodes)
+	}
+	gpus := parseGpus("NVIDIA H100 80GB HBM3, GPU-1c2d, 550.54.15, 00000000:18:00.0, 81559\n")
+	if len(gpus) != 1 || gpus[0].UUID != "GPU-1c2d" || gpus[0].PciAddr != "00000000:18:00.0" || gpus[0].MemoryMiB != 81559 {
+		t.Errorf("unexpected gpus: %+v", gpus)
+	}
+	if parseGpus("") != nil {
+		t.Errorf("expected no gpu")
+	}
+
+	// the old lsblk prints the numbers and the bools as strings
+	devices, err := parseLsblk(`{"blockdevices":[{"name":"sda","model":"SAMSUNG ","serial":"S1","size":"960197124096","rota":"0","tran":"sata","type":"disk"},
+		{"name":"nvme0n1","model":"INTEL","serial":"N1","size":3840755982336,"rota":false,"tran":"nvme","type":"disk"},
+		{"name":"sr0","model":null,"serial":null,"size":1073741312,"rota":true,"tran":"usb","type":"rom"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devices) != 2 || devices[0].Name != "nvme0n1" || devices[0].SizeBytes != 3840755982336 || devices[1].Model != "SAMSUNG" || devices[1].SizeBytes != 960197124096 || devices[1].Rotational {
+		t.Errorf("unexpected block devices: %+v", devices)
+	}
+}
@@ -0,0 +1,136 @@
+package ssh
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// the number of the kernel entries read at the first collection, when there is no cursor
+	initialJournalEntries = 2000
+	// the max number of the log entries reported at once, the older ones are dropped
+	maxJournalEvents = 100
+	// the max size of the output of journalctl, the older entries are dropped
+	maxJournalOutput = 16 * 1024 * 1024
+	journalTimeout   = 60 * time.Second
+)
+
+// kernelLogPattern is a kind of the hardware error in the kernel log
+type kernelLogPattern struct {
+	category string
+	level    string
+	regex    *regexp.Regexp
+}
+
+// the patterns are matched in order, and the first matched one is used
+var kernelLogPatterns = []kernelLogPattern{
+	{category: "MCE", level: "ERROR", regex: regexp.MustCompile(`(?i)(mce: |machine check|hardware error)`)},
+	{category: "EDAC", level: "ERROR", regex: regexp.MustCompile(`EDAC .*(CE|UE|error)`)},
+	{category: "Xid", level: "ERROR", regex: regexp.MustCompile(`NVRM: Xid`)},
+	{category: "DiskIO", level: "ERROR", regex: regexp.MustCompile(`(?i)(I/O error|blk_update_request|critical medium error|nvme.*(timeout|resetting controller))`)},
+	{category: "LinkDown", level: "WARNING", regex: regexp.MustCompile(`(?i)link is down|link down`)},
+	{category: "LinkUp", level: "INFO", regex: regexp.MustCompile(`(?i)link is up|link up`)},
+}
+
+// journalEntry is the entry of "journalctl -o json"
+type journalEntry struct {
+	Cursor    string          `json:"__CURSOR"`
+	Timestamp string          `json:"__REALTIME_TIMESTAMP"`
+	Message   json.RawMessage `json:"MESSAGE"`
+}
+
+// journalCommand returns the command which reads the kernel entries after the cursor
+func journalCommand(sudo, cursor string) string {
+	cmd := sudo + "journalctl -k -o json --no-pager"
+	if cursor == "" {
+		return cmd + " -n " + strconv.Itoa(initialJournalEntries)
+	}
+	return cmd + " --after-cursor=" + shellQuote(cursor)
+}
+
+// decodeJournalMessage decodes the MESSAGE field, which is an array of bytes when it is not valid utf-8
+func decodeJournalMessage(raw json.RawMessage) string {
+	var msg string
+	if err := json.Unmarshal(raw, &msg); err == nil {
+		return msg
+	}
+	var data []byte
+	var ints []int
+	if err := json.Unmarshal(raw, &ints); err == nil {
+		for _, i := range ints {
+			data = append(data, byte(i))
+		}
+	}
+	return strings.ToValidUTF8(string(data), "?")
+}
+
+// parseJournal returns the hardware errors in the output of journalctl, with the latest entry first, and the cursor
+// of the last entry. The cursor is empty when there is no entry
+func parseJournal(output string) ([]map[string]string, string) {
+	entries := []map[string]string{}
+	cursor := ""
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := journalEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if entry.Cursor != "" {
+			cursor = entry.Cursor
+		}
+		message := decodeJournalMessage(entry.Message)
+		for _, p := range kernelLogPatterns {
+			if !p.regex.MatchString(message) {
+				continue
+			}
+			timestamp := ""
+			if usec, err := strconv.ParseInt(entry.Timestamp, 10, 64); err == nil {
+				timestamp = time.UnixMicro(usec).UTC().Format(time.RFC3339Nano)
+			}
+			entries = append(entries, map[string]string{
+				"timestamp": timestamp,
+				"level":     p.level,
+				"message":   fmt.Sprintf("[%s] %s", p.category, strings.TrimSpace(message)),
+			})
+			break
+		}
+	}
+
+	if len(entries) > maxJournalEvents {
+		entries = entries[len(entries)-maxJournalEvents:]
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, cursor
+}
+
+// GetKernelLogs collects the hardware errors from the kernel journal after the cursor, such as the MCE, EDAC,
+// GPU Xid, disk I/O errors and NIC link flaps. It returns the entries with the latest first, and the new cursor
+func (c *Client) GetKernelLogs(cursor string) ([]map[string]string, string, error) {
+	result, err := c.run(journalCommand(c.sudo(), cursor), nil, journalTimeout, maxJournalOutput)
+	if err == nil && result.ExitCode != 0 && cursor != "" {
+		// the cursor is invalid after the journal is rotated or cleaned, so read from the latest entries
+		c.log.Warnf("Failed to read the journal of %s after the cursor, read it again: %s", c.hostInfo.Info.IpAddr, result.Stderr)
+		cursor = ""
+		result, err = c.run(journalCommand(c.sudo(), cursor), nil, journalTimeout, maxJournalOutput)
+	}
+	if err != nil {
+		return nil, cursor, err
+	}
+	if result.ExitCode != 0 {
+		return nil, cursor, fmt.Errorf("journalctl exited with %d: %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+
+	entries, newCursor := parseJournal(result.Stdout)
+	if newCursor == "" {
+		newCursor = cursor
+	}
+	return entries, newCursor, nil
+}
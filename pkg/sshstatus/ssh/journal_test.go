@@ -0,0 +1,35 @@
+package ssh
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseJournal tests the matching of the hardware errors, the order of the entries and the cursor
+func TestParseJournal(t *testing.T) {
+	output := strings.Join([]string{
+		`{"__CURSOR":"s=1;i=1","__REALTIME_TIMESTAMP":"1700000000000000","MESSAGE":"mlx5_core 0000:3b:00.0 ens1f0: Link down"}`,
+		`{"__CURSOR":"s=1;i=2","__REALTIME_TIMESTAMP":"1700000001000000","MESSAGE":"usb 1-1: new high-speed USB device"}`,
+		`{"__CURSOR":"s=1;i=3","__REALTIME_TIMESTAMP":"1700000002000000","MESSAGE":"NVRM: Xid (PCI:0000:17:00): 79, GPU has fallen off the bus."}`,
+		`{"__CURSOR":"s=1;i=4","__REALTIME_TIMESTAMP":"1700000003000000","MESSAGE":[98,108,107,95,117,112,100,97,116,101,95,114,101,113,117,101,115,116,58,32,73,47,79,32,101,114,114,111,114]}`,
+		`{"__CURSOR":"s=1;i=5","__REALTIME_TIMESTAMP":"1700000004000000","MESSAGE":"systemd-journald: started"}`,
+	}, "\n")
+
+	entries, cursor := parseJournal(output)
+	if cursor != "s=1;i=5" {
+		t.Errorf("unexpected cursor: %s", cursor)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("unexpected entries: %v", entries)
+	}
+	if entries[0]["message"] != "[DiskIO] blk_update_request: I/O error" || entries[0]["level"] != "ERROR" || entries[0]["timestamp"] != "2023-11-14T22:13:23Z" {
+		t.Errorf("unexpected latest entry: %v", entries[0])
+	}
+	if !strings.HasPrefix(entries[1]["message"], "[Xid]") || entries[2]["level"] != "WARNING" {
+		t.Errorf("unexpected entries: %v", entries)
+	}
+
+	if cmd := journalCommand("", "s=1;i=5"); cmd != "journalctl -k -o json --no-pager --after-cursor='s=1;i=5'" {
+		t.Errorf("unexpected command: %s", cmd)
+	}
+}
@@ -0,0 +1,183 @@
+package ssh
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+
+	sshstatusdata "github.com/infrastructure-io/topohub/pkg/sshstatus/data"
+)
+
+const (
+	// the interval of the keepalive probes of the pooled connections
+	keepaliveInterval = 30 * time.Second
+	keepaliveTimeout  = 10 * time.Second
+	keepaliveRequest  = "keepalive@openssh.com"
+	// the pooled connection is closed when it is not used for the time
+	maxIdleTime = 10 * time.Minute
+)
+
+// Pool keeps the ssh connections to the hosts, which are reused by the periodic collections and the operations.
+// The connections are probed by the keepalive, the broken ones are closed and dialed again at the next use
+type Pool struct {
+	lock  sync.Mutex
+	conns map[string]*pooledConn
+	once  sync.Once
+}
+
+type pooledConn struct {
+	client *Client
+	// the amount of the callers using the connection, which is not closed for the idle time when it is in use
+	users    int
+	lastUsed time.Time
+}
+
+// ConnectionPool is the global ssh connection pool
+var ConnectionPool = NewPool()
+
+// NewPool creates an empty ssh connection pool
+func NewPool() *Pool {
+	return &Pool{
+		conns: make(map[string]*pooledConn),
+	}
+}
+
+// poolKey identifies the connection by the address, the credentials and the jump host, so the connection
+// is dialed again when any of them changes
+func poolKey(hostInfo sshstatusdata.SSHConnectCon) string {
+	h := sha256.New()
+	for con := &hostInfo; con != nil; con = con.JumpHost {
+		if con.Info != nil {
+			fmt.Fprintf(h, "%s\x00%d\x00%s\x00", con.Info.IpAddr, con.Info.Port, con.Info.SSHHostKey)
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00%v\x00%s\x00%s\x00", con.Username, con.Password, con.SSHKeyAuth, con.SSHKey, con.SSHCertificate)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// keepalive sends the keepalive request to check the connection, the rejected request also means the server is alive
+func keepalive(conn *ssh.Client, timeout time.Duration) error {
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := conn.SendRequest(keepaliveRequest, true, nil)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("keepalive timed out after %v", timeout)
+	}
+}
+
+// Get returns the connection to the host from the pool, and dials a new one when there is no live connection.
+// The caller should Close the returned client to return it to the pool
+func (p *Pool) Get(hostInfo sshstatusdata.SSHConnectCon, logger *zap.SugaredLogger) (*Client, error) {
+	p.once.Do(func() {
+		go p.keepaliveLoop()
+	})
+
+	key := poolKey(hostInfo)
+	p.lock.Lock()
+	entry := p.conns[key]
+	if entry != nil {
+		entry.users++
+	}
+	p.lock.Unlock()
+
+	if entry != nil {
+		err := keepalive(entry.client.conn, keepaliveTimeout)
+		if err == nil {
+			return p.lease(entry), nil
+		}
+		if hostInfo.Info != nil {
+			logger.Infof("the ssh connection to %s is broken, reconnect: %v",
+				net.JoinHostPort(hostInfo.Info.IpAddr, strconv.Itoa(int(hostInfo.Info.Port))), err)
+		}
+		p.evict(key, entry)
+	}
+
+	client, err := NewClient(hostInfo, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if existing := p.conns[key]; existing != nil {
+		// another caller has dialed the host at the same time
+		_ = client.closeConn()
+		existing.users++
+		return p.lease(existing), nil
+	}
+	entry = &pooledConn{client: client, users: 1, lastUsed: time.Now()}
+	p.conns[key] = entry
+	return p.lease(entry), nil
+}
+
+// lease returns a copy of the pooled client, whose Close returns the connection to the pool
+func (p *Pool) lease(entry *pooledConn) *Client {
+	c := *entry.client
+	once := sync.Once{}
+	c.release = func() {
+		once.Do(func() {
+			p.lock.Lock()
+			defer p.lock.Unlock()
+			entry.users--
+			entry.lastUsed = time.Now()
+		})
+	}
+	return &c
+}
+
+// evict closes the broken connection, even though it is still in use
+func (p *Pool) evict(key string, entry *pooledConn) {
+	p.lock.Lock()
+	if p.conns[key] == entry {
+		delete(p.conns, key)
+	}
+	p.lock.Unlock()
+	_ = entry.client.closeConn()
+}
+
+// keepaliveLoop probes the pooled connections at interval, so the broken connections are found before the next use
+func (p *Pool) keepaliveLoop() {
+	ticker := time.NewTicker(keepaliveInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		p.probe()
+	}
+}
+
+// probe closes the idle connections and the connections which fail the keepalive
+func (p *Pool) probe() {
+	alive := map[string]*pooledConn{}
+	idle := []*pooledConn{}
+	p.lock.Lock()
+	for key, entry := range p.conns {
+		if entry.users == 0 && time.Since(entry.lastUsed) > maxIdleTime {
+			delete(p.conns, key)
+			idle = append(idle, entry)
+		} else {
+			alive[key] = entry
+		}
+	}
+	p.lock.Unlock()
+
+	for _, entry := range idle {
+		_ = entry.client.closeConn()
+	}
+	for key, entry := range alive {
+		if err := keepalive(entry.client.conn, keepaliveTimeout); err != nil {
+			entry.client.log.Infof("close the broken ssh connection to %s: %v", entry.client.hostInfo.Info.IpAddr, err)
+			p.evict(key, entry)
+		}
+	}
+}
@@ -10,11 +10,13 @@ import (
 
 	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
 	"github.com/infrastructure-io/topohub/pkg/lock"
+	"github.com/infrastructure-io/topohub/pkg/provision"
 	sshstatusdata "github.com/infrastructure-io/topohub/pkg/sshstatus/data"
 	ssh "github.com/infrastructure-io/topohub/pkg/sshstatus/ssh"
 
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,22 +32,30 @@ func (c *sshStatusController) UpdateSSHStatusInfo(name string, d *sshstatusdata.
 	lock.Lock()
 	defer lock.Unlock()
 
-	// Create SSH client
+	// Get the SSH client from the pool, the connection is reused between the intervals
 	var healthy bool
-	client, err1 := ssh.NewClient(*d, c.log)
-	if err1 != nil {
-		c.log.Warnf("Failed to create SSH client for SSHStatus %s: %v", name, err1)
+	client, connErr := ssh.ConnectionPool.Get(*d, c.log)
+	if connErr != nil {
+		c.log.Warnf("Failed to create SSH client for SSHStatus %s: %v", name, connErr)
 		healthy = false
 	} else {
 		defer client.Close()
-		healthy = client.IsHealthy()
+		if _, err := client.RunCommand("echo 'Connection test'"); err != nil {
+			connErr = err
+		}
+		healthy = connErr == nil
 	}
 
 	auth := "without username and password"
-	if len(d.Username) != 0 && len(d.Password) != 0 {
-		auth = "with username and password"
+	if d.SSHKeyAuth && len(d.SSHCertificate) != 0 {
+		auth = "with SSH certificate authentication"
 	} else if d.SSHKeyAuth && len(d.SSHKey) != 0 {
 		auth = "with SSH key authentication"
+	} else if len(d.Username) != 0 && len(d.Password) != 0 {
+		auth = "with username and password"
+	}
+	if d.Info.JumpHost != nil {
+		auth += fmt.Sprintf(", through the jump host %s", d.Info.JumpHost.IPAddr)
 	}
 	c.log.Debugf("try to check SSH with url: %s:%d, %s", d.Info.IpAddr, d.Info.Port, auth)
 
@@ -66,6 +76,7 @@ func (c *sshStatusController) UpdateSSHStatusInfo(name string, d *sshstatusdata.
 
 	// Check health status
 	updated.Status.Healthy = healthy
+	meta.SetStatusCondition(&updated.Status.Conditions, connectedCondition(connErr))
 	updated.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
 
 	// If healthy, get system information
@@ -76,6 +87,65 @@ func (c *sshStatusController) UpdateSSHStatusInfo(name string, d *sshstatusdata.
 			healthy = false
 		} else {
 			updated.Status.Info = infoData
+			// the SSHCollectors defined by the operators
+			c.runCollectors(name, client, existing, updated.Status.Info)
+		}
+	}
+
+	// the structured inventory is kept when it is partially collected
+	if healthy {
+		inventory, err := client.GetInventory()
+		if err != nil {
+			c.log.Warnf("Failed to get inventory of SSHStatus %s: %v", name, err)
+		}
+		if inventory != nil {
+			updated.Status.Inventory = inventory
+		}
+	}
+
+	// collect the hardware errors from the kernel journal after the cursor
+	if healthy {
+		logEntries, cursor, err := client.GetKernelLogs(updated.Status.Log.Cursor)
+		if err != nil {
+			c.log.Warnf("Failed to get logs of SSHStatus %s: %v", name, err)
+		} else {
+			updated.Status.Log.Cursor = cursor
+			lastLogTime := ""
+			if updated.Status.Log.LastestLog != nil {
+				lastLogTime = updated.Status.Log.LastestLog.Time
+			}
+			newLastestTime, newLastestMsg, newLastestWarningTime, newLastestWarningMsg, totalMsgCount, warningMsgCount, newLogAccount := c.GenerateEvents(logEntries, name, lastLogTime)
+			// the logs are collected incrementally, so the counts are accumulated
+			updated.Status.Log.TotalLogAccount += int32(totalMsgCount)
+			updated.Status.Log.WarningLogAccount += int32(warningMsgCount)
+			if newLastestTime != "" {
+				updated.Status.Log.LastestLog = &topohubv1beta1.LogEntry{
+					Time:    newLastestTime,
+					Message: newLastestMsg,
+				}
+				c.log.Infof("find %d new logs for sshStatus %s", newLogAccount, name)
+			}
+			if newLastestWarningMsg != "" {
+				updated.Status.Log.LastestWarningLog = &topohubv1beta1.LogEntry{
+					Time:    newLastestWarningTime,
+					Message: newLastestWarningMsg,
+				}
+			}
+		}
+	}
+
+	// the first ssh login after the pxe reboot indicates the new os is ready, it is reported only when the host is
+	// waiting for it, rather than on every healthy poll
+	if healthy && updated.Status.Basic.IpAddr != "" {
+		redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+		if err := c.client.List(context.Background(), redfishStatusList); err != nil {
+			c.log.Warnf("Failed to list redfishstatus for the provisioning of SSHStatus %s: %v", name, err)
+		} else if provision.WaitingForOsReady(redfishStatusList.Items, updated.Status.Basic.IpAddr) {
+			provision.SendEvent(c.provisionEvents, provision.Event{
+				Phase:   topohubv1beta1.ProvisionPhaseOsReady,
+				IpAddr:  updated.Status.Basic.IpAddr,
+				Message: fmt.Sprintf("ssh login to the sshstatus %s succeeded", name),
+			})
 		}
 	}
 
@@ -242,6 +312,12 @@ func (c *sshStatusController) processSSHStatus(sshStatus *topohubv1beta1.SSHStat
 				if hostEndpoint.Spec.SecretNamespace != nil {
 					sshStatus.Status.Basic.SecretNamespace = *hostEndpoint.Spec.SecretNamespace
 				}
+				if hostEndpoint.Spec.SSHHostKey != nil {
+					sshStatus.Status.Basic.SSHHostKey = *hostEndpoint.Spec.SSHHostKey
+				}
+				if hostEndpoint.Spec.SSHJumpHost != nil {
+					sshStatus.Status.Basic.JumpHost = hostEndpoint.Spec.SSHJumpHost.DeepCopy()
+				}
 				break
 			}
 		}
@@ -254,33 +330,13 @@ func (c *sshStatusController) processSSHStatus(sshStatus *topohubv1beta1.SSHStat
 		sshStatus.Status.Healthy)
 
 	// Cache SSH status data locally
-	username := ""
-	password := ""
-	sshKey := ""
-	sshKeyAuth := false
-	var err error
-	if len(sshStatus.Status.Basic.SecretName) > 0 && len(sshStatus.Status.Basic.SecretNamespace) > 0 {
-		username, password, sshKey, sshKeyAuth, err = c.getSecretData(
-			sshStatus.Status.Basic.SecretName,
-			sshStatus.Status.Basic.SecretNamespace,
-		)
-		if err != nil {
-			logger.Errorf("Failed to get secret data for SSHStatus %s: %v", sshStatus.Name, err)
-			return err
-		}
-		logger.Debugf("Adding/Updating SSHStatus %s in cache with username: %s, sshKeyAuth: %v",
-			sshStatus.Name, username, sshKeyAuth)
-	} else {
-		logger.Debugf("Adding/Updating SSHStatus %s in cache with empty authentication", sshStatus.Name)
-	}
-
-	sshConnectCon := sshstatusdata.SSHConnectCon{
-		Info:       &sshStatus.Status.Basic,
-		Username:   username,
-		Password:   password,
-		SSHKey:     sshKey,
-		SSHKeyAuth: sshKeyAuth,
+	sshConnectCon, err := c.getConnectCon(&sshStatus.Status.Basic)
+	if err != nil {
+		logger.Errorf("Failed to get secret data for SSHStatus %s: %v", sshStatus.Name, err)
+		return err
 	}
+	logger.Debugf("Adding/Updating SSHStatus %s in cache with username: %s, sshKeyAuth: %v",
+		sshStatus.Name, sshConnectCon.Username, sshConnectCon.SSHKeyAuth)
 
 	sshstatusdata.SSHCacheDatabase.Add(sshStatus.Name, sshConnectCon)
 
@@ -316,6 +372,7 @@ func (c *sshStatusController) Reconcile(ctx context.Context, req ctrl.Request) (
 				logger.Infof("delete sshStatus %s in cache, %+v", req.Name, *data)
 				sshstatusdata.SSHCacheDatabase.Delete(req.Name)
 			}
+			c.forgetCollectorRuns(req.Name)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "Failed to get SSHStatus")
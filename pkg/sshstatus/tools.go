@@ -3,33 +3,72 @@ package sshstatus
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	sshstatusdata "github.com/infrastructure-io/topohub/pkg/sshstatus/data"
+	ssh "github.com/infrastructure-io/topohub/pkg/sshstatus/ssh"
 )
 
-// getSecretData retrieves username and password from Secret
-func (c *sshStatusController) getSecretData(secretName, secretNamespace string) (string, string, string, bool, error) {
-	c.log.Debugf("Attempting to get secret data for %s/%s", secretNamespace, secretName)
-
+// getSecretData retrieves the authentication data from Secret
+func (c *sshStatusController) getSecretData(secretName, secretNamespace string) (map[string][]byte, error) {
 	c.log.Debugf("Fetching secret from Kubernetes API for %s/%s", secretNamespace, secretName)
 	// Get authentication information from Secret
 	secret, err := c.kubeClient.CoreV1().Secrets(secretNamespace).Get(context.TODO(), secretName, metav1.GetOptions{})
 	if err != nil {
 		c.log.Errorf("Failed to get secret %s/%s: %v", secretNamespace, secretName, err)
-		return "", "", "", false, err
+		return nil, err
 	}
 
-	username := string(secret.Data["username"])
-	password := string(secret.Data["password"])
-	sshKey := string(secret.Data["ssh-privatekey"])
-	sshKeyAuth := sshKey != ""
-
 	c.log.Debugf("Successfully retrieved secret data for %s/%s", secretNamespace, secretName)
-	return username, password, sshKey, sshKeyAuth, nil
+	return secret.Data, nil
+}
+
+// getConnectCon builds the connection information of the host and its jump host from their secrets
+func (c *sshStatusController) getConnectCon(basic *topohubv1beta1.SSHBasicInfo) (sshstatusdata.SSHConnectCon, error) {
+	con := sshstatusdata.SSHConnectCon{Info: basic}
+	if len(basic.SecretName) > 0 && len(basic.SecretNamespace) > 0 {
+		data, err := c.getSecretData(basic.SecretName, basic.SecretNamespace)
+		if err != nil {
+			return con, err
+		}
+		con = sshstatusdata.NewSSHConnectCon(basic, data)
+	}
+
+	if jumpInfo := sshstatusdata.JumpHostInfo(basic); jumpInfo != nil {
+		jump := sshstatusdata.SSHConnectCon{Info: jumpInfo}
+		if len(jumpInfo.SecretName) > 0 && len(jumpInfo.SecretNamespace) > 0 {
+			data, err := c.getSecretData(jumpInfo.SecretName, jumpInfo.SecretNamespace)
+			if err != nil {
+				return con, fmt.Errorf("failed to get the secret of the jump host: %v", err)
+			}
+			jump = sshstatusdata.NewSSHConnectCon(jumpInfo, data)
+		}
+		con.JumpHost = &jump
+	}
+	return con, nil
+}
+
+// connectedCondition returns the Connected condition for the result of the connection
+func connectedCondition(err error) metav1.Condition {
+	if err != nil {
+		return metav1.Condition{
+			Type:    topohubv1beta1.SSHStatusConditionConnected,
+			Status:  metav1.ConditionFalse,
+			Reason:  ssh.ErrorReason(err),
+			Message: err.Error(),
+		}
+	}
+	return metav1.Condition{
+		Type:    topohubv1beta1.SSHStatusConditionConnected,
+		Status:  metav1.ConditionTrue,
+		Reason:  topohubv1beta1.SSHReasonConnected,
+		Message: "the ssh connection is established",
+	}
 }
 
 // compareSSHStatus checks if two SSHStatus are equal, ignoring pointer issues
@@ -91,6 +130,25 @@ func compareSSHStatus(a, b topohubv1beta1.SSHStatusStatus, logger *zap.SugaredLo
 		return false
 	}
 
+	if !reflect.DeepEqual(a.Inventory, b.Inventory) {
+		if logger != nil {
+			logger.Debugf("compareSSHStatus Inventory changed: %+v -> %+v", b.Inventory, a.Inventory)
+		}
+		return false
+	}
+	if !conditionsEqual(a.Conditions, b.Conditions) {
+		if logger != nil {
+			logger.Debugf("compareSSHStatus Conditions changed: %+v -> %+v", b.Conditions, a.Conditions)
+		}
+		return false
+	}
+	if !reflect.DeepEqual(a.Log, b.Log) {
+		if logger != nil {
+			logger.Debugf("compareSSHStatus Log changed: %+v -> %+v", b.Log, a.Log)
+		}
+		return false
+	}
+
 	// Compare Info fields
 	if len(a.Info) != len(b.Info) {
 		if logger != nil {
@@ -110,6 +168,19 @@ func compareSSHStatus(a, b topohubv1beta1.SSHStatusStatus, logger *zap.SugaredLo
 	return true
 }
 
+// conditionsEqual compares the conditions ignoring the LastTransitionTime, which is only changed with the status
+func conditionsEqual(a, b []metav1.Condition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || a[i].Status != b[i].Status || a[i].Reason != b[i].Reason || a[i].Message != b[i].Message {
+			return false
+		}
+	}
+	return true
+}
+
 // GenerateEvents creates Kubernetes events from SSH log entries and returns the latest messages and counts
 func (c *sshStatusController) GenerateEvents(logEntries []map[string]string, sshStatusName string, lastLogTime string) (newLastestTime, newLastestMsg, newLastestWarningTime, newLastestWarningMsg string, totalMsgCount, warningMsgCount, newLogAccount int) {
 	totalMsgCount = 0
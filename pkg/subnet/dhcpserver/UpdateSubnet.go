@@ -3,16 +3,20 @@ package dhcpserver
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"time"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 
 	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/metrics"
 	"github.com/infrastructure-io/topohub/pkg/tools"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -25,6 +29,16 @@ type clientInfo struct {
 	DhcpExpireTime string `json:"dhcpExpireTime"`
 }
 
+// notifyStatusUpdate informs the statusUpdateWorker to update the status of the subnet. The channel is buffered
+// with one slot, and the worker updates the latest status, so the notification is dropped when one is pending.
+// It never blocks the sender when the worker is busy or has exited
+func (s *dhcpServer) notifyStatusUpdate() {
+	select {
+	case s.statusUpdateCh <- struct{}{}:
+	default:
+	}
+}
+
 // statusUpdateWorker handles subnet status updates with rate limiting
 func (s *dhcpServer) statusUpdateWorker() {
 	ticker := time.NewTicker(time.Second)
@@ -45,9 +59,14 @@ func (s *dhcpServer) statusUpdateWorker() {
 		case <-ticker.C:
 			// If we have a pending update when the ticker fires, process it
 			if pendingUpdate {
+				vendorClasses := parseVendorClassFromLog(s.logPath)
+				s.recordPxeClientArchs(vendorClasses)
 				if err := s.updateSubnetWithRetry(); err != nil {
 					s.log.Errorf("Failed to update subnet status: %v", err)
 				}
+				if err := s.syncDhcpLeases(vendorClasses); err != nil {
+					s.log.Errorf("Failed to sync DhcpLease: %v", err)
+				}
 				pendingUpdate = false
 			}
 		}
@@ -62,7 +81,9 @@ func (s *dhcpServer) updateSubnetWithRetry() error {
 		Steps:    5,
 	}
 
-	return retry.OnError(backoff,
+	var events []subnetEvent
+	var subnetName string
+	err := retry.OnError(backoff,
 		func(err error) bool {
 			// Retry on any error
 			//return true
@@ -79,6 +100,8 @@ func (s *dhcpServer) updateSubnetWithRetry() error {
 			defer s.lockData.RUnlock()
 
 			s.log.Debugf("it is about to update the status of subnet %s", s.subnet.Name)
+			events = nil
+			subnetName = s.subnet.Name
 
 			// 获取最新的 subnet
 			current := &topohubv1beta1.Subnet{}
@@ -105,7 +128,12 @@ func (s *dhcpServer) updateSubnetWithRetry() error {
 
 			clientDetails, usedIpAmount := updateClientFunc(s.log, s.currentLeaseClients, s.currentManualBindingClients)
 			updated.Status.DhcpClientDetails = clientDetails
-			updated.Status.DhcpStatus.DhcpIpAvailableAmount = totalIPs - usedIpAmount
+			// the used ips could be more than the total ips after the ip range is shrunk
+			availableIPs := uint64(0)
+			if totalIPs > usedIpAmount {
+				availableIPs = totalIPs - usedIpAmount
+			}
+			updated.Status.DhcpStatus.DhcpIpAvailableAmount = availableIPs
 			updated.Status.DhcpStatus.DhcpIpTotalAmount = totalIPs
 			updated.Status.DhcpStatus.DhcpIpActiveAmount = uint64(len(s.currentLeaseClients))
 			updated.Status.DhcpStatus.DhcpIpBindAmount = uint64(len(s.currentManualBindingClients))
@@ -126,7 +154,42 @@ func (s *dhcpServer) updateSubnetWithRetry() error {
 				})
 			}
 
+			if _, enabled := conflictDetectionEnabled(s.subnet); enabled {
+				setConflictCondition(&updated.Status, s.ipConflicts)
+			} else {
+				meta.RemoveStatusCondition(&updated.Status.Conditions, topohubv1beta1.SubnetConditionIPConflict)
+			}
+
+			if _, enabled := discoveryEnabled(s.subnet); enabled {
+				updated.Status.DiscoveredHosts = s.discoveredHostList()
+			} else {
+				updated.Status.DiscoveredHosts = nil
+			}
+
+			nearlyFull, exhausted := poolThresholds(s.subnet)
+			events = append(events, setPoolConditions(&updated.Status, totalIPs, availableIPs, nearlyFull, exhausted)...)
+			metrics.SetSubnetIPAmount(s.subnet.Name, totalIPs, availableIPs, uint64(len(s.currentLeaseClients)), uint64(len(s.currentManualBindingClients)))
+
+			updated.Status.OutOfRangeLeases = findOutOfRangeLeases(s.currentLeaseClients, s.subnet.Spec.IPv4Subnet.IPRange)
+			known := make(map[string]struct{}, len(current.Status.OutOfRangeLeases))
+			for _, item := range current.Status.OutOfRangeLeases {
+				known[item.IpAddr] = struct{}{}
+			}
+			for _, item := range updated.Status.OutOfRangeLeases {
+				if _, ok := known[item.IpAddr]; !ok {
+					events = append(events, subnetEvent{corev1.EventTypeWarning, leaseOutOfRangeEventReason,
+						fmt.Sprintf("the lease of ip %s for mac %s is out of the ip range %s", item.IpAddr, item.MacAddr, s.subnet.Spec.IPv4Subnet.IPRange)})
+				}
+			}
+
+			if s.subnet.Spec.Feature != nil && s.subnet.Spec.Feature.EnablePxe {
+				updated.Status.PxeClientArchs = s.pxeClientArchList()
+			} else {
+				updated.Status.PxeClientArchs = nil
+			}
+
 			if reflect.DeepEqual(current.Status, updated.Status) {
+				events = nil
 				return nil
 			}
 
@@ -138,6 +201,15 @@ func (s *dhcpServer) updateSubnetWithRetry() error {
 			s.log.Infof("succeeded to update subnet status for %s: %+v", updated.ObjectMeta.Name, updated.Status.DhcpStatus)
 			return nil
 		})
+	if err != nil {
+		return err
+	}
+
+	// the events are recorded only after the status is updated, in case of duplicate events on retries
+	for _, event := range events {
+		s.recordSubnetEvent(subnetName, event.eventType, event.reason, event.message)
+	}
+	return nil
 }
 
 // updateClientFunc returns a string representation of all DHCP clients with their binding status
@@ -0,0 +1,148 @@
+package dhcpserver
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	arpRequest = 1
+	arpReply   = 2
+
+	ethernetHeaderLen = 14
+	arpPacketLen      = 28
+)
+
+func htons(v uint16) uint16 {
+	return (v << 8) | (v >> 8)
+}
+
+// buildArpRequest builds an ethernet frame of arp request, who-has target tell src
+func buildArpRequest(srcMac net.HardwareAddr, srcIP, targetIP net.IP) []byte {
+	frame := make([]byte, ethernetHeaderLen+arpPacketLen)
+
+	// ethernet header
+	copy(frame[0:6], []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	copy(frame[6:12], srcMac)
+	binary.BigEndian.PutUint16(frame[12:14], syscall.ETH_P_ARP)
+
+	// arp packet
+	arp := frame[ethernetHeaderLen:]
+	binary.BigEndian.PutUint16(arp[0:2], 1)
+	binary.BigEndian.PutUint16(arp[2:4], syscall.ETH_P_IP)
+	arp[4] = 6
+	arp[5] = 4
+	binary.BigEndian.PutUint16(arp[6:8], arpRequest)
+	copy(arp[8:14], srcMac)
+	copy(arp[14:18], srcIP.To4())
+	copy(arp[24:28], targetIP.To4())
+
+	return frame
+}
+
+// parseArpReply returns the sender of an ethernet frame of arp reply
+func parseArpReply(frame []byte) (senderIP net.IP, senderMac net.HardwareAddr, ok bool) {
+	if len(frame) < ethernetHeaderLen+arpPacketLen {
+		return nil, nil, false
+	}
+	if binary.BigEndian.Uint16(frame[12:14]) != syscall.ETH_P_ARP {
+		return nil, nil, false
+	}
+	arp := frame[ethernetHeaderLen:]
+	if binary.BigEndian.Uint16(arp[6:8]) != arpReply || arp[4] != 6 || arp[5] != 4 {
+		return nil, nil, false
+	}
+	senderMac = make(net.HardwareAddr, 6)
+	copy(senderMac, arp[8:14])
+	senderIP = net.IPv4(arp[14], arp[15], arp[16], arp[17]).To4()
+	return senderIP, senderMac, true
+}
+
+// arpScan sends arp requests for all targets from the interface, and collects
+// the macs which answer for each ip within the timeout. More than one mac for
+// an ip means that the ip is conflicted
+func arpScan(interfaceName string, srcIP net.IP, targets []net.IP, timeout time.Duration) (map[string][]string, error) {
+	iface, err := net.InterfaceByName(interfaceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interface %s: %v", interfaceName, err)
+	}
+
+	fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_RAW, int(htons(syscall.ETH_P_ARP)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create raw socket: %v", err)
+	}
+	defer syscall.Close(fd)
+
+	if err := syscall.Bind(fd, &syscall.SockaddrLinklayer{Protocol: htons(syscall.ETH_P_ARP), Ifindex: iface.Index}); err != nil {
+		return nil, fmt.Errorf("failed to bind raw socket to %s: %v", interfaceName, err)
+	}
+	tv := syscall.NsecToTimeval((200 * time.Millisecond).Nanoseconds())
+	if err := syscall.SetsockoptTimeval(fd, syscall.SOL_SOCKET, syscall.SO_RCVTIMEO, &tv); err != nil {
+		return nil, fmt.Errorf("failed to set receive timeout: %v", err)
+	}
+
+	wanted := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		wanted[target.String()] = struct{}{}
+	}
+	result := make(map[string][]string)
+	done := make(chan struct{})
+
+	// receive the replies while sending the requests
+	go func() {
+		defer close(done)
+		buf := make([]byte, 1500)
+		deadline := time.Now().Add(timeout)
+		for time.Now().Before(deadline) {
+			n, _, err := syscall.Recvfrom(fd, buf, 0)
+			if err != nil {
+				continue
+			}
+			ip, mac, ok := parseArpReply(buf[:n])
+			if !ok {
+				continue
+			}
+			if _, ok := wanted[ip.String()]; !ok {
+				continue
+			}
+			macStr := strings.ToLower(mac.String())
+			found := false
+			for _, item := range result[ip.String()] {
+				if item == macStr {
+					found = true
+					break
+				}
+			}
+			if !found {
+				result[ip.String()] = append(result[ip.String()], macStr)
+			}
+		}
+	}()
+
+	broadcast := [8]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	dst := &syscall.SockaddrLinklayer{Protocol: htons(syscall.ETH_P_ARP), Ifindex: iface.Index, Halen: 6, Addr: broadcast}
+	var sendErr error
+	for i, target := range targets {
+		if target.Equal(srcIP) {
+			continue
+		}
+		if err := syscall.Sendto(fd, buildArpRequest(iface.HardwareAddr, srcIP, target), 0, dst); err != nil {
+			sendErr = fmt.Errorf("failed to send arp request for %s: %v", target, err)
+			break
+		}
+		// avoid flooding the network
+		if i%64 == 63 {
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	<-done
+	if sendErr != nil {
+		return nil, sendErr
+	}
+	return result, nil
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 	"text/template"
 	"time"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
 )
 
 // generateDnsmasqConfig generates the dnsmasq configuration file
@@ -36,12 +38,7 @@ func (s *dhcpServer) generateDnsmasqConfig() error {
 	}
 
 	// 准备接口名称
-	var interfaceName string
-	if s.subnet.Spec.Interface.VlanID != nil && *s.subnet.Spec.Interface.VlanID > 0 {
-		interfaceName = fmt.Sprintf(vlanInterfaceFormat, s.subnet.Spec.Interface.Interface, *s.subnet.Spec.Interface.VlanID)
-	} else {
-		interfaceName = s.subnet.Spec.Interface.Interface
-	}
+	interfaceName := s.getInterfaceName(s.subnet)
 
 	ipRange := strings.Split(s.subnet.Spec.IPv4Subnet.IPRange, ",")
 	for k := range ipRange {
@@ -57,12 +54,15 @@ func (s *dhcpServer) generateDnsmasqConfig() error {
 		LogFile                  string
 		EnablePxe                bool
 		EnableZtp                bool
+		BuiltinTftp              bool
 		EnableDhcpTrustedOnly    bool
 		Name                     string
 		SelfIP                   string
 		TftpServerDir            string
 		PxeEfiInTftpServerDir    string
 		HostIpBindingsConfigPath string
+		HttpServer               string
+		BootFiles                topohubv1beta1.PxeBootFilesSpec
 	}{
 		Interface:                interfaceName,
 		IPRanges:                 ipRange,
@@ -78,6 +78,14 @@ func (s *dhcpServer) generateDnsmasqConfig() error {
 		TftpServerDir:            s.config.StoragePathTftp,
 		PxeEfiInTftpServerDir:    s.config.StoragePathTftpAbsoluteDirForPxeEfi,
 		HostIpBindingsConfigPath: s.HostIpBindingsConfigPath,
+		BootFiles:                pxeBootFiles(s.subnet),
+	}
+	_, data.BuiltinTftp = builtinTftpEnabled(s.subnet)
+	if s.config.HttpEnabled {
+		data.HttpServer = data.SelfIP
+		if s.config.HttpPort != "" && s.config.HttpPort != "80" {
+			data.HttpServer += ":" + s.config.HttpPort
+		}
 	}
 
 	// 删除已存在的配置文件
@@ -114,6 +122,11 @@ func (s *dhcpServer) generateDnsmasqConfig() error {
 		}
 		s.log.Infof("created new bindings file: %s", s.HostIpBindingsConfigPath)
 	}
+	// restore the lease file from the DhcpLease objects when the storage is lost
+	if err := s.restoreLeaseFile(); err != nil {
+		s.log.Errorf("failed to restore lease file: %v", err)
+	}
+
 	// update the lease
 	if _, err := s.processDhcpLease(true); err != nil {
 		return fmt.Errorf("failed to process lease file: %v", err)
@@ -193,12 +206,14 @@ func (s *dhcpServer) processDhcpLease(ignoreLeaseExistenceError bool) (clientCha
 			MAC:                          fields[1],
 			IP:                           fields[2],
 			Hostname:                     fields[3],
+			ClientID:                     fields[4],
 			Active:                       true,
 			DhcpExpireTime:               expireTime,
 			Subnet:                       s.subnet.Spec.IPv4Subnet.Subnet,
 			SubnetName:                   s.subnet.Name,
 			ClusterName:                  clusterName,
 			EnableBindIpForRedfishstatus: &enableBindIP,
+			Naming:                       s.subnet.Spec.Feature.SyncRedfishstatus.Naming,
 		}
 		currentLeaseClients[clientInfo.IP] = clientInfo
 
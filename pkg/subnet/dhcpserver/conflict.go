@@ -0,0 +1,204 @@
+package dhcpserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+const (
+	// the default interval between two arp scans
+	defaultConflictScanInterval = 300 * time.Second
+	// the time to wait for the arp replies
+	conflictScanTimeout = 3 * time.Second
+	// the max amount of ip to be scanned for a subnet
+	conflictScanMaxIPs = 4096
+
+	conflictEventReason = "IPConflict"
+)
+
+// conflictDetectionEnabled returns the scan interval when the ip conflict detection is enabled
+func conflictDetectionEnabled(subnet *topohubv1beta1.Subnet) (time.Duration, bool) {
+	if subnet.Spec.Feature == nil || subnet.Spec.Feature.ConflictDetection == nil || !subnet.Spec.Feature.ConflictDetection.Enabled {
+		return 0, false
+	}
+	if subnet.Spec.Feature.ConflictDetection.ScanInterval > 0 {
+		return time.Duration(subnet.Spec.Feature.ConflictDetection.ScanInterval) * time.Second, true
+	}
+	return defaultConflictScanInterval, true
+}
+
+// conflictDetectWorker scans the subnet by arp at an interval.
+// note: dnsmasq pings the ip before offering it, which prevents from allocating the ip in use,
+// while the arp scan finds the hosts which do not answer the ping and the duplicate macs for an ip
+func (s *dhcpServer) conflictDetectWorker() {
+	timer := time.NewTimer(10 * time.Second)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			s.log.Infof("the ip conflict detector of subnet is exiting")
+			return
+
+		case <-timer.C:
+			s.lockData.RLock()
+			interval, enabled := conflictDetectionEnabled(s.subnet)
+			s.lockData.RUnlock()
+
+			if enabled {
+				if err := s.detectIPConflicts(); err != nil {
+					s.log.Errorf("failed to detect ip conflicts: %v", err)
+				}
+			} else {
+				interval = defaultConflictScanInterval
+				s.lockData.Lock()
+				changed := len(s.ipConflicts) > 0
+				s.ipConflicts = map[string]string{}
+				s.lockData.Unlock()
+				if changed {
+					s.notifyStatusUpdate()
+				}
+			}
+			timer.Reset(interval)
+		}
+	}
+}
+
+// detectIPConflicts scans the ip range, the binding ips and the hostendpoints in the subnet
+func (s *dhcpServer) detectIPConflicts() error {
+	s.lockData.RLock()
+	subnet := s.subnet.DeepCopy()
+	expected := make(map[string]string)
+	for ip, item := range s.currentLeaseClients {
+		expected[ip] = item.MAC
+	}
+	for ip, item := range s.currentManualBindingClients {
+		expected[ip] = item.MAC
+	}
+	s.lockData.RUnlock()
+
+	targets, err := tools.ListIPsInRange(subnet.Spec.IPv4Subnet.IPRange, conflictScanMaxIPs)
+	if err != nil {
+		return err
+	}
+	targetSet := make(map[string]struct{}, len(targets))
+	for _, ip := range targets {
+		targetSet[ip.String()] = struct{}{}
+	}
+
+	// the hostendpoints with static ip could be out of the ip range
+	_, cidr, err := net.ParseCIDR(subnet.Spec.IPv4Subnet.Subnet)
+	if err != nil {
+		return fmt.Errorf("invalid subnet %s: %v", subnet.Spec.IPv4Subnet.Subnet, err)
+	}
+	hostEndpointList := &topohubv1beta1.HostEndpointList{}
+	if err := s.client.List(context.Background(), hostEndpointList); err != nil {
+		return fmt.Errorf("failed to list hostendpoints: %v", err)
+	}
+	for _, item := range hostEndpointList.Items {
+		ip := net.ParseIP(item.Spec.IPAddr)
+		if ip == nil || !cidr.Contains(ip) {
+			continue
+		}
+		if _, ok := targetSet[ip.String()]; !ok {
+			targetSet[ip.String()] = struct{}{}
+			targets = append(targets, ip.To4())
+		}
+	}
+
+	replies, err := arpScan(s.getInterfaceName(subnet), net.ParseIP(strings.Split(subnet.Spec.Interface.IPv4, "/")[0]), targets, conflictScanTimeout)
+	if err != nil {
+		return err
+	}
+	conflicts := findIPConflicts(replies, expected, func(ip string) bool {
+		return tools.IsIPInRange(net.ParseIP(ip), subnet.Spec.IPv4Subnet.IPRange)
+	})
+	s.log.Debugf("arp scan finished for %d ips, %d answered, %d conflicts", len(targets), len(replies), len(conflicts))
+
+	s.lockData.Lock()
+	previous := s.ipConflicts
+	s.ipConflicts = conflicts
+	s.lockData.Unlock()
+
+	changed := len(previous) != len(conflicts)
+	for ip, msg := range conflicts {
+		if previous[ip] == msg {
+			continue
+		}
+		changed = true
+		s.log.Warnf("ip conflict: %s", msg)
+		s.recordSubnetEvent(subnet.Name, corev1.EventTypeWarning, conflictEventReason, msg)
+	}
+	if changed {
+		s.notifyStatusUpdate()
+	}
+
+	return nil
+}
+
+// findIPConflicts compares the arp replies with the expected macs of the leases and bindings
+// Example:
+//   - Input: replies={"192.168.1.10": ["00:11:22:33:44:55", "00:11:22:33:44:66"]}
+//   - Returns: {"192.168.1.10": "ip 192.168.1.10 is answered by multiple macs: 00:11:22:33:44:55,00:11:22:33:44:66"}
+func findIPConflicts(replies map[string][]string, expected map[string]string, inRange func(string) bool) map[string]string {
+	conflicts := make(map[string]string)
+	for ip, macs := range replies {
+		if len(macs) > 1 {
+			sorted := append([]string{}, macs...)
+			sort.Strings(sorted)
+			conflicts[ip] = fmt.Sprintf("ip %s is answered by multiple macs: %s", ip, strings.Join(sorted, ","))
+			continue
+		}
+		if len(macs) == 0 {
+			continue
+		}
+		if mac, ok := expected[ip]; ok {
+			if !strings.EqualFold(mac, macs[0]) {
+				conflicts[ip] = fmt.Sprintf("ip %s is assigned to mac %s, but answered by mac %s", ip, mac, macs[0])
+			}
+			continue
+		}
+		if inRange(ip) {
+			conflicts[ip] = fmt.Sprintf("ip %s in the dhcp range is used by mac %s, which is not allocated by the dhcp server", ip, macs[0])
+		}
+	}
+	return conflicts
+}
+
+// setConflictCondition sets the IPConflict condition of the subnet
+func setConflictCondition(status *topohubv1beta1.SubnetStatus, conflicts map[string]string) {
+	condition := metav1.Condition{
+		Type:    topohubv1beta1.SubnetConditionIPConflict,
+		Status:  metav1.ConditionFalse,
+		Reason:  "NoConflict",
+		Message: "no ip conflict is detected",
+	}
+	if len(conflicts) > 0 {
+		ips := make([]string, 0, len(conflicts))
+		for ip := range conflicts {
+			ips = append(ips, ip)
+		}
+		sort.Slice(ips, func(i, j int) bool {
+			return tools.CompareIP(net.ParseIP(ips[i]), net.ParseIP(ips[j])) < 0
+		})
+		msgs := make([]string, 0, len(ips))
+		for _, ip := range ips {
+			msgs = append(msgs, conflicts[ip])
+		}
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ConflictDetected"
+		condition.Message = strings.Join(msgs, "; ")
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+}
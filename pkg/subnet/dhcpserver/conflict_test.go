@@ -0,0 +1,60 @@
+package dhcpserver
+
+import (
+	"net"
+	"testing"
+)
+
+// TestFindIPConflicts tests the conflicts found from the arp replies
+func TestFindIPConflicts(t *testing.T) {
+	replies := map[string][]string{
+		"192.168.1.10":  {"00:11:22:33:44:55"},
+		"192.168.1.11":  {"00:11:22:33:44:66", "00:11:22:33:44:55"},
+		"192.168.1.12":  {"00:11:22:33:44:77"},
+		"192.168.1.13":  {"00:11:22:33:44:88"},
+		"192.168.1.200": {"00:11:22:33:44:99"},
+	}
+	expected := map[string]string{
+		"192.168.1.10": "00:11:22:33:44:55",
+		"192.168.1.12": "00:11:22:33:44:00",
+	}
+	inRange := func(ip string) bool {
+		return ip != "192.168.1.200"
+	}
+
+	conflicts := findIPConflicts(replies, expected, inRange)
+
+	want := map[string]string{
+		"192.168.1.11": "ip 192.168.1.11 is answered by multiple macs: 00:11:22:33:44:55,00:11:22:33:44:66",
+		"192.168.1.12": "ip 192.168.1.12 is assigned to mac 00:11:22:33:44:00, but answered by mac 00:11:22:33:44:77",
+		"192.168.1.13": "ip 192.168.1.13 in the dhcp range is used by mac 00:11:22:33:44:88, which is not allocated by the dhcp server",
+	}
+	if len(conflicts) != len(want) {
+		t.Fatalf("unexpected conflicts: %+v", conflicts)
+	}
+	for ip, msg := range want {
+		if conflicts[ip] != msg {
+			t.Errorf("unexpected conflict for %s: %s", ip, conflicts[ip])
+		}
+	}
+}
+
+// TestArpFrame tests that the reply for the request is parsed
+func TestArpFrame(t *testing.T) {
+	srcMac, _ := net.ParseMAC("00:11:22:33:44:55")
+	frame := buildArpRequest(srcMac, net.ParseIP("192.168.1.2"), net.ParseIP("192.168.1.10"))
+	if _, _, ok := parseArpReply(frame); ok {
+		t.Fatalf("arp request should not be parsed as reply")
+	}
+
+	// turn the request into a reply from 192.168.1.10
+	replyMac, _ := net.ParseMAC("00:11:22:33:44:66")
+	frame[ethernetHeaderLen+7] = arpReply
+	copy(frame[ethernetHeaderLen+8:], replyMac)
+	copy(frame[ethernetHeaderLen+14:], net.ParseIP("192.168.1.10").To4())
+
+	ip, mac, ok := parseArpReply(frame)
+	if !ok || ip.String() != "192.168.1.10" || mac.String() != replyMac.String() {
+		t.Errorf("unexpected arp reply: %v %v %v", ip, mac, ok)
+	}
+}
@@ -0,0 +1,440 @@
+package dhcpserver
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+const (
+	// the interval to check the approval and the scan interval
+	discoveryCheckInterval = 10 * time.Second
+	// the default interval between two discovery scans
+	defaultDiscoveryScanInterval = 3600 * time.Second
+	// the timeout to probe a service
+	discoveryProbeTimeout = 3 * time.Second
+	// the max amount of ip to be scanned for a subnet
+	discoveryMaxIPs = 4096
+	// the amount of hosts probed concurrently
+	discoveryConcurrency = 32
+
+	discoveryApproveAll = "all"
+
+	discoveryEventReason = "HostDiscovered"
+)
+
+// discoveryEnabled returns the discovery configuration when it is enabled
+func discoveryEnabled(subnet *topohubv1beta1.Subnet) (*topohubv1beta1.DiscoverySpec, bool) {
+	if subnet.Spec.Feature == nil || subnet.Spec.Feature.Discovery == nil || !subnet.Spec.Feature.Discovery.Enabled {
+		return nil, false
+	}
+	return subnet.Spec.Feature.Discovery, true
+}
+
+// discoveryWorker sweeps the network for the redfish and ssh hosts at an interval, and registers
+// the found hosts as hostEndpoint automatically or after the approval
+func (s *dhcpServer) discoveryWorker() {
+	ticker := time.NewTicker(discoveryCheckInterval)
+	defer ticker.Stop()
+
+	var lastScanTime time.Time
+	for {
+		select {
+		case <-s.stopCh:
+			s.log.Infof("the discovery of subnet is exiting")
+			return
+
+		case <-ticker.C:
+			s.lockData.RLock()
+			subnet := s.subnet.DeepCopy()
+			s.lockData.RUnlock()
+
+			spec, enabled := discoveryEnabled(subnet)
+			if !enabled {
+				lastScanTime = time.Time{}
+				s.lockData.Lock()
+				changed := len(s.discoveredHosts) > 0
+				s.discoveredHosts = map[string]*topohubv1beta1.DiscoveredHost{}
+				s.lockData.Unlock()
+				if changed {
+					s.notifyStatusUpdate()
+				}
+				continue
+			}
+
+			interval := defaultDiscoveryScanInterval
+			if spec.ScanInterval > 0 {
+				interval = time.Duration(spec.ScanInterval) * time.Second
+			}
+			if time.Since(lastScanTime) >= interval {
+				lastScanTime = time.Now()
+				if err := s.discoverHosts(subnet, spec); err != nil {
+					s.log.Errorf("failed to discover hosts: %v", err)
+				}
+			}
+
+			if err := s.registerDiscoveredHosts(spec); err != nil {
+				s.log.Errorf("failed to register discovered hosts: %v", err)
+			}
+		}
+	}
+}
+
+// knownHostIPs returns the ip of the hosts which have been managed
+func (s *dhcpServer) knownHostIPs() (map[string]struct{}, error) {
+	ctx := context.Background()
+	known := make(map[string]struct{})
+
+	hostEndpointList := &topohubv1beta1.HostEndpointList{}
+	if err := s.client.List(ctx, hostEndpointList); err != nil {
+		return nil, fmt.Errorf("failed to list hostendpoints: %v", err)
+	}
+	for _, item := range hostEndpointList.Items {
+		known[item.Spec.IPAddr] = struct{}{}
+	}
+
+	redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+	if err := s.client.List(ctx, redfishStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list redfishstatus: %v", err)
+	}
+	for _, item := range redfishStatusList.Items {
+		known[item.Status.Basic.IpAddr] = struct{}{}
+	}
+
+	sshStatusList := &topohubv1beta1.SSHStatusList{}
+	if err := s.client.List(ctx, sshStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list sshstatus: %v", err)
+	}
+	for _, item := range sshStatusList.Items {
+		known[item.Status.Basic.IpAddr] = struct{}{}
+	}
+
+	// the dhcp clients are synchronized by the SyncRedfishstatus
+	s.lockData.RLock()
+	for ip := range s.currentLeaseClients {
+		known[ip] = struct{}{}
+	}
+	s.lockData.RUnlock()
+
+	return known, nil
+}
+
+// discoverHosts probes all unknown ip in the cidr
+func (s *dhcpServer) discoverHosts(subnet *topohubv1beta1.Subnet, spec *topohubv1beta1.DiscoverySpec) error {
+	cidr := subnet.Spec.IPv4Subnet.Subnet
+	if spec.Cidr != nil && len(*spec.Cidr) > 0 {
+		cidr = *spec.Cidr
+	}
+	ipRange, err := cidrHostRange(cidr)
+	if err != nil {
+		return err
+	}
+	targets, err := tools.ListIPsInRange(ipRange, discoveryMaxIPs)
+	if err != nil {
+		return err
+	}
+
+	known, err := s.knownHostIPs()
+	if err != nil {
+		return err
+	}
+	known[strings.Split(subnet.Spec.Interface.IPv4, "/")[0]] = struct{}{}
+
+	redfishPort := int32(443)
+	if spec.RedfishPort > 0 {
+		redfishPort = spec.RedfishPort
+	}
+	sshPort := int32(22)
+	if spec.SSHPort != nil {
+		sshPort = *spec.SSHPort
+	}
+
+	s.log.Infof("begin to discover hosts in %s", cidr)
+	now := time.Now().UTC().Format(time.RFC3339)
+	found := make(map[string]*topohubv1beta1.DiscoveredHost)
+	lockFound := &sync.Mutex{}
+	sem := make(chan struct{}, discoveryConcurrency)
+	wg := sync.WaitGroup{}
+	for _, target := range targets {
+		ip := target.String()
+		if _, ok := known[ip]; ok {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var host *topohubv1beta1.DiscoveredHost
+			if probeRedfish(ip, redfishPort, discoveryProbeTimeout) {
+				host = &topohubv1beta1.DiscoveredHost{IpAddr: ip, Type: topohubv1beta1.EndpointTypeRedfish, Port: redfishPort, LastSeenTime: now}
+			} else if sshPort > 0 && probeSSH(ip, sshPort, discoveryProbeTimeout) {
+				host = &topohubv1beta1.DiscoveredHost{IpAddr: ip, Type: topohubv1beta1.EndpointTypeSSH, Port: sshPort, LastSeenTime: now}
+			}
+			if host != nil {
+				lockFound.Lock()
+				found[ip] = host
+				lockFound.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	s.log.Infof("finished discovering hosts in %s, found %d unmanaged hosts", cidr, len(found))
+
+	s.lockData.Lock()
+	for ip, host := range s.discoveredHosts {
+		if _, ok := found[ip]; ok {
+			found[ip].HostEndpoint = host.HostEndpoint
+		} else if len(host.HostEndpoint) > 0 {
+			// the registered host is skipped by the scan
+			found[ip] = host
+		}
+	}
+	var newHosts []*topohubv1beta1.DiscoveredHost
+	for ip, host := range found {
+		if _, ok := s.discoveredHosts[ip]; !ok {
+			newHosts = append(newHosts, host)
+		}
+	}
+	s.discoveredHosts = found
+	s.lockData.Unlock()
+
+	for _, host := range newHosts {
+		s.recordSubnetEvent(subnet.Name, corev1.EventTypeNormal, discoveryEventReason,
+			fmt.Sprintf("discovered %s host %s on port %d", host.Type, host.IpAddr, host.Port))
+	}
+	s.notifyStatusUpdate()
+
+	return nil
+}
+
+// registerDiscoveredHosts creates the hostEndpoint for the approved hosts
+func (s *dhcpServer) registerDiscoveredHosts(spec *topohubv1beta1.DiscoverySpec) error {
+	s.lockData.RLock()
+	subnetName := s.subnet.Name
+	var pending []topohubv1beta1.DiscoveredHost
+	for _, host := range s.discoveredHosts {
+		if len(host.HostEndpoint) == 0 {
+			pending = append(pending, *host)
+		}
+	}
+	s.lockData.RUnlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// get the latest annotations of the subnet
+	current := &topohubv1beta1.Subnet{}
+	if err := s.client.Get(context.Background(), types.NamespacedName{Name: subnetName}, current); err != nil {
+		return err
+	}
+	approveAll, approved := parseDiscoveryApproval(current.Annotations[topohubv1beta1.AnnotationDiscoveryApprove])
+
+	changed := false
+	for _, host := range pending {
+		if _, ok := approved[host.IpAddr]; !spec.AutoRegister && !approveAll && !ok {
+			continue
+		}
+		if secretName, _ := discoverySSHSecret(spec, s.config); host.Type == topohubv1beta1.EndpointTypeSSH && secretName == nil {
+			s.log.Debugf("skip registering the discovered ssh host %s, no ssh secret is configured", host.IpAddr)
+			continue
+		}
+		name, err := s.createHostEndpointForDiscoveredHost(current, spec, host)
+		if err != nil {
+			s.log.Errorf("failed to register discovered host %s: %v", host.IpAddr, err)
+			continue
+		}
+		s.lockData.Lock()
+		if item, ok := s.discoveredHosts[host.IpAddr]; ok {
+			item.HostEndpoint = name
+		}
+		s.lockData.Unlock()
+		changed = true
+		s.recordSubnetEvent(subnetName, corev1.EventTypeNormal, discoveryEventReason,
+			fmt.Sprintf("registered discovered %s host %s as hostEndpoint %s", host.Type, host.IpAddr, name))
+	}
+	if changed {
+		s.notifyStatusUpdate()
+	}
+
+	return nil
+}
+
+// discoverySSHSecret returns the secret of the discovered ssh hosts, which defaults to the ssh secret of the agent.
+// It returns nil when neither is configured, and the ssh hosts are not registered. The redfish secret is never
+// used, so the credentials of the BMC are not tried to login the operating system
+func discoverySSHSecret(spec *topohubv1beta1.DiscoverySpec, agentConfig *config.AgentConfig) (*string, *string) {
+	if spec.SSHSecretName != nil && *spec.SSHSecretName != "" {
+		namespace := agentConfig.SSHSecretNamespace
+		if spec.SSHSecretNamespace != nil && *spec.SSHSecretNamespace != "" {
+			namespace = *spec.SSHSecretNamespace
+		}
+		return spec.SSHSecretName, &namespace
+	}
+	if agentConfig.SSHSecretName == "" {
+		return nil, nil
+	}
+	name, namespace := agentConfig.SSHSecretName, agentConfig.SSHSecretNamespace
+	return &name, &namespace
+}
+
+func (s *dhcpServer) createHostEndpointForDiscoveredHost(subnet *topohubv1beta1.Subnet, spec *topohubv1beta1.DiscoverySpec, host topohubv1beta1.DiscoveredHost) (string, error) {
+	name := strings.ReplaceAll(host.IpAddr, ".", "-")
+	hostType := host.Type
+	port := host.Port
+	hostEndpoint := &topohubv1beta1.HostEndpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				topohubv1beta1.LabelSubnetName: subnet.Name,
+			},
+		},
+		Spec: topohubv1beta1.HostEndpointSpec{
+			ClusterName: spec.DefaultClusterName,
+			IPAddr:      host.IpAddr,
+			Port:        &port,
+			Type:        &hostType,
+		},
+	}
+	if hostType == topohubv1beta1.EndpointTypeSSH {
+		hostEndpoint.Spec.SecretName, hostEndpoint.Spec.SecretNamespace = discoverySSHSecret(spec, s.config)
+	}
+
+	if err := s.client.Create(context.Background(), hostEndpoint); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return name, nil
+		}
+		return "", err
+	}
+	s.log.Infof("created hostEndpoint %s for the discovered host: %+v", name, host)
+	return name, nil
+}
+
+// discoveredHostList returns the discovered hosts sorted by ip
+func (s *dhcpServer) discoveredHostList() []topohubv1beta1.DiscoveredHost {
+	if len(s.discoveredHosts) == 0 {
+		return nil
+	}
+	result := make([]topohubv1beta1.DiscoveredHost, 0, len(s.discoveredHosts))
+	for _, host := range s.discoveredHosts {
+		result = append(result, *host)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return tools.CompareIP(net.ParseIP(result[i].IpAddr), net.ParseIP(result[j].IpAddr)) < 0
+	})
+	return result
+}
+
+func (s *dhcpServer) recordSubnetEvent(subnetName, eventType, reason, msg string) {
+	s.recorder.Event(&corev1.ObjectReference{
+		Kind:       topohubv1beta1.KindSubnet,
+		Name:       subnetName,
+		Namespace:  s.config.PodNamespace,
+		APIVersion: topohubv1beta1.APIVersion,
+	}, eventType, reason, msg)
+}
+
+// parseDiscoveryApproval parses the value of the approval annotation
+// Example:
+//   - Input: "192.168.1.10, 192.168.1.11"
+//   - Returns: false, {"192.168.1.10", "192.168.1.11"}
+//   - Input: "all"
+//   - Returns: true, {}
+func parseDiscoveryApproval(value string) (bool, map[string]struct{}) {
+	approved := make(map[string]struct{})
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == discoveryApproveAll {
+			return true, approved
+		}
+		if len(item) > 0 {
+			approved[item] = struct{}{}
+		}
+	}
+	return false, approved
+}
+
+// cidrHostRange returns the ip range of the hosts in the cidr, without the network and broadcast address
+// Example:
+//   - Input: "192.168.1.0/24"
+//   - Returns: "192.168.1.1-192.168.1.254"
+func cidrHostRange(cidr string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", fmt.Errorf("invalid cidr %s: %v", cidr, err)
+	}
+	ones, bits := ipNet.Mask.Size()
+	if bits != 32 {
+		return "", fmt.Errorf("cidr %s is not ipv4", cidr)
+	}
+	start := binary.BigEndian.Uint32(ipNet.IP.To4())
+	end := start | (uint32(1)<<(32-ones) - 1)
+	if ones < 31 {
+		start++
+		end--
+	}
+	toIP := func(v uint32) string {
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, v)
+		return net.IP(b).String()
+	}
+	return toIP(start) + "-" + toIP(end), nil
+}
+
+// probeRedfish checks whether the redfish service root is served on the ip
+func probeRedfish(ip string, port int32, timeout time.Duration) bool {
+	c := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+	}
+	resp, err := c.Get(fmt.Sprintf("https://%s/redfish/v1", net.JoinHostPort(ip, fmt.Sprintf("%d", port))))
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(body), "RedfishVersion") || strings.Contains(string(body), "@odata.id")
+}
+
+// probeSSH checks whether the ssh banner is answered on the ip
+func probeSSH(ip string, port int32, timeout time.Duration) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(ip, fmt.Sprintf("%d", port)), timeout)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	banner, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(banner, "SSH-")
+}
@@ -0,0 +1,93 @@
+package dhcpserver
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestCidrHostRange tests the host range of the cidr
+func TestCidrHostRange(t *testing.T) {
+	cases := map[string]string{
+		"192.168.1.0/24":   "192.168.1.1-192.168.1.254",
+		"192.168.1.130/25": "192.168.1.129-192.168.1.254",
+		"192.168.1.2/31":   "192.168.1.2-192.168.1.3",
+		"192.168.1.2/32":   "192.168.1.2-192.168.1.2",
+	}
+	for cidr, expected := range cases {
+		got, err := cidrHostRange(cidr)
+		if err != nil || got != expected {
+			t.Errorf("unexpected range for %s: %s, %v", cidr, got, err)
+		}
+	}
+}
+
+// TestParseDiscoveryApproval tests the value of the approval annotation
+func TestParseDiscoveryApproval(t *testing.T) {
+	all, approved := parseDiscoveryApproval("192.168.1.10, 192.168.1.11")
+	if all || len(approved) != 2 {
+		t.Errorf("unexpected approval: %v %v", all, approved)
+	}
+	if all, _ := parseDiscoveryApproval("all"); !all {
+		t.Errorf("expected to approve all hosts")
+	}
+	if all, approved := parseDiscoveryApproval(""); all || len(approved) != 0 {
+		t.Errorf("expected to approve no host")
+	}
+}
+
+// TestDiscoverySSHSecret tests the discovered ssh hosts use the ssh secret rather than the redfish secret
+func TestDiscoverySSHSecret(t *testing.T) {
+	agentConfig := &config.AgentConfig{
+		RedfishSecretName:      "topohub-redfish-auth",
+		RedfishSecretNamespace: "topohub",
+		SSHSecretName:          "topohub-ssh-auth",
+		SSHSecretNamespace:     "topohub",
+	}
+	name, namespace := discoverySSHSecret(&topohubv1beta1.DiscoverySpec{}, agentConfig)
+	if *name != "topohub-ssh-auth" || *namespace != "topohub" {
+		t.Errorf("unexpected default secret: %s/%s", *namespace, *name)
+	}
+
+	secretName := "rack1-ssh"
+	name, namespace = discoverySSHSecret(&topohubv1beta1.DiscoverySpec{SSHSecretName: &secretName}, agentConfig)
+	if *name != "rack1-ssh" || *namespace != "topohub" {
+		t.Errorf("unexpected secret of the subnet: %s/%s", *namespace, *name)
+	}
+
+	// no default ssh credentials are configured
+	agentConfig.SSHSecretName = ""
+	if name, _ = discoverySSHSecret(&topohubv1beta1.DiscoverySpec{}, agentConfig); name != nil {
+		t.Errorf("unexpected secret without the ssh credentials: %s", *name)
+	}
+}
+
+// TestProbeSSH tests the ssh banner probe
+func TestProbeSSH(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte("SSH-2.0-OpenSSH_9.6\r\n"))
+			conn.Close()
+		}
+	}()
+
+	port := int32(listener.Addr().(*net.TCPAddr).Port)
+	if !probeSSH("127.0.0.1", port, time.Second) {
+		t.Errorf("expected to find the ssh service")
+	}
+	if probeRedfish("127.0.0.1", port, time.Second) {
+		t.Errorf("expected not to find the redfish service")
+	}
+}
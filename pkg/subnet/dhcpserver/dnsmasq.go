@@ -27,6 +27,11 @@ func (s *dhcpServer) startDnsmasq() error {
 	}
 	s.log.Infof("dnsmasq config file %s", s.configPath)
 
+	// the built-in tftp server replaces the tftp server of dnsmasq
+	if err := s.startTftp(); err != nil {
+		return err
+	}
+
 	// 创建 context 用于进程管理
 	ctx, cancel := context.WithCancel(context.Background())
 	s.cmdCancel = cancel
@@ -41,8 +46,11 @@ func (s *dhcpServer) startDnsmasq() error {
 	}
 
 	s.cmd = cmd
+	done := make(chan struct{})
+	s.cmdDone = done
 
 	go func() {
+		defer close(done)
 		// cancel the ctx
 		defer cancel()
 		if err := cmd.Wait(); err != nil {
@@ -61,11 +69,33 @@ func (s *dhcpServer) startDnsmasq() error {
 	}
 
 	// update the status of subnet
-	s.statusUpdateCh <- struct{}{}
+	s.notifyStatusUpdate()
 
 	return nil
 }
 
+// stopDnsmasq kills the running dnsmasq process and waits for its exit
+func (s *dhcpServer) stopDnsmasq() {
+	s.stopTftp()
+	if s.cmd == nil || s.cmd.Process == nil {
+		return
+	}
+	if s.cmdCancel != nil {
+		s.cmdCancel()
+	}
+	if err := s.cmd.Process.Kill(); err != nil {
+		s.log.Debugf("failed to kill dnsmasq process: %v", err)
+	}
+	if s.cmdDone != nil {
+		select {
+		case <-s.cmdDone:
+		case <-time.After(5 * time.Second):
+			s.log.Warnf("timeout to wait for the exit of dnsmasq process")
+		}
+	}
+	s.cmd = nil
+}
+
 // UpdateService updates the subnet configuration and restarts the DHCP server
 func (s *dhcpServer) UpdateService(subnet topohubv1beta1.Subnet) error {
 	s.lockData.Lock()
@@ -130,7 +160,7 @@ func (s *dhcpServer) monitor() {
 					continue
 				}
 				// update the status of subnet
-				s.statusUpdateCh <- struct{}{}
+				s.notifyStatusUpdate()
 			} else {
 				s.log.Debugf("watcher invalid file event: %+v", event)
 			}
@@ -187,10 +217,12 @@ func (s *dhcpServer) monitor() {
 			needReload = true
 
 		// reconcile notify subnet spec changes
+		// note: dnsmasq does not reload the dhcp-range on SIGHUP, so it has to be restarted
+		// to take the change of the ip range, and the config is renewed in the startDnsmasq
 		case <-s.restartCh:
-			needRenewConfig = true
-			needReload = true
-			s.log.Infof("dhcp server reload after the spec of subnet is updated")
+			s.stopDnsmasq()
+			needRestart = true
+			s.log.Infof("dhcp server restarts after the spec of subnet is updated")
 
 		// check the process
 		case <-tickerProcess.C:
@@ -217,6 +249,13 @@ func (s *dhcpServer) monitor() {
 			}
 		}
 
+		// the process is gone when the last restart fails, so it is restarted rather than reloaded
+		if needReload && (s.cmd == nil || s.cmd.Process == nil) {
+			s.log.Infof("dhcp server for %s is not running, restart it instead of the reload", subnetName)
+			needReload = false
+			needRestart = true
+		}
+
 		if needReload {
 			s.log.Infof("reload dhcp server")
 			// 重新加载 dnsmasq 配置
@@ -226,17 +265,13 @@ func (s *dhcpServer) monitor() {
 			}
 			s.log.Infof("Reloaded dnsmasq config: %s", s.configPath)
 			// update the status of subnet
-			s.statusUpdateCh <- struct{}{}
-
+			s.notifyStatusUpdate()
 		} else if needRestart {
 			s.log.Infof("restarting dhcp server")
-			// in the startDnsmasq, it finish 's.statusUpdateCh <- struct{}{}'
+			// the startDnsmasq notifies the status update of the subnet
 			if err := s.startDnsmasq(); err != nil {
 				s.log.Errorf("Failed to restart dnsmasq: %v", err)
 			}
-			// in the startDnsmasq, it finish 's.statusUpdateCh <- struct{}{}'
-			// s.statusUpdateCh <- struct{}{}
 		}
-
 	}
 }
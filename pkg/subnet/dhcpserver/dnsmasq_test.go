@@ -0,0 +1,64 @@
+package dhcpserver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	bindingipdata "github.com/infrastructure-io/topohub/pkg/bindingip/data"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/lock"
+)
+
+// TestMonitorReloadAfterFailedRestart tests the binding ip event does not reload the dnsmasq which fails to restart
+func TestMonitorReloadAfterFailedRestart(t *testing.T) {
+	dir := t.TempDir()
+	s := &dhcpServer{
+		lockData:         &lock.RWMutex{},
+		lockConfigUpdate: &lock.RWMutex{},
+		subnet: &topohubv1beta1.Subnet{
+			ObjectMeta: metav1.ObjectMeta{Name: "net0"},
+			Spec: topohubv1beta1.SubnetSpec{
+				// the interface does not exist, so the restart fails
+				Interface: topohubv1beta1.InterfaceSpec{Interface: "topohub-none0"},
+			},
+		},
+		addedBindingIp:              make(chan bindingipdata.BindingIPInfo, 1),
+		deletedBindingIp:            make(chan bindingipdata.BindingIPInfo, 1),
+		stopCh:                      make(chan struct{}),
+		statusUpdateCh:              make(chan struct{}, 1),
+		restartCh:                   make(chan struct{}),
+		log:                         zap.NewNop().Sugar(),
+		currentLeaseClients:         make(map[string]*DhcpClientInfo),
+		currentManualBindingClients: make(map[string]*DhcpClientInfo),
+		configPath:                  filepath.Join(dir, "dnsmasq-net0.conf"),
+		HostIpBindingsConfigPath:    filepath.Join(dir, "dnsmasq-net0-bindIp.conf"),
+		leasePath:                   filepath.Join(dir, "dnsmasq-net0.leases"),
+		logPath:                     filepath.Join(dir, "dnsmasq-net0.log"),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.monitor()
+	}()
+
+	// the restart fails, and the process of dnsmasq is gone
+	s.restartCh <- struct{}{}
+	s.addedBindingIp <- bindingipdata.BindingIPInfo{Subnet: "net0", IPAddr: "192.168.1.10", MacAddr: "00:11:22:33:44:55"}
+	// the monitor receives the next event only after the binding ip event is handled
+	select {
+	case s.restartCh <- struct{}{}:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timeout to wait for the monitor to handle the binding ip event")
+	}
+	if s.cmd != nil {
+		t.Errorf("expected the dnsmasq not to be running")
+	}
+
+	close(s.stopCh)
+	<-done
+}
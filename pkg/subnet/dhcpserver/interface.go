@@ -3,6 +3,7 @@ package dhcpserver
 import (
 	"fmt"
 
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
 	"github.com/infrastructure-io/topohub/pkg/tools"
 	"github.com/vishvananda/netlink"
 )
@@ -13,6 +14,14 @@ const (
 	//macvlanInterfaceFormat = "%s.topohub"
 )
 
+// getInterfaceName returns the name of the interface where the dhcp server works
+func (s *dhcpServer) getInterfaceName(subnet *topohubv1beta1.Subnet) string {
+	if subnet.Spec.Interface.VlanID != nil && *subnet.Spec.Interface.VlanID > 0 {
+		return fmt.Sprintf(vlanInterfaceFormat, subnet.Spec.Interface.Interface, *subnet.Spec.Interface.VlanID)
+	}
+	return subnet.Spec.Interface.Interface
+}
+
 // setupInterface configures the network interface for DHCP server
 func (s *dhcpServer) setupInterface() error {
 	var interfaceName string
@@ -0,0 +1,264 @@
+package dhcpserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+const (
+	// the size of the tail of the dnsmasq log, which is scanned for the vendor class of the clients
+	vendorClassLogScanSize = 1024 * 1024
+
+	// dnsmasq writes "*" to the lease file for an empty hostname or client-id
+	leaseEmptyField = "*"
+)
+
+// formatDhcpLeaseName returns the name of the DhcpLease object, for example: net0-192-168-1-10
+func formatDhcpLeaseName(subnetName, ip string) string {
+	return subnetName + "-" + strings.ReplaceAll(ip, ".", "-")
+}
+
+func leaseFieldValue(value string) string {
+	if value == leaseEmptyField {
+		return ""
+	}
+	return value
+}
+
+// syncDhcpLeases makes the DhcpLease objects of the subnet consistent with the lease file,
+// the vendor classes parsed from the dnsmasq log are recorded in the DhcpLease
+func (s *dhcpServer) syncDhcpLeases(vendorClasses map[string]string) error {
+	s.lockData.RLock()
+	subnet := s.subnet.DeepCopy()
+	clients := make(map[string]DhcpClientInfo, len(s.currentLeaseClients))
+	for ip, item := range s.currentLeaseClients {
+		clients[ip] = *item
+	}
+	s.lockData.RUnlock()
+
+	ctx := context.Background()
+	leaseList := &topohubv1beta1.DhcpLeaseList{}
+	if err := s.client.List(ctx, leaseList, client.MatchingLabels{topohubv1beta1.LabelSubnetName: subnet.Name}); err != nil {
+		return fmt.Errorf("failed to list DhcpLease: %v", err)
+	}
+
+	existing := make(map[string]*topohubv1beta1.DhcpLease, len(leaseList.Items))
+	for i := range leaseList.Items {
+		existing[leaseList.Items[i].Name] = &leaseList.Items[i]
+	}
+
+	for _, item := range clients {
+		name := formatDhcpLeaseName(subnet.Name, item.IP)
+		spec := topohubv1beta1.DhcpLeaseSpec{
+			Subnet:     subnet.Name,
+			IpAddr:     item.IP,
+			MacAddr:    item.MAC,
+			Hostname:   leaseFieldValue(item.Hostname),
+			ExpireTime: item.DhcpExpireTime.Format(time.RFC3339),
+			ClientID:   leaseFieldValue(item.ClientID),
+		}
+		labels := map[string]string{
+			topohubv1beta1.LabelSubnetName: subnet.Name,
+			topohubv1beta1.LabelIPAddr:     item.IP,
+			topohubv1beta1.LabelMacAddr:    strings.ReplaceAll(strings.ToLower(item.MAC), ":", "-"),
+		}
+		if len(item.ClusterName) > 0 {
+			labels[topohubv1beta1.LabelClusterName] = item.ClusterName
+		}
+
+		old, ok := existing[name]
+		delete(existing, name)
+		if ok {
+			// the vendor class could be rotated out of the log, so keep the previous one
+			spec.VendorClass = vendorClasses[strings.ToLower(item.MAC)]
+			if len(spec.VendorClass) == 0 && old.Spec.MacAddr == spec.MacAddr {
+				spec.VendorClass = old.Spec.VendorClass
+			}
+
+			updated := old.DeepCopy()
+			updated.Spec = spec
+			if updated.Labels == nil {
+				updated.Labels = map[string]string{}
+			}
+			for k, v := range labels {
+				updated.Labels[k] = v
+			}
+			if reflect.DeepEqual(old.Spec, updated.Spec) && reflect.DeepEqual(old.Labels, updated.Labels) {
+				continue
+			}
+			if err := s.client.Update(ctx, updated); err != nil {
+				s.log.Errorf("failed to update DhcpLease %s: %v", name, err)
+				continue
+			}
+			s.log.Debugf("updated DhcpLease %s: %+v", name, updated.Spec)
+			continue
+		}
+
+		spec.VendorClass = vendorClasses[strings.ToLower(item.MAC)]
+		setTrue := true
+		lease := &topohubv1beta1.DhcpLease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: labels,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion:         topohubv1beta1.APIVersion,
+						Kind:               topohubv1beta1.KindSubnet,
+						Name:               subnet.Name,
+						UID:                subnet.UID,
+						BlockOwnerDeletion: &setTrue,
+					},
+				},
+			},
+			Spec: spec,
+		}
+		if err := s.client.Create(ctx, lease); err != nil {
+			if errors.IsAlreadyExists(err) {
+				continue
+			}
+			s.log.Errorf("failed to create DhcpLease %s: %v", name, err)
+			continue
+		}
+		s.log.Infof("created DhcpLease %s: %+v", name, lease.Spec)
+	}
+
+	// the lease is released or expired
+	for name, lease := range existing {
+		if err := s.client.Delete(ctx, lease); err != nil && !errors.IsNotFound(err) {
+			s.log.Errorf("failed to delete DhcpLease %s: %v", name, err)
+			continue
+		}
+		s.log.Infof("deleted DhcpLease %s for the released ip %s", name, lease.Spec.IpAddr)
+	}
+
+	return nil
+}
+
+// restoreLeaseFile rebuilds the lease file from the unexpired DhcpLease objects,
+// when the lease file is lost with the storage. So that, dnsmasq will not
+// allocate the ip in use to other clients
+func (s *dhcpServer) restoreLeaseFile() error {
+	if _, err := os.Stat(s.leasePath); err == nil || !os.IsNotExist(err) {
+		return nil
+	}
+
+	s.lockData.RLock()
+	subnetName := s.subnet.Name
+	s.lockData.RUnlock()
+
+	leaseList := &topohubv1beta1.DhcpLeaseList{}
+	if err := s.client.List(context.Background(), leaseList, client.MatchingLabels{topohubv1beta1.LabelSubnetName: subnetName}); err != nil {
+		return fmt.Errorf("failed to list DhcpLease: %v", err)
+	}
+
+	content := buildLeaseFileContent(leaseList.Items, time.Now())
+	if len(content) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.leasePath), 0755); err != nil {
+		return fmt.Errorf("failed to create lease directory: %v", err)
+	}
+	if err := os.WriteFile(s.leasePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write lease file: %v", err)
+	}
+	s.log.Infof("restored lease file %s from %d DhcpLease objects", s.leasePath, len(leaseList.Items))
+
+	return nil
+}
+
+// buildLeaseFileContent generates the dnsmasq lease lines for the unexpired leases
+// Example:
+// 1735689600 00:11:22:33:44:55 192.168.1.10 host1 01:00:11:22:33:44:55
+func buildLeaseFileContent(leases []topohubv1beta1.DhcpLease, now time.Time) string {
+	var lines []string
+	for _, lease := range leases {
+		expireTime, err := time.Parse(time.RFC3339, lease.Spec.ExpireTime)
+		if err != nil || !expireTime.After(now) {
+			continue
+		}
+		hostname := lease.Spec.Hostname
+		if len(hostname) == 0 {
+			hostname = leaseEmptyField
+		}
+		clientID := lease.Spec.ClientID
+		if len(clientID) == 0 {
+			clientID = leaseEmptyField
+		}
+		lines = append(lines, fmt.Sprintf("%d %s %s %s %s", expireTime.Unix(), lease.Spec.MacAddr, lease.Spec.IpAddr, hostname, clientID))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// parseVendorClassFromLog scans the tail of the dnsmasq log, and returns the vendor class of each client mac
+func parseVendorClassFromLog(logPath string) map[string]string {
+	f, err := os.Open(logPath)
+	if err != nil {
+		return map[string]string{}
+	}
+	defer f.Close()
+
+	if info, err := f.Stat(); err == nil && info.Size() > vendorClassLogScanSize {
+		if _, err := f.Seek(-vendorClassLogScanSize, io.SeekEnd); err != nil {
+			return map[string]string{}
+		}
+	}
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return map[string]string{}
+	}
+
+	return parseVendorClass(string(content))
+}
+
+// parseVendorClass parses the dnsmasq log with log-dhcp enabled, where the vendor class
+// and the ack are associated by the transaction id
+// Example:
+// Jan  1 00:00:00 dnsmasq-dhcp[10]: 1234567 vendor class: PXEClient:Arch:00007:UNDI:003016
+// Jan  1 00:00:00 dnsmasq-dhcp[10]: 1234567 DHCPACK(eth0) 192.168.1.10 00:11:22:33:44:55 host1
+func parseVendorClass(content string) map[string]string {
+	result := make(map[string]string)
+	vendorByTransaction := make(map[string]string)
+
+	for _, line := range strings.Split(content, "\n") {
+		idx := strings.Index(line, "]: ")
+		if idx < 0 {
+			continue
+		}
+		fields := strings.Fields(line[idx+3:])
+		if len(fields) < 2 {
+			continue
+		}
+		transactionID := fields[0]
+
+		if vc, found := strings.CutPrefix(strings.Join(fields[1:], " "), "vendor class: "); found {
+			vendorByTransaction[transactionID] = vc
+			continue
+		}
+		if strings.HasPrefix(fields[1], "DHCPACK(") && len(fields) >= 4 {
+			if vc, ok := vendorByTransaction[transactionID]; ok {
+				result[strings.ToLower(fields[3])] = vc
+				delete(vendorByTransaction, transactionID)
+			}
+		}
+	}
+
+	return result
+}
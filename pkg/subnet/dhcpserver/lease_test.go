@@ -0,0 +1,62 @@
+package dhcpserver
+
+import (
+	"testing"
+	"time"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestParseVendorClass tests that the vendor class is associated to the mac by the transaction id
+func TestParseVendorClass(t *testing.T) {
+	content := `Jan  1 00:00:00 dnsmasq-dhcp[10]: 1234567 vendor class: PXEClient:Arch:00007:UNDI:003016
+Jan  1 00:00:00 dnsmasq-dhcp[10]: 1234567 DHCPDISCOVER(eth0) 00:11:22:33:44:55
+Jan  1 00:00:00 dnsmasq-dhcp[10]: 1234567 DHCPACK(eth0) 192.168.1.10 00:11:22:33:44:55 host1
+Jan  1 00:00:01 dnsmasq-dhcp[10]: 7654321 DHCPACK(eth0) 192.168.1.11 00:11:22:33:44:66 host2
+`
+	result := parseVendorClass(content)
+
+	if result["00:11:22:33:44:55"] != "PXEClient:Arch:00007:UNDI:003016" {
+		t.Errorf("unexpected vendor class: %+v", result)
+	}
+	if _, ok := result["00:11:22:33:44:66"]; ok {
+		t.Errorf("expected no vendor class for the client without option 60: %+v", result)
+	}
+}
+
+// TestBuildLeaseFileContent tests that only the unexpired leases are restored
+func TestBuildLeaseFileContent(t *testing.T) {
+	now := time.Unix(1735689600, 0)
+	leases := []topohubv1beta1.DhcpLease{
+		{Spec: topohubv1beta1.DhcpLeaseSpec{IpAddr: "192.168.1.10", MacAddr: "00:11:22:33:44:55", Hostname: "host1", ClientID: "01:00:11:22:33:44:55", ExpireTime: now.Add(time.Hour).Format(time.RFC3339)}},
+		{Spec: topohubv1beta1.DhcpLeaseSpec{IpAddr: "192.168.1.11", MacAddr: "00:11:22:33:44:66", ExpireTime: now.Add(time.Hour).Format(time.RFC3339)}},
+		{Spec: topohubv1beta1.DhcpLeaseSpec{IpAddr: "192.168.1.12", MacAddr: "00:11:22:33:44:77", ExpireTime: now.Add(-time.Hour).Format(time.RFC3339)}},
+	}
+
+	expected := "1735693200 00:11:22:33:44:55 192.168.1.10 host1 01:00:11:22:33:44:55\n" +
+		"1735693200 00:11:22:33:44:66 192.168.1.11 * *\n"
+	if got := buildLeaseFileContent(leases, now); got != expected {
+		t.Errorf("unexpected lease file content:\n%s", got)
+	}
+}
+
+// TestParsePxeClientArch tests the parsing of the client architecture from the vendor class
+func TestParsePxeClientArch(t *testing.T) {
+	tests := []struct {
+		vendorClass string
+		arch        int32
+		ok          bool
+	}{
+		{"PXEClient:Arch:00000:UNDI:002001", 0, true},
+		{"PXEClient:Arch:00011:UNDI:003016", 11, true},
+		{"HTTPClient:Arch:00016:UNDI:003001", 16, true},
+		{"MSFT 5.0", 0, false},
+		{"PXEClient:Arch:abc", 0, false},
+	}
+	for _, tt := range tests {
+		arch, ok := parsePxeClientArch(tt.vendorClass)
+		if arch != tt.arch || ok != tt.ok {
+			t.Errorf("parsePxeClientArch(%q) = %d, %v, expected %d, %v", tt.vendorClass, arch, ok, tt.arch, tt.ok)
+		}
+	}
+}
@@ -7,12 +7,16 @@ import (
 	"path/filepath"
 
 	"go.uber.org/zap"
+	"k8s.io/client-go/tools/record"
 
 	bindingipdata "github.com/infrastructure-io/topohub/pkg/bindingip/data"
 	"github.com/infrastructure-io/topohub/pkg/config"
 	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
 	"github.com/infrastructure-io/topohub/pkg/lock"
 	"github.com/infrastructure-io/topohub/pkg/log"
+	"github.com/infrastructure-io/topohub/pkg/metrics"
+	"github.com/infrastructure-io/topohub/pkg/provision"
+	"github.com/infrastructure-io/topohub/pkg/tftp"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -46,7 +50,9 @@ type dhcpServer struct {
 	//
 	cmd       *exec.Cmd
 	cmdCancel context.CancelFunc
-	stopCh    chan struct{}
+	// closed when the dnsmasq process exits
+	cmdDone <-chan struct{}
+	stopCh  chan struct{}
 	// 本模块用来通知给 Redfishstatus 模块，有新的 dhcp ip 分配，让其创建出 redfishstatus
 	addedDhcpClientForRedfishStatus chan DhcpClientInfo
 	// 本模块用来通知给 Redfishstatus 模块，有 dhcp ip 释放
@@ -56,6 +62,19 @@ type dhcpServer struct {
 	addedBindingIp   chan bindingipdata.BindingIPInfo
 	deletedBindingIp chan bindingipdata.BindingIPInfo
 
+	// the ip conflicts found by the arp scan, the key is the ip and the value is the description
+	ipConflicts map[string]string
+	// the hosts found by the discovery scan, the key is the ip
+	discoveredHosts map[string]*topohubv1beta1.DiscoveredHost
+	// the client architectures of the pxe clients, the key is the mac
+	pxeClientArchs map[string]int32
+	// report the pxe dhcp requests and the tftp downloads to track the provisioning
+	provisionEvents chan provision.Event
+	recorder        record.EventRecorder
+	// the files generated in memory for the built-in tftp server
+	tftpFiles  *tftp.MemoryFiles
+	tftpServer *tftp.Server
+
 	// update the status of crd
 	statusUpdateCh chan struct{}
 	log            *zap.SugaredLogger
@@ -72,7 +91,7 @@ type dhcpServer struct {
 }
 
 // NewDhcpServer creates a new DHCP server instance
-func NewDhcpServer(config *config.AgentConfig, subnet *topohubv1beta1.Subnet, client client.Client, addedDhcpClientForRedfishStatus chan DhcpClientInfo, deletedDhcpClientForRedfishStatus chan DhcpClientInfo) *dhcpServer {
+func NewDhcpServer(config *config.AgentConfig, subnet *topohubv1beta1.Subnet, client client.Client, recorder record.EventRecorder, addedDhcpClientForRedfishStatus chan DhcpClientInfo, deletedDhcpClientForRedfishStatus chan DhcpClientInfo, provisionEvents chan provision.Event, tftpFiles *tftp.MemoryFiles) *dhcpServer {
 
 	return &dhcpServer{
 		config:                            config,
@@ -80,12 +99,18 @@ func NewDhcpServer(config *config.AgentConfig, subnet *topohubv1beta1.Subnet, cl
 		lockConfigUpdate:                  &lock.RWMutex{},
 		subnet:                            subnet,
 		client:                            client,
+		recorder:                          recorder,
+		ipConflicts:                       make(map[string]string),
+		discoveredHosts:                   make(map[string]*topohubv1beta1.DiscoveredHost),
+		pxeClientArchs:                    make(map[string]int32),
+		provisionEvents:                   provisionEvents,
+		tftpFiles:                         tftpFiles,
 		addedDhcpClientForRedfishStatus:   addedDhcpClientForRedfishStatus,
 		deletedDhcpClientForRedfishStatus: deletedDhcpClientForRedfishStatus,
 		addedBindingIp:                    make(chan bindingipdata.BindingIPInfo, 1000),
 		deletedBindingIp:                  make(chan bindingipdata.BindingIPInfo, 1000),
 		stopCh:                            make(chan struct{}),
-		statusUpdateCh:                    make(chan struct{}),
+		statusUpdateCh:                    make(chan struct{}, 1),
 		restartCh:                         make(chan struct{}),
 		log:                               log.Logger.Named("dhcpServer/" + subnet.Name),
 		currentLeaseClients:               make(map[string]*DhcpClientInfo),
@@ -118,6 +143,15 @@ func (s *dhcpServer) Run() error {
 	// 启动状态监控
 	go s.monitor()
 
+	// 启动 IP 冲突检测
+	go s.conflictDetectWorker()
+
+	// 启动主机发现
+	go s.discoveryWorker()
+
+	// 跟踪主机装机过程
+	go s.provisionLogWorker()
+
 	s.log.Infof("finished setting up dhcp server")
 
 	return nil
@@ -134,6 +168,11 @@ func (s *dhcpServer) Stop() error {
 			s.log.Errorf("Failed to kill dnsmasq process: %v", err)
 		}
 	}
+	s.stopTftp()
+
+	s.lockData.RLock()
+	metrics.DeleteSubnet(s.subnet.Name)
+	s.lockData.RUnlock()
 
 	// 清理网络接口
 	s.log.Infof("clean all interfaces")
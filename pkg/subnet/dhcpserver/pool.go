@@ -0,0 +1,103 @@
+package dhcpserver
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+const (
+	defaultPoolNearlyFullThreshold = 80
+	defaultPoolExhaustedThreshold  = 100
+
+	poolEventReason            = "IPPool"
+	leaseOutOfRangeEventReason = "LeaseOutOfRange"
+)
+
+type subnetEvent struct {
+	eventType string
+	reason    string
+	message   string
+}
+
+// poolThresholds returns the utilization thresholds of the ip pool in percent
+func poolThresholds(subnet *topohubv1beta1.Subnet) (nearlyFull, exhausted int32) {
+	nearlyFull = defaultPoolNearlyFullThreshold
+	exhausted = defaultPoolExhaustedThreshold
+	if subnet.Spec.Feature == nil || subnet.Spec.Feature.PoolThreshold == nil {
+		return
+	}
+	if subnet.Spec.Feature.PoolThreshold.NearlyFull > 0 {
+		nearlyFull = subnet.Spec.Feature.PoolThreshold.NearlyFull
+	}
+	if subnet.Spec.Feature.PoolThreshold.Exhausted > 0 {
+		exhausted = subnet.Spec.Feature.PoolThreshold.Exhausted
+	}
+	return
+}
+
+// setPoolConditions sets the PoolNearlyFull and PoolExhausted conditions by the utilization of
+// the ip pool, and returns the events for the changed conditions
+func setPoolConditions(status *topohubv1beta1.SubnetStatus, total, available uint64, nearlyFull, exhausted int32) []subnetEvent {
+	utilization := uint64(100)
+	if total > 0 {
+		utilization = (total - available) * 100 / total
+	}
+
+	var events []subnetEvent
+	for _, item := range []struct {
+		conditionType string
+		threshold     int32
+	}{
+		{topohubv1beta1.SubnetConditionPoolNearlyFull, nearlyFull},
+		{topohubv1beta1.SubnetConditionPoolExhausted, exhausted},
+	} {
+		condition := metav1.Condition{
+			Type:    item.conditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  "BelowThreshold",
+			Message: fmt.Sprintf("ip utilization %d%% is below the threshold %d%%, %d of %d ip available", utilization, item.threshold, available, total),
+		}
+		if utilization >= uint64(item.threshold) {
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = "ThresholdExceeded"
+			condition.Message = fmt.Sprintf("ip utilization %d%% reaches the threshold %d%%, %d of %d ip available", utilization, item.threshold, available, total)
+		}
+
+		old := meta.FindStatusCondition(status.Conditions, item.conditionType)
+		if condition.Status == metav1.ConditionTrue && (old == nil || old.Status != metav1.ConditionTrue) {
+			events = append(events, subnetEvent{corev1.EventTypeWarning, poolEventReason, item.conditionType + ": " + condition.Message})
+		} else if condition.Status == metav1.ConditionFalse && old != nil && old.Status == metav1.ConditionTrue {
+			events = append(events, subnetEvent{corev1.EventTypeNormal, poolEventReason, item.conditionType + " is resolved: " + condition.Message})
+		}
+		meta.SetStatusCondition(&status.Conditions, condition)
+	}
+
+	return events
+}
+
+// findOutOfRangeLeases returns the leases which are not in the ip range, sorted by ip
+func findOutOfRangeLeases(leases map[string]*DhcpClientInfo, ipRange string) []topohubv1beta1.OutOfRangeLease {
+	var result []topohubv1beta1.OutOfRangeLease
+	for ip, item := range leases {
+		if tools.IsIPInRange(net.ParseIP(ip), ipRange) {
+			continue
+		}
+		result = append(result, topohubv1beta1.OutOfRangeLease{
+			IpAddr:   ip,
+			MacAddr:  item.MAC,
+			Hostname: leaseFieldValue(item.Hostname),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return tools.CompareIP(net.ParseIP(result[i].IpAddr), net.ParseIP(result[j].IpAddr)) < 0
+	})
+	return result
+}
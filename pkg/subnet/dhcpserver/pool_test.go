@@ -0,0 +1,48 @@
+package dhcpserver
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestSetPoolConditions tests the pool conditions and the events on the transitions
+func TestSetPoolConditions(t *testing.T) {
+	status := &topohubv1beta1.SubnetStatus{}
+
+	events := setPoolConditions(status, 100, 50, 80, 100)
+	if len(events) != 0 {
+		t.Errorf("expected no event, got %+v", events)
+	}
+
+	events = setPoolConditions(status, 100, 10, 80, 100)
+	if len(events) != 1 || !meta.IsStatusConditionTrue(status.Conditions, topohubv1beta1.SubnetConditionPoolNearlyFull) {
+		t.Errorf("expected PoolNearlyFull, got events %+v, conditions %+v", events, status.Conditions)
+	}
+
+	events = setPoolConditions(status, 100, 0, 80, 100)
+	if len(events) != 1 || !meta.IsStatusConditionTrue(status.Conditions, topohubv1beta1.SubnetConditionPoolExhausted) {
+		t.Errorf("expected PoolExhausted, got events %+v, conditions %+v", events, status.Conditions)
+	}
+
+	events = setPoolConditions(status, 200, 150, 80, 100)
+	if len(events) != 2 || meta.IsStatusConditionTrue(status.Conditions, topohubv1beta1.SubnetConditionPoolNearlyFull) ||
+		meta.IsStatusConditionTrue(status.Conditions, topohubv1beta1.SubnetConditionPoolExhausted) {
+		t.Errorf("expected the conditions to be resolved, got events %+v, conditions %+v", events, status.Conditions)
+	}
+}
+
+// TestFindOutOfRangeLeases tests the leases out of a shrunk ip range
+func TestFindOutOfRangeLeases(t *testing.T) {
+	leases := map[string]*DhcpClientInfo{
+		"192.168.1.20": {IP: "192.168.1.20", MAC: "00:00:00:00:00:02", Hostname: "*"},
+		"192.168.1.5":  {IP: "192.168.1.5", MAC: "00:00:00:00:00:01", Hostname: "host1"},
+		"192.168.1.10": {IP: "192.168.1.10", MAC: "00:00:00:00:00:03", Hostname: "host3"},
+	}
+	result := findOutOfRangeLeases(leases, "192.168.1.10-192.168.1.15")
+	if len(result) != 2 || result[0].IpAddr != "192.168.1.5" || result[1].IpAddr != "192.168.1.20" || result[1].Hostname != "" {
+		t.Errorf("unexpected out of range leases: %+v", result)
+	}
+}
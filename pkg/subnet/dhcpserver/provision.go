@@ -0,0 +1,154 @@
+package dhcpserver
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/provision"
+)
+
+const (
+	provisionLogInterval = 2 * time.Second
+	// the max amount of the pending vendor classes which wait for the ack
+	maxPendingVendorClass = 1000
+)
+
+// provisionLogWorker follows the dnsmasq log, and reports the pxe dhcp requests and the tftp downloads
+// to track the provisioning of the hosts
+func (s *dhcpServer) provisionLogWorker() {
+	if s.provisionEvents == nil {
+		return
+	}
+
+	ticker := time.NewTicker(provisionLogInterval)
+	defer ticker.Stop()
+
+	// skip the history log
+	var offset int64
+	if info, err := os.Stat(s.logPath); err == nil {
+		offset = info.Size()
+	}
+	vendorByTransaction := make(map[string]string)
+
+	for {
+		select {
+		case <-s.stopCh:
+			s.log.Info("the provision log worker of subnet is exiting")
+			return
+		case <-ticker.C:
+			content, newOffset, err := readLogFrom(s.logPath, offset)
+			if err != nil {
+				s.log.Debugf("Failed to read dnsmasq log: %v", err)
+				continue
+			}
+			offset = newOffset
+			for _, event := range parseProvisionLog(content, s.config.StoragePathTftp, vendorByTransaction) {
+				if !provision.SendEvent(s.provisionEvents, event) {
+					s.log.Debugf("the provisioning event is dropped: %+v", event)
+				}
+			}
+		}
+	}
+}
+
+// readLogFrom returns the complete lines of the log after the offset, and the new offset.
+// The log is read from the beginning when it has been truncated
+func readLogFrom(logPath string, offset int64) (string, int64, error) {
+	f, err := os.Open(logPath)
+	if err != nil {
+		return "", offset, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", offset, err
+	}
+	if info.Size() < offset {
+		offset = 0
+	}
+	if info.Size() == offset {
+		return "", offset, nil
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return "", offset, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", offset, err
+	}
+
+	// leave the incomplete line for the next read
+	end := strings.LastIndex(string(data), "\n")
+	if end < 0 {
+		return "", offset, nil
+	}
+	return string(data[:end+1]), offset + int64(end+1), nil
+}
+
+// parseProvisionLog parses the provisioning events from the dnsmasq log, the vendor classes which wait
+// for the ack are kept in vendorByTransaction between the calls
+// Example:
+// Jan  1 00:00:00 dnsmasq-dhcp[10]: 1234567 vendor class: PXEClient:Arch:00007:UNDI:003016
+// Jan  1 00:00:00 dnsmasq-dhcp[10]: 1234567 DHCPACK(eth0) 192.168.1.10 00:11:22:33:44:55 host1
+// Jan  1 00:00:01 dnsmasq-tftp[10]: sent /var/lib/topohub/tftp/boot/grub/x86_64-efi/core.efi to 192.168.1.10
+func parseProvisionLog(content, tftpDir string, vendorByTransaction map[string]string) []provision.Event {
+	result := []provision.Event{}
+
+	for _, line := range strings.Split(content, "\n") {
+		idx := strings.Index(line, "]: ")
+		if idx < 0 {
+			continue
+		}
+		fields := strings.Fields(line[idx+3:])
+		if len(fields) < 2 {
+			continue
+		}
+
+		if fields[0] == "sent" && len(fields) >= 4 && fields[2] == "to" {
+			file := fields[1]
+			if rel, err := filepath.Rel(tftpDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+				file = rel
+			}
+			result = append(result, provision.Event{
+				Phase:   topohubv1beta1.ProvisionPhaseBootFileFetched,
+				IpAddr:  fields[3],
+				Message: fmt.Sprintf("the boot file %s is fetched by tftp", file),
+			})
+			continue
+		}
+
+		transactionID := fields[0]
+		if vc, found := strings.CutPrefix(strings.Join(fields[1:], " "), "vendor class: "); found {
+			if len(vendorByTransaction) >= maxPendingVendorClass {
+				clear(vendorByTransaction)
+			}
+			vendorByTransaction[transactionID] = vc
+			continue
+		}
+		if strings.HasPrefix(fields[1], "DHCPACK(") && len(fields) >= 4 {
+			vc, ok := vendorByTransaction[transactionID]
+			if !ok {
+				continue
+			}
+			delete(vendorByTransaction, transactionID)
+			// only the requests of the pxe firmware indicate the host is booting from the network
+			if !strings.HasPrefix(vc, "PXEClient") && !strings.HasPrefix(vc, "HTTPClient") {
+				continue
+			}
+			result = append(result, provision.Event{
+				Phase:   topohubv1beta1.ProvisionPhaseDhcpSeen,
+				MacAddr: strings.ToLower(fields[3]),
+				IpAddr:  fields[2],
+				Message: fmt.Sprintf("the ip %s is assigned to the pxe client with vendor class %s", fields[2], vc),
+			})
+		}
+	}
+
+	return result
+}
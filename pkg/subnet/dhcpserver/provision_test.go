@@ -0,0 +1,35 @@
+package dhcpserver
+
+import (
+	"testing"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestParseProvisionLog tests the parsing of the pxe dhcp requests and the tftp downloads
+func TestParseProvisionLog(t *testing.T) {
+	pending := make(map[string]string)
+	first := `Jan  1 00:00:00 dnsmasq-dhcp[10]: 1234567 vendor class: PXEClient:Arch:00007:UNDI:003016
+Jan  1 00:00:00 dnsmasq-dhcp[10]: 7654321 vendor class: MSFT 5.0
+Jan  1 00:00:00 dnsmasq-dhcp[10]: 7654321 DHCPACK(eth0) 192.168.1.11 00:11:22:33:44:66 host2
+`
+	if events := parseProvisionLog(first, "/var/lib/topohub/tftp", pending); len(events) != 0 {
+		t.Errorf("expected no event for the non-pxe client: %+v", events)
+	}
+
+	// the ack is in the next read of the log
+	second := `Jan  1 00:00:00 dnsmasq-dhcp[10]: 1234567 DHCPACK(eth0) 192.168.1.10 00:11:22:33:44:AA host1
+Jan  1 00:00:01 dnsmasq-tftp[10]: sent /var/lib/topohub/tftp/boot/grub/x86_64-efi/core.efi to 192.168.1.10
+`
+	events := parseProvisionLog(second, "/var/lib/topohub/tftp", pending)
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+	if events[0].Phase != topohubv1beta1.ProvisionPhaseDhcpSeen || events[0].MacAddr != "00:11:22:33:44:aa" || events[0].IpAddr != "192.168.1.10" {
+		t.Errorf("unexpected dhcp event: %+v", events[0])
+	}
+	if events[1].Phase != topohubv1beta1.ProvisionPhaseBootFileFetched || events[1].IpAddr != "192.168.1.10" ||
+		events[1].Message != "the boot file boot/grub/x86_64-efi/core.efi is fetched by tftp" {
+		t.Errorf("unexpected tftp event: %+v", events[1])
+	}
+}
@@ -0,0 +1,144 @@
+package dhcpserver
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+const (
+	defaultBootFileBios     = "boot/grub/i386-pc/core.0"
+	defaultBootFileEfiX64   = "boot/grub/x86_64-efi/core.efi"
+	defaultBootFileEfiArm64 = "boot/grub/arm64-efi/core.efi"
+
+	// the http server serves the boot directory of the tftp directory with the same path
+	httpBootDirPrefix = "boot/"
+)
+
+// the names of the client architectures of the dhcp option 93, defined by RFC 4578 and IANA
+var pxeClientArchNames = map[int32]string{
+	0:  "x86 BIOS",
+	6:  "x86 UEFI",
+	7:  "x64 UEFI",
+	9:  "x64 UEFI",
+	10: "ARM32 UEFI",
+	11: "ARM64 UEFI",
+	15: "x86 UEFI HTTP",
+	16: "x64 UEFI HTTP",
+	19: "ARM64 UEFI HTTP",
+}
+
+// pxeBootFiles returns the boot files of the subnet, with the default values for the empty ones
+func pxeBootFiles(subnet *topohubv1beta1.Subnet) topohubv1beta1.PxeBootFilesSpec {
+	files := topohubv1beta1.PxeBootFilesSpec{}
+	if subnet.Spec.Feature != nil && subnet.Spec.Feature.PxeBootFiles != nil {
+		files = *subnet.Spec.Feature.PxeBootFiles
+	}
+	if files.Bios == "" {
+		files.Bios = defaultBootFileBios
+	}
+	if files.EfiX64 == "" {
+		files.EfiX64 = defaultBootFileEfiX64
+	}
+	if files.EfiArm64 == "" {
+		files.EfiArm64 = defaultBootFileEfiArm64
+	}
+	if files.HttpBootX64 == "" {
+		files.HttpBootX64 = defaultBootFileEfiX64
+	}
+	if files.HttpBootArm64 == "" {
+		files.HttpBootArm64 = defaultBootFileEfiArm64
+	}
+	return files
+}
+
+// bootFileForArch returns the boot file of the client architecture, and whether it is loaded by http
+func bootFileForArch(files topohubv1beta1.PxeBootFilesSpec, arch int32) (string, bool) {
+	switch arch {
+	case 0:
+		return files.Bios, false
+	case 7, 9:
+		return files.EfiX64, false
+	case 11:
+		return files.EfiArm64, false
+	case 16:
+		return files.HttpBootX64, true
+	case 19:
+		return files.HttpBootArm64, true
+	}
+	return "", false
+}
+
+// parsePxeClientArch parses the client architecture from the vendor class of the pxe or http boot client
+// Example:
+//   - Input: "PXEClient:Arch:00007:UNDI:003016"
+//   - Returns: 7, true
+func parsePxeClientArch(vendorClass string) (int32, bool) {
+	fields := strings.Split(vendorClass, ":")
+	if len(fields) < 3 || (fields[0] != "PXEClient" && fields[0] != "HTTPClient") || fields[1] != "Arch" {
+		return 0, false
+	}
+	arch, err := strconv.ParseInt(fields[2], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(arch), true
+}
+
+// recordPxeClientArchs records the client architectures from the vendor classes of the clients
+func (s *dhcpServer) recordPxeClientArchs(vendorClasses map[string]string) {
+	s.lockData.Lock()
+	defer s.lockData.Unlock()
+	for mac, vendorClass := range vendorClasses {
+		if arch, ok := parsePxeClientArch(vendorClass); ok {
+			s.pxeClientArchs[mac] = arch
+		}
+	}
+}
+
+// pxeClientArchList returns the report of the client architectures, sorted by the architecture
+func (s *dhcpServer) pxeClientArchList() []topohubv1beta1.PxeClientArch {
+	amounts := make(map[int32]int32)
+	for _, arch := range s.pxeClientArchs {
+		amounts[arch]++
+	}
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	files := pxeBootFiles(s.subnet)
+	result := make([]topohubv1beta1.PxeClientArch, 0, len(amounts))
+	for arch, amount := range amounts {
+		item := topohubv1beta1.PxeClientArch{
+			Arch:         arch,
+			Name:         pxeClientArchNames[arch],
+			ClientAmount: amount,
+		}
+		if item.Name == "" {
+			item.Name = "unknown"
+		}
+		if file, isHttp := bootFileForArch(files, arch); file != "" {
+			item.BootFile = file
+			item.BootFileFound = s.bootFileExists(file, isHttp)
+		}
+		result = append(result, item)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Arch < result[j].Arch
+	})
+	return result
+}
+
+// bootFileExists checks whether the boot file exists in the tftp or http directory
+func (s *dhcpServer) bootFileExists(file string, isHttp bool) bool {
+	path := filepath.Join(s.config.StoragePathTftp, file)
+	if isHttp && !strings.HasPrefix(file, httpBootDirPrefix) {
+		path = filepath.Join(s.config.StoragePathHttp, file)
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,111 @@
+package dhcpserver
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/metrics"
+	"github.com/infrastructure-io/topohub/pkg/provision"
+	"github.com/infrastructure-io/topohub/pkg/tftp"
+)
+
+const (
+	tftpPort             = "69"
+	defaultTftpBlockSize = 1468
+	defaultTftpWindow    = 8
+)
+
+// builtinTftpEnabled returns the configuration of the built-in tftp server, which only works with the pxe
+func builtinTftpEnabled(subnet *topohubv1beta1.Subnet) (*topohubv1beta1.BuiltinTftpSpec, bool) {
+	if subnet.Spec.Feature == nil || !subnet.Spec.Feature.EnablePxe || subnet.Spec.Feature.BuiltinTftp == nil || !subnet.Spec.Feature.BuiltinTftp.Enabled {
+		return nil, false
+	}
+	return subnet.Spec.Feature.BuiltinTftp, true
+}
+
+// startTftp starts the built-in tftp server on the interface ip of the subnet
+func (s *dhcpServer) startTftp() error {
+	s.lockData.RLock()
+	spec, enabled := builtinTftpEnabled(s.subnet)
+	selfIP := strings.Split(s.subnet.Spec.Interface.IPv4, "/")[0]
+	subnetName := s.subnet.Name
+	s.lockData.RUnlock()
+	// the server is restarted with the dnsmasq
+	s.stopTftp()
+	if !enabled {
+		return nil
+	}
+
+	blockSize, windowSize := defaultTftpBlockSize, defaultTftpWindow
+	if spec.MaxBlockSize > 0 {
+		blockSize = int(spec.MaxBlockSize)
+	}
+	if spec.MaxWindowSize > 0 {
+		windowSize = int(spec.MaxWindowSize)
+	}
+	server := tftp.NewServer(tftp.Config{
+		Address:       net.JoinHostPort(selfIP, tftpPort),
+		Root:          s.config.StoragePathTftp,
+		MaxBlockSize:  blockSize,
+		MaxWindowSize: windowSize,
+		Provider:      s.tftpFiles,
+		MacByIP:       s.macByIP,
+		OnTransfer:    func(t tftp.Transfer) { s.recordTftpTransfer(subnetName, t) },
+		Log:           s.log.Named("tftp"),
+	})
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("failed to start the built-in tftp server: %v", err)
+	}
+	s.tftpServer = server
+	return nil
+}
+
+// stopTftp stops the built-in tftp server and waits for the running transfers
+func (s *dhcpServer) stopTftp() {
+	if s.tftpServer == nil {
+		return
+	}
+	s.tftpServer.Stop()
+	s.tftpServer = nil
+	s.log.Infof("the built-in tftp server is stopped")
+}
+
+// macByIP returns the mac of the client from the leases and the binding ips
+func (s *dhcpServer) macByIP(ip string) string {
+	s.lockData.RLock()
+	defer s.lockData.RUnlock()
+	if client, ok := s.currentManualBindingClients[ip]; ok {
+		return strings.ToLower(client.MAC)
+	}
+	if client, ok := s.currentLeaseClients[ip]; ok {
+		return strings.ToLower(client.MAC)
+	}
+	return ""
+}
+
+// recordTftpTransfer logs and records the metrics of the transfer, and reports the boot file download
+// to track the provisioning
+func (s *dhcpServer) recordTftpTransfer(subnetName string, t tftp.Transfer) {
+	metrics.AddTftpTransfer(subnetName, t.File, t.Bytes, t.Err == nil)
+	if t.Err != nil {
+		s.log.Warnf("tftp: failed to send %s to %s (mac %s) after %d bytes: %v", t.File, t.ClientIP, t.MacAddr, t.Bytes, t.Err)
+		return
+	}
+	s.log.Infof("tftp: sent %s to %s (mac %s), %d bytes in %v, blksize %d, windowsize %d, in memory %v",
+		t.File, t.ClientIP, t.MacAddr, t.Bytes, t.Duration, t.BlockSize, t.WindowSize, t.InMemory)
+
+	if s.provisionEvents == nil {
+		return
+	}
+	event := provision.Event{
+		Phase:   topohubv1beta1.ProvisionPhaseBootFileFetched,
+		IpAddr:  t.ClientIP,
+		MacAddr: t.MacAddr,
+		Message: fmt.Sprintf("the boot file %s is fetched by tftp", t.File),
+	}
+	if !provision.SendEvent(s.provisionEvents, event) {
+		s.log.Debugf("the provisioning event is dropped: %+v", event)
+	}
+}
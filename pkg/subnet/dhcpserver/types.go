@@ -1,19 +1,26 @@
 // Package dhcpserver defines the common types used by the DHCP server
 package dhcpserver
 
-import "time"
+import (
+	"time"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
 
 // DhcpClientInfo represents information about a DHCP client
 type DhcpClientInfo struct {
 	MAC                          string    `json:"mac"`
 	IP                           string    `json:"ip"`
 	Hostname                     string    `json:"hostname"`
+	ClientID                     string    `json:"clientId,omitempty"`
 	Active                       bool      `json:"active"`
 	DhcpExpireTime               time.Time `json:"dhcpExpireTime"` // When the DHCP lease expires
 	Subnet                       string    `json:"subnet"`
 	SubnetName                   string    `json:"subnetName"`
 	ClusterName                  string    `json:"clusterName,omitempty"`
 	EnableBindIpForRedfishstatus *bool     `json:"enableBindIpForRedfishstatus,omitempty"`
+	// Naming of the redfishstatus created for the client
+	Naming *topohubv1beta1.RedfishStatusNamingSpec `json:"naming,omitempty"`
 }
 
 // DhcpServerConfig represents the configuration for the DHCP server
@@ -10,8 +10,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/infrastructure-io/topohub/pkg/lock"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -19,7 +22,9 @@ import (
 	"github.com/infrastructure-io/topohub/pkg/config"
 	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
 	"github.com/infrastructure-io/topohub/pkg/log"
+	"github.com/infrastructure-io/topohub/pkg/provision"
 	"github.com/infrastructure-io/topohub/pkg/subnet/dhcpserver"
+	"github.com/infrastructure-io/topohub/pkg/tftp"
 )
 
 type SubnetManager interface {
@@ -34,6 +39,7 @@ type subnetManager struct {
 	kubeClient kubernetes.Interface
 	config     *config.AgentConfig
 	cache      *SubnetCache
+	recorder   record.EventRecorder
 
 	log *zap.SugaredLogger
 
@@ -45,12 +51,17 @@ type subnetManager struct {
 	addedBindingIp   chan bindingipdata.BindingIPInfo
 	deletedBindingIp chan bindingipdata.BindingIPInfo
 
+	// 本模块往其中添加数据，关于 PXE 装机的 dhcp 和 tftp 事件。由 provision 模块来消费使用
+	provisionEvents chan provision.Event
+	// the files generated in memory for the built-in tftp servers of the subnets
+	tftpFiles *tftp.MemoryFiles
+
 	// lock
 	dataLock       lock.RWMutex
 	dhcpServerList map[string]dhcpserver.DhcpServer
 }
 
-func NewSubnetReconciler(config config.AgentConfig, kubeClient kubernetes.Interface) SubnetManager {
+func NewSubnetReconciler(config config.AgentConfig, kubeClient kubernetes.Interface, provisionEvents chan provision.Event, tftpFiles *tftp.MemoryFiles) SubnetManager {
 	return &subnetManager{
 		config:                            &config,
 		kubeClient:                        kubeClient,
@@ -60,6 +71,8 @@ func NewSubnetReconciler(config config.AgentConfig, kubeClient kubernetes.Interf
 		deletedDhcpClientForRedfishStatus: make(chan dhcpserver.DhcpClientInfo, 1000),
 		addedBindingIp:                    make(chan bindingipdata.BindingIPInfo, 1000),
 		deletedBindingIp:                  make(chan bindingipdata.BindingIPInfo, 1000),
+		provisionEvents:                   provisionEvents,
+		tftpFiles:                         tftpFiles,
 		dhcpServerList:                    make(map[string]dhcpserver.DhcpServer),
 		log:                               log.Logger.Named("subnetManager"),
 	}
@@ -129,7 +142,7 @@ func (s *subnetManager) Reconcile(ctx context.Context, req reconcile.Request) (r
 
 		// todo: start the dhcp server on the subnet
 		if !exists {
-			t := dhcpserver.NewDhcpServer(s.config, subnet, s.client, s.addedDhcpClientForRedfishStatus, s.deletedDhcpClientForRedfishStatus)
+			t := dhcpserver.NewDhcpServer(s.config, subnet, s.client, s.recorder, s.addedDhcpClientForRedfishStatus, s.deletedDhcpClientForRedfishStatus, s.provisionEvents, s.tftpFiles)
 			err := t.Run()
 			if err != nil {
 				msg := fmt.Sprintf("Failed to start DHCP server for subnet %s: %v", subnet.Name, err)
@@ -175,6 +188,11 @@ func (s *subnetManager) Reconcile(ctx context.Context, req reconcile.Request) (r
 func (s *subnetManager) SetupWithManager(mgr ctrl.Manager) error {
 	s.client = mgr.GetClient()
 
+	// Create event recorder
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: s.kubeClient.CoreV1().Events("")})
+	s.recorder = eventBroadcaster.NewRecorder(mgr.GetScheme(), corev1.EventSource{Component: "subnet-controller"})
+
 	// start all dhcp server when we are the leader
 	go func() {
 		<-mgr.Elected()
@@ -197,7 +215,7 @@ func (s *subnetManager) SetupWithManager(mgr ctrl.Manager) error {
 			// 检查是否已经存在对应的 DHCP 服务器
 			if _, exists := s.dhcpServerList[subnet.Name]; !exists {
 				// 创建新的 DHCP 服务器实例
-				dhcpServer := dhcpserver.NewDhcpServer(s.config, &subnet, s.client, s.addedDhcpClientForRedfishStatus, s.deletedDhcpClientForRedfishStatus)
+				dhcpServer := dhcpserver.NewDhcpServer(s.config, &subnet, s.client, s.recorder, s.addedDhcpClientForRedfishStatus, s.deletedDhcpClientForRedfishStatus, s.provisionEvents, s.tftpFiles)
 
 				// 启动 DHCP 服务器
 				if err := dhcpServer.Run(); err != nil {
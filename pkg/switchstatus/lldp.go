@@ -0,0 +1,227 @@
+// 通过 SSH 采集交换机的 LLDP 邻居
+
+package switchstatus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+const (
+	// the switches running lldpd, like SONiC and Cumulus
+	lldpctlNeighborsCmd = "lldpctl -f json0"
+	lldpctlChassisCmd   = "lldpcli show chassis -f json0"
+
+	// the Arista EOS switches
+	eosNeighborsCmd = "show lldp neighbors detail | json"
+	eosLocalCmd     = "show lldp local-info | json"
+)
+
+// lldpInfo is the lldp information collected from a switch
+type lldpInfo struct {
+	ChassisID  string
+	SystemName string
+	Neighbors  []topohubv1beta1.LldpNeighbor
+}
+
+// commandRunner runs a command on the switch
+type commandRunner func(cmd string) (string, error)
+
+// collectLldp collects the lldp information by lldpctl, and falls back to the EOS cli
+func collectLldp(run commandRunner) (*lldpInfo, error) {
+	if out, err := run(lldpctlNeighborsCmd); err == nil {
+		neighbors, err := parseLldpctlNeighbors(out)
+		if err != nil {
+			return nil, err
+		}
+		info := &lldpInfo{Neighbors: neighbors}
+		if out, err := run(lldpctlChassisCmd); err == nil {
+			info.ChassisID, info.SystemName, _ = parseLldpctlChassis(out)
+		}
+		return info, nil
+	} else if out, eosErr := run(eosNeighborsCmd); eosErr == nil {
+		neighbors, err := parseEosNeighbors(out)
+		if err != nil {
+			return nil, err
+		}
+		info := &lldpInfo{Neighbors: neighbors}
+		if out, err := run(eosLocalCmd); err == nil {
+			info.ChassisID, info.SystemName, _ = parseEosLocal(out)
+		}
+		return info, nil
+	} else {
+		return nil, fmt.Errorf("failed to get lldp neighbors by lldpctl: %v, or by eos cli: %v", err, eosErr)
+	}
+}
+
+// normalizeID returns the mac address in the lowercase colon format, other ids are returned as they are
+// Example:
+//   - Input: "5254.0012.34AB"
+//   - Returns: "52:54:00:12:34:ab"
+//   - Input: "Ethernet1"
+//   - Returns: "Ethernet1"
+func normalizeID(id string) string {
+	id = strings.Trim(strings.TrimSpace(id), `"`)
+	if hw, err := net.ParseMAC(id); err == nil && len(hw) == 6 {
+		return hw.String()
+	}
+	return id
+}
+
+func sortNeighbors(neighbors []topohubv1beta1.LldpNeighbor) {
+	sort.Slice(neighbors, func(i, j int) bool {
+		if neighbors[i].LocalPort != neighbors[j].LocalPort {
+			return neighbors[i].LocalPort < neighbors[j].LocalPort
+		}
+		return neighbors[i].ChassisID < neighbors[j].ChassisID
+	})
+}
+
+// ------------------------------  lldpctl
+
+type lldpctlValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type lldpctlChassis struct {
+	ID     []lldpctlValue `json:"id"`
+	Name   []lldpctlValue `json:"name"`
+	MgmtIP []lldpctlValue `json:"mgmt-ip"`
+}
+
+type lldpctlPort struct {
+	ID    []lldpctlValue `json:"id"`
+	Descr []lldpctlValue `json:"descr"`
+}
+
+type lldpctlInterface struct {
+	Name    string           `json:"name"`
+	Chassis []lldpctlChassis `json:"chassis"`
+	Port    []lldpctlPort    `json:"port"`
+}
+
+// the json0 format of lldpctl always uses arrays, which is stable for the different versions
+type lldpctlNeighbors struct {
+	Lldp []struct {
+		Interface []lldpctlInterface `json:"interface"`
+	} `json:"lldp"`
+}
+
+type lldpctlLocal struct {
+	LocalChassis []struct {
+		Chassis []lldpctlChassis `json:"chassis"`
+	} `json:"local-chassis"`
+}
+
+func firstValue(values []lldpctlValue) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0].Value
+}
+
+// parseLldpctlNeighbors parses the output of "lldpctl -f json0"
+func parseLldpctlNeighbors(out string) ([]topohubv1beta1.LldpNeighbor, error) {
+	data := &lldpctlNeighbors{}
+	if err := json.Unmarshal([]byte(out), data); err != nil {
+		return nil, fmt.Errorf("failed to parse the output of lldpctl: %v", err)
+	}
+	var neighbors []topohubv1beta1.LldpNeighbor
+	for _, lldp := range data.Lldp {
+		for _, item := range lldp.Interface {
+			neighbor := topohubv1beta1.LldpNeighbor{LocalPort: item.Name}
+			if len(item.Chassis) > 0 {
+				neighbor.ChassisID = normalizeID(firstValue(item.Chassis[0].ID))
+				neighbor.SystemName = firstValue(item.Chassis[0].Name)
+				neighbor.MgmtAddr = firstValue(item.Chassis[0].MgmtIP)
+			}
+			if len(item.Port) > 0 {
+				neighbor.PortID = normalizeID(firstValue(item.Port[0].ID))
+				neighbor.PortDescription = firstValue(item.Port[0].Descr)
+			}
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	sortNeighbors(neighbors)
+	return neighbors, nil
+}
+
+// parseLldpctlChassis parses the output of "lldpcli show chassis -f json0"
+func parseLldpctlChassis(out string) (string, string, error) {
+	data := &lldpctlLocal{}
+	if err := json.Unmarshal([]byte(out), data); err != nil {
+		return "", "", fmt.Errorf("failed to parse the local chassis of lldpctl: %v", err)
+	}
+	for _, local := range data.LocalChassis {
+		for _, chassis := range local.Chassis {
+			return normalizeID(firstValue(chassis.ID)), firstValue(chassis.Name), nil
+		}
+	}
+	return "", "", nil
+}
+
+// ------------------------------  Arista EOS
+
+type eosNeighborInfo struct {
+	ChassisID           string `json:"chassisId"`
+	SystemName          string `json:"systemName"`
+	ManagementAddresses []struct {
+		Address string `json:"address"`
+	} `json:"managementAddresses"`
+	NeighborInterfaceInfo struct {
+		InterfaceID          string `json:"interfaceId"`
+		InterfaceDescription string `json:"interfaceDescription"`
+	} `json:"neighborInterfaceInfo"`
+}
+
+type eosNeighbors struct {
+	LldpNeighbors map[string]struct {
+		LldpNeighborInfo []eosNeighborInfo `json:"lldpNeighborInfo"`
+	} `json:"lldpNeighbors"`
+}
+
+type eosLocal struct {
+	ChassisID  string `json:"chassisId"`
+	SystemName string `json:"systemName"`
+}
+
+// parseEosNeighbors parses the output of "show lldp neighbors detail | json"
+func parseEosNeighbors(out string) ([]topohubv1beta1.LldpNeighbor, error) {
+	data := &eosNeighbors{}
+	if err := json.Unmarshal([]byte(out), data); err != nil {
+		return nil, fmt.Errorf("failed to parse the lldp neighbors of eos: %v", err)
+	}
+	var neighbors []topohubv1beta1.LldpNeighbor
+	for port, item := range data.LldpNeighbors {
+		for _, info := range item.LldpNeighborInfo {
+			neighbor := topohubv1beta1.LldpNeighbor{
+				LocalPort:       port,
+				ChassisID:       normalizeID(info.ChassisID),
+				PortID:          normalizeID(info.NeighborInterfaceInfo.InterfaceID),
+				PortDescription: info.NeighborInterfaceInfo.InterfaceDescription,
+				SystemName:      info.SystemName,
+			}
+			if len(info.ManagementAddresses) > 0 {
+				neighbor.MgmtAddr = info.ManagementAddresses[0].Address
+			}
+			neighbors = append(neighbors, neighbor)
+		}
+	}
+	sortNeighbors(neighbors)
+	return neighbors, nil
+}
+
+// parseEosLocal parses the output of "show lldp local-info | json"
+func parseEosLocal(out string) (string, string, error) {
+	data := &eosLocal{}
+	if err := json.Unmarshal([]byte(out), data); err != nil {
+		return "", "", fmt.Errorf("failed to parse the lldp local info of eos: %v", err)
+	}
+	return normalizeID(data.ChassisID), data.SystemName, nil
+}
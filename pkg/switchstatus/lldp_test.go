@@ -0,0 +1,122 @@
+package switchstatus
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+const lldpctlOutput = `{"lldp": [{"interface": [
+  {"name": "Ethernet4", "via": "LLDP",
+   "chassis": [{"id": [{"type": "mac", "value": "52:54:00:00:00:02"}], "name": [{"value": "spine1"}], "mgmt-ip": [{"value": "10.0.0.2"}]}],
+   "port": [{"id": [{"type": "ifname", "value": "Ethernet0"}], "descr": [{"value": "to-leaf1"}]}]},
+  {"name": "Ethernet0", "via": "LLDP",
+   "chassis": [{"id": [{"type": "mac", "value": "0c:42:a1:00:00:09"}], "name": [{"value": "node1"}]}],
+   "port": [{"id": [{"type": "mac", "value": "0C:42:A1:00:00:10"}], "descr": [{"value": "ens1f0"}]}]}
+]}]}`
+
+const eosOutput = `{"lldpNeighbors": {"Ethernet1": {"lldpNeighborInfo": [{
+  "chassisIdType": "macAddress", "chassisId": "0c42.a100.0009", "systemName": "node1",
+  "managementAddresses": [{"address": "10.0.0.9"}],
+  "neighborInterfaceInfo": {"interfaceIdType": "macAddress", "interfaceId": "\"0c42.a100.0010\"", "interfaceDescription": "ens1f0"}}]}}}`
+
+// TestCollectLldp tests the lldp neighbors are parsed from lldpctl, and from the eos cli when lldpctl is not found
+func TestCollectLldp(t *testing.T) {
+	info, err := collectLldp(func(cmd string) (string, error) {
+		switch cmd {
+		case lldpctlNeighborsCmd:
+			return lldpctlOutput, nil
+		case lldpctlChassisCmd:
+			return `{"local-chassis": [{"chassis": [{"id": [{"type": "mac", "value": "52:54:00:00:00:01"}], "name": [{"value": "leaf1"}]}]}]}`, nil
+		}
+		return "", fmt.Errorf("unknown command")
+	})
+	if err != nil {
+		t.Fatalf("failed to collect by lldpctl: %v", err)
+	}
+	if info.ChassisID != "52:54:00:00:00:01" || info.SystemName != "leaf1" || len(info.Neighbors) != 2 {
+		t.Fatalf("unexpected lldpctl info: %+v", info)
+	}
+	if n := info.Neighbors[0]; n.LocalPort != "Ethernet0" || n.PortID != "0c:42:a1:00:00:10" || n.SystemName != "node1" {
+		t.Errorf("unexpected neighbor: %+v", n)
+	}
+
+	info, err = collectLldp(func(cmd string) (string, error) {
+		switch cmd {
+		case eosNeighborsCmd:
+			return eosOutput, nil
+		case eosLocalCmd:
+			return `{"chassisIdType": "macAddress", "chassisId": "5254.0000.0003", "systemName": "leaf2"}`, nil
+		}
+		return "", fmt.Errorf("command not found")
+	})
+	if err != nil {
+		t.Fatalf("failed to collect by eos cli: %v", err)
+	}
+	if info.ChassisID != "52:54:00:00:00:03" || len(info.Neighbors) != 1 || info.Neighbors[0].PortID != "0c:42:a1:00:00:10" || info.Neighbors[0].MgmtAddr != "10.0.0.9" {
+		t.Errorf("unexpected eos info: %+v", info)
+	}
+}
+
+// TestBuildTopology tests the neighbors are correlated with the hosts and switches, and exported as a graph
+func TestBuildTopology(t *testing.T) {
+	neighbors, err := parseLldpctlNeighbors(lldpctlOutput)
+	if err != nil {
+		t.Fatalf("failed to parse: %v", err)
+	}
+	redfishStatuses := []topohubv1beta1.RedfishStatus{{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc-node1"},
+		Status: topohubv1beta1.RedfishStatusStatus{Info: map[string]string{
+			"PCIeDevices[0].Functions[0].EthernetInterfaces[0].MACAddress": "0C:42:A1:00:00:10",
+		}},
+	}}
+	sshStatuses := []topohubv1beta1.SSHStatus{{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status:     topohubv1beta1.SSHStatusStatus{Info: map[string]string{"Hostname": "node1", "NetworkMACs": "ens1f0=0c:42:a1:00:00:10"}},
+	}}
+	switches := []topohubv1beta1.SwitchStatus{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "leaf1"},
+			Status:     topohubv1beta1.SwitchStatusStatus{ChassisID: "52:54:00:00:00:01", SystemName: "leaf1", Neighbors: neighbors},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "spine1"},
+			Status: topohubv1beta1.SwitchStatusStatus{ChassisID: "52:54:00:00:00:02", SystemName: "spine1", Neighbors: []topohubv1beta1.LldpNeighbor{
+				{LocalPort: "Ethernet0", ChassisID: "52:54:00:00:00:01", PortID: "Ethernet4", SystemName: "leaf1"},
+			}},
+		},
+	}
+
+	index := newPeerIndex(redfishStatuses, sshStatuses, switches)
+	for i := range switches {
+		for j := range switches[i].Status.Neighbors {
+			index.correlate(&switches[i].Status.Neighbors[j])
+		}
+	}
+
+	host := switches[0].Status.Neighbors[0]
+	if host.PeerKind != topohubv1beta1.PeerKindRedfishStatus || host.PeerName != "bmc-node1" || host.PeerInterface != "0c:42:a1:00:00:10" {
+		t.Errorf("the host is not correlated to the redfishstatus: %+v", host)
+	}
+	spine := switches[0].Status.Neighbors[1]
+	if spine.PeerKind != topohubv1beta1.PeerKindSwitchStatus || spine.PeerName != "spine1" || spine.PeerInterface != "Ethernet0" {
+		t.Errorf("the spine is not correlated: %+v", spine)
+	}
+
+	topology := BuildTopology(switches)
+	if len(topology.Nodes) != 3 {
+		t.Errorf("expected 3 nodes, got %+v", topology.Nodes)
+	}
+	// the link between leaf1 and spine1 is reported by both switches
+	if len(topology.Links) != 2 {
+		t.Errorf("expected 2 links, got %+v", topology.Links)
+	}
+	dot := topology.DOT()
+	if !strings.Contains(dot, `"SwitchStatus/leaf1" -- "RedfishStatus/bmc-node1" [taillabel="Ethernet0", headlabel="0c:42:a1:00:00:10"];`) {
+		t.Errorf("unexpected dot: %s", dot)
+	}
+}
@@ -0,0 +1,241 @@
+package switchstatus
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+	sshstatusdata "github.com/infrastructure-io/topohub/pkg/sshstatus/data"
+	ssh "github.com/infrastructure-io/topohub/pkg/sshstatus/ssh"
+)
+
+// SwitchStatusController collects the lldp neighbors of the switches and correlates them with the hosts
+type SwitchStatusController interface {
+	Stop()
+	SetupWithManager(ctrl.Manager) error
+}
+
+type switchStatusController struct {
+	client     client.Client
+	kubeClient kubernetes.Interface
+	config     *config.AgentConfig
+	stopCh     chan struct{}
+	log        *zap.SugaredLogger
+
+	// the basic info of the switches which have been collected, the reconcile only collects the new or changed switches
+	lock      sync.Mutex
+	collected map[string]topohubv1beta1.SSHBasicInfo
+}
+
+// NewSwitchStatusController creates a new switch status controller
+func NewSwitchStatusController(kubeClient kubernetes.Interface, config *config.AgentConfig, mgr ctrl.Manager) SwitchStatusController {
+	return &switchStatusController{
+		client:     mgr.GetClient(),
+		kubeClient: kubeClient,
+		config:     config,
+		stopCh:     make(chan struct{}),
+		log:        log.Logger.Named("switchstatus"),
+		collected:  make(map[string]topohubv1beta1.SSHBasicInfo),
+	}
+}
+
+// Stop shuts down the switch status controller
+func (c *switchStatusController) Stop() {
+	close(c.stopCh)
+	c.log.Info("SwitchStatus controller stopped")
+}
+
+// getConnectCon returns the ssh connection info of the switch and its jump host
+func (c *switchStatusController) getConnectCon(basic *topohubv1beta1.SSHBasicInfo) (sshstatusdata.SSHConnectCon, error) {
+	con, err := c.getSecretConnectCon(basic)
+	if err != nil {
+		return con, err
+	}
+	if jumpInfo := sshstatusdata.JumpHostInfo(basic); jumpInfo != nil {
+		jump, err := c.getSecretConnectCon(jumpInfo)
+		if err != nil {
+			return con, fmt.Errorf("failed to get the secret of the jump host: %v", err)
+		}
+		con.JumpHost = &jump
+	}
+	return con, nil
+}
+
+// getSecretConnectCon builds the connection information from the secret of the basic info
+func (c *switchStatusController) getSecretConnectCon(basic *topohubv1beta1.SSHBasicInfo) (sshstatusdata.SSHConnectCon, error) {
+	if basic.SecretName == "" || basic.SecretNamespace == "" {
+		return sshstatusdata.SSHConnectCon{Info: basic}, nil
+	}
+	secret, err := c.kubeClient.CoreV1().Secrets(basic.SecretNamespace).Get(context.TODO(), basic.SecretName, metav1.GetOptions{})
+	if err != nil {
+		return sshstatusdata.SSHConnectCon{Info: basic}, fmt.Errorf("failed to get secret %s/%s: %v", basic.SecretNamespace, basic.SecretName, err)
+	}
+	return sshstatusdata.NewSSHConnectCon(basic, secret.Data), nil
+}
+
+// newPeerIndexFromCluster indexes the hosts and switches in the cluster
+func (c *switchStatusController) newPeerIndexFromCluster(ctx context.Context) (*peerIndex, error) {
+	redfishStatusList := &topohubv1beta1.RedfishStatusList{}
+	if err := c.client.List(ctx, redfishStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list redfishstatus: %v", err)
+	}
+	sshStatusList := &topohubv1beta1.SSHStatusList{}
+	if err := c.client.List(ctx, sshStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list sshstatus: %v", err)
+	}
+	switchStatusList := &topohubv1beta1.SwitchStatusList{}
+	if err := c.client.List(ctx, switchStatusList); err != nil {
+		return nil, fmt.Errorf("failed to list switchstatus: %v", err)
+	}
+	return newPeerIndex(redfishStatusList.Items, sshStatusList.Items, switchStatusList.Items), nil
+}
+
+// UpdateSwitchStatus collects the lldp neighbors of the switch and updates the status
+func (c *switchStatusController) UpdateSwitchStatus(ctx context.Context, name string) error {
+	existing := &topohubv1beta1.SwitchStatus{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: name}, existing); err != nil {
+		return err
+	}
+	if existing.Status.Basic.IpAddr == "" {
+		return fmt.Errorf("the basic info of SwitchStatus %s is not set", name)
+	}
+	basic := existing.Status.Basic
+	updated := existing.DeepCopy()
+	updated.Status.Healthy = false
+
+	con, err := c.getConnectCon(&basic)
+	if err == nil {
+		var sshClient *ssh.Client
+		sshClient, err = ssh.ConnectionPool.Get(con, c.log)
+		if err == nil {
+			var info *lldpInfo
+			info, err = collectLldp(sshClient.RunCommand)
+			sshClient.Close()
+			if err == nil {
+				updated.Status.Healthy = true
+				updated.Status.ChassisID = info.ChassisID
+				updated.Status.SystemName = info.SystemName
+				updated.Status.Neighbors = info.Neighbors
+				updated.Status.NeighborAmount = int32(len(info.Neighbors))
+			}
+		}
+	}
+	if err != nil {
+		c.log.Warnf("Failed to collect the lldp neighbors of SwitchStatus %s: %v", name, err)
+	}
+
+	index, indexErr := c.newPeerIndexFromCluster(ctx)
+	if indexErr != nil {
+		return indexErr
+	}
+	for i := range updated.Status.Neighbors {
+		index.correlate(&updated.Status.Neighbors[i])
+	}
+
+	c.lock.Lock()
+	c.collected[name] = basic
+	c.lock.Unlock()
+
+	if reflect.DeepEqual(existing.Status, updated.Status) {
+		c.log.Debugf("SwitchStatus %s has no changes, skipping update", name)
+		return nil
+	}
+	updated.Status.LastUpdateTime = time.Now().UTC().Format(time.RFC3339)
+	if err := c.client.Status().Update(ctx, updated); err != nil {
+		return fmt.Errorf("failed to update SwitchStatus %s: %v", name, err)
+	}
+	c.log.Infof("Successfully updated SwitchStatus %s: healthy %v, %d neighbors", name, updated.Status.Healthy, updated.Status.NeighborAmount)
+	return nil
+}
+
+// UpdateSwitchStatusAtInterval periodically collects all switches, so the correlations follow the changes of the hosts
+func (c *switchStatusController) UpdateSwitchStatusAtInterval() {
+	interval := time.Duration(c.config.SwitchStatusUpdateInterval) * time.Second
+	if interval == 0 {
+		interval = 60 * time.Second // Default to 60 seconds
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	c.log.Infof("begin to update all switchStatus at interval of %v seconds", interval/time.Second)
+
+	for {
+		select {
+		case <-c.stopCh:
+			c.log.Info("Stopping UpdateSwitchStatusAtInterval")
+			return
+		case <-ticker.C:
+			list := &topohubv1beta1.SwitchStatusList{}
+			if err := c.client.List(context.Background(), list); err != nil {
+				c.log.Errorf("Failed to list SwitchStatus: %v", err)
+				continue
+			}
+			for _, item := range list.Items {
+				if item.Status.Basic.IpAddr == "" {
+					continue
+				}
+				if err := c.UpdateSwitchStatus(context.Background(), item.Name); err != nil {
+					c.log.Errorf("Failed to update SwitchStatus %s: %v", item.Name, err)
+				}
+			}
+		}
+	}
+}
+
+// 只有 leader 才会执行 Reconcile
+// Reconcile collects the new switches and the switches whose basic info is changed
+func (c *switchStatusController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := c.log.With("switchstatus", req.Name)
+
+	switchStatus := &topohubv1beta1.SwitchStatus{}
+	if err := c.client.Get(ctx, req.NamespacedName, switchStatus); err != nil {
+		if errors.IsNotFound(err) {
+			c.lock.Lock()
+			delete(c.collected, req.Name)
+			c.lock.Unlock()
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if switchStatus.Status.Basic.IpAddr == "" {
+		logger.Debugf("the basic info is not set by the HostEndpoint, skipping")
+		return ctrl.Result{}, nil
+	}
+
+	c.lock.Lock()
+	basic, ok := c.collected[req.Name]
+	c.lock.Unlock()
+	if ok && reflect.DeepEqual(basic, switchStatus.Status.Basic) {
+		return ctrl.Result{}, nil
+	}
+
+	if err := c.UpdateSwitchStatus(ctx, req.Name); err != nil {
+		logger.Errorf("Failed to update SwitchStatus, will retry: %v", err)
+		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the manager
+func (c *switchStatusController) SetupWithManager(mgr ctrl.Manager) error {
+	go func() {
+		<-mgr.Elected()
+		c.log.Info("Elected as leader, begin to start SwitchStatus controller")
+		go c.UpdateSwitchStatusAtInterval()
+	}()
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&topohubv1beta1.SwitchStatus{}).
+		Complete(c)
+}
@@ -0,0 +1,203 @@
+// 关联 LLDP 邻居与主机、交换机，生成拓扑
+
+package switchstatus
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/provision"
+)
+
+// peerRef is the object which a mac address or a name belongs to
+type peerRef struct {
+	kind  string
+	name  string
+	iface string
+}
+
+// the rank of the kinds, the bmc inventory of the hardware is preferred when a mac is found in multiple objects
+var peerRanks = map[string]int{
+	topohubv1beta1.PeerKindRedfishStatus: 0,
+	topohubv1beta1.PeerKindSwitchStatus:  1,
+	topohubv1beta1.PeerKindSSHStatus:     2,
+}
+
+// peerIndex looks up the objects by the mac addresses and the system names
+type peerIndex struct {
+	macs  map[string]peerRef
+	names map[string]peerRef
+}
+
+func (p *peerIndex) add(index map[string]peerRef, key string, ref peerRef) {
+	if key == "" {
+		return
+	}
+	if old, ok := index[key]; ok && peerRanks[old.kind] <= peerRanks[ref.kind] {
+		return
+	}
+	index[key] = ref
+}
+
+// newPeerIndex indexes the nic macs of the RedfishStatus and SSHStatus, and the chassis ids of the SwitchStatus
+func newPeerIndex(redfishStatuses []topohubv1beta1.RedfishStatus, sshStatuses []topohubv1beta1.SSHStatus, switchStatuses []topohubv1beta1.SwitchStatus) *peerIndex {
+	p := &peerIndex{
+		macs:  make(map[string]peerRef),
+		names: make(map[string]peerRef),
+	}
+	for i := range redfishStatuses {
+		item := &redfishStatuses[i]
+		for _, mac := range provision.HostMacAddrs(item) {
+			p.add(p.macs, normalizeID(mac), peerRef{kind: topohubv1beta1.PeerKindRedfishStatus, name: item.Name, iface: normalizeID(mac)})
+		}
+	}
+	for _, item := range sshStatuses {
+		// the format is like eth0=00:11:22:33:44:55,eth1=00:11:22:33:44:56
+		for _, pair := range strings.Split(item.Status.Info["NetworkMACs"], ",") {
+			iface, mac, found := strings.Cut(pair, "=")
+			if !found {
+				continue
+			}
+			p.add(p.macs, normalizeID(mac), peerRef{kind: topohubv1beta1.PeerKindSSHStatus, name: item.Name, iface: iface})
+		}
+		p.add(p.names, item.Status.Info["Hostname"], peerRef{kind: topohubv1beta1.PeerKindSSHStatus, name: item.Name})
+	}
+	for _, item := range switchStatuses {
+		p.add(p.macs, item.Status.ChassisID, peerRef{kind: topohubv1beta1.PeerKindSwitchStatus, name: item.Name})
+		p.add(p.names, item.Status.SystemName, peerRef{kind: topohubv1beta1.PeerKindSwitchStatus, name: item.Name})
+	}
+	return p
+}
+
+// correlate sets the peer of the neighbor by the port id, the chassis id and the system name in order
+func (p *peerIndex) correlate(neighbor *topohubv1beta1.LldpNeighbor) {
+	neighbor.PeerKind = ""
+	neighbor.PeerName = ""
+	neighbor.PeerInterface = ""
+
+	var ref peerRef
+	var ok bool
+	if ref, ok = p.macs[neighbor.PortID]; !ok {
+		if ref, ok = p.macs[neighbor.ChassisID]; !ok {
+			ref, ok = p.names[neighbor.SystemName]
+		}
+	}
+	if !ok {
+		return
+	}
+	neighbor.PeerKind = ref.kind
+	neighbor.PeerName = ref.name
+	neighbor.PeerInterface = ref.iface
+	// the port of the peer switch, or the nic which is matched by the system name
+	if neighbor.PeerInterface == "" {
+		neighbor.PeerInterface = neighbor.PortID
+	}
+}
+
+// Topology is the graph of the switches and the hosts connected to them
+type Topology struct {
+	Nodes []TopologyNode `json:"nodes"`
+	Links []TopologyLink `json:"links"`
+}
+
+type TopologyNode struct {
+	// the id is like SwitchStatus/leaf1, or lldp/<chassis id> for the neighbors which are not correlated
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+type TopologyLink struct {
+	Source     string `json:"source"`
+	SourcePort string `json:"sourcePort"`
+	Target     string `json:"target"`
+	TargetPort string `json:"targetPort,omitempty"`
+}
+
+func nodeID(kind, name string) string {
+	return kind + "/" + name
+}
+
+// BuildTopology builds the graph from the lldp neighbors of the switches, the link between two switches
+// is reported by both of them and is only kept once
+func BuildTopology(items []topohubv1beta1.SwitchStatus) *Topology {
+	nodes := make(map[string]TopologyNode)
+	links := make(map[string]TopologyLink)
+
+	for _, item := range items {
+		source := nodeID(topohubv1beta1.PeerKindSwitchStatus, item.Name)
+		nodes[source] = TopologyNode{ID: source, Kind: topohubv1beta1.PeerKindSwitchStatus, Name: item.Name}
+
+		for _, neighbor := range item.Status.Neighbors {
+			var target TopologyNode
+			if neighbor.PeerKind != "" {
+				target = TopologyNode{ID: nodeID(neighbor.PeerKind, neighbor.PeerName), Kind: neighbor.PeerKind, Name: neighbor.PeerName}
+			} else {
+				id := neighbor.ChassisID
+				if id == "" {
+					id = neighbor.PortID
+				}
+				name := neighbor.SystemName
+				if name == "" {
+					name = id
+				}
+				target = TopologyNode{ID: nodeID("lldp", id), Kind: "lldp", Name: name}
+			}
+			if _, ok := nodes[target.ID]; !ok {
+				nodes[target.ID] = target
+			}
+
+			link := TopologyLink{Source: source, SourcePort: neighbor.LocalPort, Target: target.ID, TargetPort: neighbor.PeerInterface}
+			if link.TargetPort == "" {
+				link.TargetPort = neighbor.PortID
+			}
+			key := fmt.Sprintf("%s|%s|%s|%s", link.Source, link.SourcePort, link.Target, link.TargetPort)
+			reverse := fmt.Sprintf("%s|%s|%s|%s", link.Target, link.TargetPort, link.Source, link.SourcePort)
+			if _, ok := links[reverse]; ok {
+				continue
+			}
+			links[key] = link
+		}
+	}
+
+	t := &Topology{Nodes: []TopologyNode{}, Links: []TopologyLink{}}
+	for _, node := range nodes {
+		t.Nodes = append(t.Nodes, node)
+	}
+	for _, link := range links {
+		t.Links = append(t.Links, link)
+	}
+	sort.Slice(t.Nodes, func(i, j int) bool { return t.Nodes[i].ID < t.Nodes[j].ID })
+	sort.Slice(t.Links, func(i, j int) bool {
+		if t.Links[i].Source != t.Links[j].Source {
+			return t.Links[i].Source < t.Links[j].Source
+		}
+		return t.Links[i].SourcePort < t.Links[j].SourcePort
+	})
+	return t
+}
+
+// DOT returns the topology in the graphviz dot format
+func (t *Topology) DOT() string {
+	shapes := map[string]string{
+		topohubv1beta1.PeerKindSwitchStatus:  "box",
+		topohubv1beta1.PeerKindRedfishStatus: "ellipse",
+		topohubv1beta1.PeerKindSSHStatus:     "ellipse",
+	}
+	var b strings.Builder
+	b.WriteString("graph topohub {\n")
+	for _, node := range t.Nodes {
+		shape, ok := shapes[node.Kind]
+		if !ok {
+			shape = "plaintext"
+		}
+		fmt.Fprintf(&b, "  %q [label=%q, shape=%s];\n", node.ID, node.Name, shape)
+	}
+	for _, link := range t.Links {
+		fmt.Fprintf(&b, "  %q -- %q [taillabel=%q, headlabel=%q];\n", link.Source, link.Target, link.SourcePort, link.TargetPort)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,405 @@
+package switchztp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+// all the SwitchZtps are rendered together, so the events are merged into a single request
+var renderRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "switchztps"}}
+
+// FetchEvent is reported by the http server when a switch fetches its ztp file
+type FetchEvent struct {
+	// DirName is the directory of the switch, see DeviceDirName
+	DirName string
+	IpAddr  string
+	// File is the requested path, like /ztp/ztp.json
+	File string
+	Time time.Time
+}
+
+// SendFetchEvent reports the event without blocking, the event is dropped when the controller is busy or not running
+func SendFetchEvent(ch chan FetchEvent, event FetchEvent) bool {
+	if ch == nil {
+		return false
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	select {
+	case ch <- event:
+		return true
+	default:
+		return false
+	}
+}
+
+// SwitchZtpController renders the ztp files for the switches of the SwitchZtps, and records the fetches of the switches
+type SwitchZtpController struct {
+	client      client.Client
+	agentConfig *config.AgentConfig
+	log         *zap.SugaredLogger
+	fetchCh     chan FetchEvent
+}
+
+func NewSwitchZtpController(mgr ctrl.Manager, agentConfig *config.AgentConfig) *SwitchZtpController {
+	return &SwitchZtpController{
+		client:      mgr.GetClient(),
+		agentConfig: agentConfig,
+		log:         log.Logger.Named("switchztpReconcile"),
+		fetchCh:     make(chan FetchEvent, 1000),
+	}
+}
+
+// GetFetchEventChan returns the channel to report the fetches of the ztp files
+func (r *SwitchZtpController) GetFetchEventChan() chan FetchEvent {
+	return r.fetchCh
+}
+
+// 只有 leader 才会执行 Reconcile
+func (r *SwitchZtpController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if err := r.renderAll(ctx); err != nil {
+		r.log.Errorf("failed to render switchztps: %v", err)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// renderAll renders the ztp files of all SwitchZtps, and removes the files of the switches which are not assigned
+func (r *SwitchZtpController) renderAll(ctx context.Context) error {
+	ztpList := &topohubv1beta1.SwitchZtpList{}
+	if err := r.client.List(ctx, ztpList); err != nil {
+		return fmt.Errorf("failed to list switchztps: %v", err)
+	}
+	subnetList := &topohubv1beta1.SubnetList{}
+	if err := r.client.List(ctx, subnetList); err != nil {
+		return fmt.Errorf("failed to list subnets: %v", err)
+	}
+	subnets := make(map[string]*topohubv1beta1.Subnet)
+	for i := range subnetList.Items {
+		subnets[subnetList.Items[i].Name] = &subnetList.Items[i]
+	}
+	ips, err := r.listSwitchIPs(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the SwitchZtp with the smaller name wins when a switch is assigned to multiple SwitchZtps
+	sort.Slice(ztpList.Items, func(i, j int) bool {
+		return ztpList.Items[i].Name < ztpList.Items[j].Name
+	})
+	assigned := make(map[string]string)
+	for i := range ztpList.Items {
+		ztp := &ztpList.Items[i]
+		switches, msgs := r.renderSwitchZtp(ztp, subnets, ips, assigned)
+		if err := r.updateZtpStatus(ctx, ztp, switches, msgs); err != nil {
+			r.log.Errorf("failed to update status of switchztp %s: %v", ztp.Name, err)
+		}
+	}
+
+	r.cleanupStaleDirs(assigned)
+	return nil
+}
+
+// listSwitchIPs returns the ip of the switches from the BindingIp and DhcpLease objects, the key is the lowercase mac address
+func (r *SwitchZtpController) listSwitchIPs(ctx context.Context) (map[string]string, error) {
+	result := make(map[string]string)
+
+	// the binding ip is preferred, so the leases are only used for the macs without binding ip
+	bindingIPList := &topohubv1beta1.BindingIpList{}
+	if err := r.client.List(ctx, bindingIPList); err != nil {
+		return nil, fmt.Errorf("failed to list bindingips: %v", err)
+	}
+	for _, item := range bindingIPList.Items {
+		result[strings.ToLower(item.Spec.MacAddr)] = item.Spec.IpAddr
+	}
+
+	leaseList := &topohubv1beta1.DhcpLeaseList{}
+	if err := r.client.List(ctx, leaseList); err != nil {
+		return nil, fmt.Errorf("failed to list dhcpleases: %v", err)
+	}
+	for _, item := range leaseList.Items {
+		mac := strings.ToLower(item.Spec.MacAddr)
+		if _, ok := result[mac]; !ok {
+			result[mac] = item.Spec.IpAddr
+		}
+	}
+
+	return result, nil
+}
+
+// renderSwitchZtp renders the ztp files of the switches, and returns the rendered switches and the problems
+func (r *SwitchZtpController) renderSwitchZtp(ztp *topohubv1beta1.SwitchZtp, subnets map[string]*topohubv1beta1.Subnet,
+	ips map[string]string, assigned map[string]string) ([]topohubv1beta1.ZtpSwitchStatus, []string) {
+	subnet, ok := subnets[ztp.Spec.Subnet]
+	if !ok || subnet.Spec.Feature == nil || !subnet.Spec.Feature.EnableZtp {
+		return nil, []string{fmt.Sprintf("the ztp of the subnet %s is not enabled", ztp.Spec.Subnet)}
+	}
+	fileName, err := vendorFile(ztp.Spec.Vendor)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	httpServer := tools.FormatHttpServer(strings.Split(subnet.Spec.Interface.IPv4, "/")[0], r.agentConfig.HttpPort)
+
+	var msgs []string
+	var rendered []topohubv1beta1.ZtpSwitchStatus
+	for _, sw := range ztp.Spec.Switches {
+		if err := validateSwitch(sw); err != nil {
+			msgs = append(msgs, err.Error())
+			continue
+		}
+		dirName := DeviceDirName(sw.MacAddr, sw.Serial)
+		if owner, ok := assigned[dirName]; ok {
+			msgs = append(msgs, fmt.Sprintf("switch %s has been assigned to the switchztp %s", dirName, owner))
+			continue
+		}
+
+		data := newTemplateData(ztp, sw, ips[strings.ToLower(sw.MacAddr)], httpServer)
+		content, err := renderZtpFile(ztp, data)
+		if err != nil {
+			msgs = append(msgs, fmt.Sprintf("failed to render switch %s: %v", dirName, err))
+			continue
+		}
+		if err := r.writeZtpFile(dirName, fileName, content); err != nil {
+			msgs = append(msgs, fmt.Sprintf("failed to write switch %s: %v", dirName, err))
+			continue
+		}
+		r.log.Debugf("rendered the ztp file of switch %s for switchztp %s", dirName, ztp.Name)
+
+		assigned[dirName] = ztp.Name
+		rendered = append(rendered, topohubv1beta1.ZtpSwitchStatus{
+			MacAddr: data.MacAddr,
+			Serial:  sw.Serial,
+			File:    ztpFilePath(dirName, fileName),
+		})
+	}
+
+	return rendered, msgs
+}
+
+// writeZtpFile writes the rendered ztp file into the directory of the switch. The file is replaced by the rename,
+// so the switch fetching it never gets a missing or partial file, and it is not written when nothing changes
+func (r *SwitchZtpController) writeZtpFile(dirName, fileName, content string) error {
+	dir := filepath.Join(r.agentConfig.StoragePathHttpZtp, dirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", dir, err)
+	}
+	// the scripts are executed by the switches
+	mode := os.FileMode(0644)
+	if fileName != "ztp.json" {
+		mode = 0755
+	}
+
+	file := filepath.Join(dir, fileName)
+	if info, err := os.Stat(file); err == nil && info.Mode().Perm() == mode {
+		if existing, err := os.ReadFile(file); err == nil && string(existing) == content {
+			return r.removeOtherFiles(dir, fileName)
+		}
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+fileName+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create the temporary file of %s: %v", fileName, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %v", fileName, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", fileName, err)
+	}
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return fmt.Errorf("failed to chmod %s: %v", fileName, err)
+	}
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return fmt.Errorf("failed to rename %s: %v", fileName, err)
+	}
+	return r.removeOtherFiles(dir, fileName)
+}
+
+// removeOtherFiles removes the files rendered before for the other type of the ztp file, and the temporary files
+// left by a crash
+func (r *SwitchZtpController) removeOtherFiles(dir, fileName string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", dir, err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == fileName {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return fmt.Errorf("failed to remove %s: %v", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+// cleanupStaleDirs removes the directories of the switches which are not assigned to any SwitchZtp,
+// the files uploaded by the users are kept
+func (r *SwitchZtpController) cleanupStaleDirs(assigned map[string]string) {
+	entries, err := os.ReadDir(r.agentConfig.StoragePathHttpZtp)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || !isDeviceDirName(name) {
+			continue
+		}
+		if _, ok := assigned[name]; !ok {
+			r.log.Infof("remove stale ztp directory %s", name)
+			_ = os.RemoveAll(filepath.Join(r.agentConfig.StoragePathHttpZtp, name))
+		}
+	}
+}
+
+// updateZtpStatus updates the rendered switches and the Rendered condition, the fetch records of the switches are kept
+func (r *SwitchZtpController) updateZtpStatus(ctx context.Context, ztp *topohubv1beta1.SwitchZtp, switches []topohubv1beta1.ZtpSwitchStatus, msgs []string) error {
+	updated := ztp.DeepCopy()
+
+	fetched := make(map[string]topohubv1beta1.ZtpSwitchStatus, len(ztp.Status.Switches))
+	for _, item := range ztp.Status.Switches {
+		fetched[item.File] = item
+	}
+	for i := range switches {
+		if old, ok := fetched[switches[i].File]; ok {
+			switches[i].FetchedBy = old.FetchedBy
+			switches[i].FetchedFile = old.FetchedFile
+			switches[i].FetchTime = old.FetchTime
+		}
+	}
+	updated.Status.Switches = switches
+	updated.Status.SwitchAmount = int32(len(switches))
+
+	condition := metav1.Condition{
+		Type:    topohubv1beta1.SwitchZtpConditionRendered,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Rendered",
+		Message: fmt.Sprintf("ztp files are rendered for %d switches", len(switches)),
+	}
+	if len(msgs) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "RenderFailed"
+		condition.Message = strings.Join(msgs, "; ")
+	}
+	meta.SetStatusCondition(&updated.Status.Conditions, condition)
+
+	if reflect.DeepEqual(ztp.Status, updated.Status) {
+		return nil
+	}
+	if err := r.client.Status().Update(ctx, updated); err != nil {
+		return err
+	}
+	r.log.Infof("updated status of switchztp %s: %d switches", ztp.Name, len(switches))
+	return nil
+}
+
+// processFetchEvents records the fetches reported by the http server
+func (r *SwitchZtpController) processFetchEvents() {
+	for event := range r.fetchCh {
+		if err := r.recordFetch(event); err != nil {
+			r.log.Errorf("Failed to record the ztp fetch %+v: %v", event, err)
+		}
+	}
+}
+
+// recordFetch updates the fetch record of the switch with retries for conflicts
+func (r *SwitchZtpController) recordFetch(event FetchEvent) error {
+	ctx := context.Background()
+	ztpList := &topohubv1beta1.SwitchZtpList{}
+	if err := r.client.List(ctx, ztpList); err != nil {
+		return fmt.Errorf("failed to list switchztps: %v", err)
+	}
+	name := ""
+	for _, item := range ztpList.Items {
+		for _, sw := range item.Status.Switches {
+			if DeviceDirName(sw.MacAddr, sw.Serial) == event.DirName {
+				name = item.Name
+			}
+		}
+	}
+	if name == "" {
+		r.log.Debugf("no switchztp is found for the ztp fetch of %s", event.DirName)
+		return nil
+	}
+
+	maxRetries := 3
+	for i := 0; i < maxRetries; i++ {
+		existing := &topohubv1beta1.SwitchZtp{}
+		if err := r.client.Get(ctx, client.ObjectKey{Name: name}, existing); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to get switchztp %s: %v", name, err)
+		}
+		updated := existing.DeepCopy()
+		for j := range updated.Status.Switches {
+			sw := &updated.Status.Switches[j]
+			if DeviceDirName(sw.MacAddr, sw.Serial) == event.DirName {
+				sw.FetchedBy = event.IpAddr
+				sw.FetchedFile = event.File
+				sw.FetchTime = event.Time.UTC().Format(time.RFC3339)
+			}
+		}
+		if reflect.DeepEqual(existing.Status, updated.Status) {
+			return nil
+		}
+
+		if err := r.client.Status().Update(ctx, updated); err != nil {
+			if errors.IsConflict(err) {
+				r.log.Debugf("Conflict updating switchztp %s, will retry", name)
+				continue
+			}
+			return fmt.Errorf("failed to update switchztp %s: %v", name, err)
+		}
+		r.log.Infof("switch %s (IP: %s) fetched the ztp file %s of switchztp %s", event.DirName, event.IpAddr, event.File, name)
+		return nil
+	}
+	return fmt.Errorf("failed to update switchztp %s after %d retries", name, maxRetries)
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *SwitchZtpController) SetupWithManager(mgr ctrl.Manager) error {
+	go func() {
+		<-mgr.Elected()
+		r.log.Info("Elected as leader, begin to record the ztp fetches")
+		go r.processFetchEvents()
+	}()
+
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		return []reconcile.Request{renderRequest}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("switchztp").
+		// the status updates of the SwitchZtps and subnets are ignored
+		Watches(&topohubv1beta1.SwitchZtp{}, enqueue, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&topohubv1beta1.Subnet{}, enqueue, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&topohubv1beta1.BindingIp{}, enqueue).
+		Watches(&topohubv1beta1.DhcpLease{}, enqueue).
+		Complete(r)
+}
@@ -0,0 +1,45 @@
+package switchztp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/infrastructure-io/topohub/pkg/config"
+)
+
+// TestWriteZtpFile tests the ztp file is replaced in place, and it is not written again when nothing changes
+func TestWriteZtpFile(t *testing.T) {
+	dir := t.TempDir()
+	r := &SwitchZtpController{
+		agentConfig: &config.AgentConfig{StoragePathHttpZtp: dir},
+		log:         zap.NewNop().Sugar(),
+	}
+
+	if err := r.writeZtpFile("sn-abc", "ztp.py", "print(1)"); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	file := filepath.Join(dir, "sn-abc", "ztp.py")
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(file, past, past); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.writeZtpFile("sn-abc", "ztp.py", "print(1)"); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	if info, _ := os.Stat(file); !info.ModTime().Equal(past) || info.Mode().Perm() != 0755 {
+		t.Errorf("expected the unchanged script not to be written again: %v %v", info.ModTime(), info.Mode())
+	}
+
+	// the file of the other type is removed after the new one is in place
+	if err := r.writeZtpFile("sn-abc", "ztp.json", "{}"); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(dir, "sn-abc"))
+	if len(entries) != 1 || entries[0].Name() != "ztp.json" {
+		t.Errorf("unexpected files of the switch: %v", entries)
+	}
+}
@@ -0,0 +1,181 @@
+// 为每台交换机生成 ZTP 配置文件
+
+package switchztp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path"
+	"regexp"
+	"strings"
+	"text/template"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+const (
+	// the http directory of the ztp files
+	httpZtpDir = "ztp"
+
+	// the directory name prefix of the switches identified by the serial number
+	serialDirPrefix = "serial-"
+
+	// the cumulus ztp script must contain the marker, otherwise it is refused by the switch
+	cumulusZtpMarker = "CUMULUS-AUTOPROVISIONING"
+)
+
+// ZtpEntryFiles are the urls in the dhcp options, which are resolved to the file of the requesting switch.
+// The sonic and arista switches fetch the url in option 67, and the cumulus switches fetch the url in option 239
+var ZtpEntryFiles = []string{"ztp.json", "ztp.sh"}
+
+// vendorFiles is the name of the rendered ztp file for each vendor
+var vendorFiles = map[string]string{
+	topohubv1beta1.SwitchVendorSonic:   "ztp.json",
+	topohubv1beta1.SwitchVendorCumulus: "ztp.sh",
+	topohubv1beta1.SwitchVendorArista:  "bootstrap",
+}
+
+// the serial number is used as the directory name
+var serialRegex = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// templateData holds the variables for the ztp template
+type templateData struct {
+	// the address of the http server, like http://192.168.1.2
+	HttpServer string
+	Subnet     string
+	MacAddr    string
+	Serial     string
+	Hostname   string
+	IpAddr     string
+	Variables  map[string]string
+}
+
+// DeviceDirName returns the directory name of a switch, the mac address takes precedence over the serial number
+// Example:
+//   - Input: "00:11:22:AA:BB:CC", "SN123"
+//   - Returns: "00-11-22-aa-bb-cc"
+//   - Input: "", "SN123"
+//   - Returns: "serial-SN123"
+func DeviceDirName(mac, serial string) string {
+	if mac != "" {
+		return strings.ToLower(strings.ReplaceAll(mac, ":", "-"))
+	}
+	if serial != "" {
+		return serialDirPrefix + serial
+	}
+	return ""
+}
+
+// isDeviceDirName checks whether the directory is created for a switch
+func isDeviceDirName(name string) bool {
+	if strings.HasPrefix(name, serialDirPrefix) {
+		return true
+	}
+	_, err := net.ParseMAC(strings.ReplaceAll(name, "-", ":"))
+	return err == nil
+}
+
+// ParseSerialFromClientID returns the serial number in the dhcp client identifier recorded by dnsmasq.
+// The identifier with the type 0 carries a string, and the type 1 carries a mac address which is ignored
+// Example:
+//   - Input: "00:53:4e:31:32:33"
+//   - Returns: "SN123"
+//   - Input: "01:00:11:22:33:44:55"
+//   - Returns: ""
+func ParseSerialFromClientID(clientID string) string {
+	if clientID == "" {
+		return ""
+	}
+	data, err := hex.DecodeString(strings.ReplaceAll(clientID, ":", ""))
+	if err != nil || len(data) == 0 {
+		return ""
+	}
+	if data[0] == 0 {
+		data = data[1:]
+	} else if data[0] == 1 {
+		return ""
+	}
+	serial := strings.TrimRight(string(data), "\x00")
+	if !serialRegex.MatchString(serial) {
+		return ""
+	}
+	return serial
+}
+
+// vendorFile returns the name of the ztp file of the vendor
+func vendorFile(vendor string) (string, error) {
+	name, ok := vendorFiles[vendor]
+	if !ok {
+		return "", fmt.Errorf("unsupported vendor %s", vendor)
+	}
+	return name, nil
+}
+
+// validateSwitch checks the identity of the switch
+func validateSwitch(sw topohubv1beta1.ZtpSwitch) error {
+	if sw.MacAddr == "" && sw.Serial == "" {
+		return fmt.Errorf("either macAddr or serial is required")
+	}
+	if sw.MacAddr != "" {
+		if _, err := net.ParseMAC(sw.MacAddr); err != nil {
+			return fmt.Errorf("invalid macAddr %s", sw.MacAddr)
+		}
+	}
+	if sw.Serial != "" && !serialRegex.MatchString(sw.Serial) {
+		return fmt.Errorf("invalid serial %s", sw.Serial)
+	}
+	return nil
+}
+
+// newTemplateData builds the template variables of a switch
+func newTemplateData(ztp *topohubv1beta1.SwitchZtp, sw topohubv1beta1.ZtpSwitch, ipAddr, httpServer string) templateData {
+	variables := sw.Variables
+	if variables == nil {
+		variables = map[string]string{}
+	}
+	return templateData{
+		HttpServer: httpServer,
+		Subnet:     ztp.Spec.Subnet,
+		MacAddr:    strings.ToLower(sw.MacAddr),
+		Serial:     sw.Serial,
+		Hostname:   sw.Hostname,
+		IpAddr:     ipAddr,
+		Variables:  variables,
+	}
+}
+
+// renderZtpFile renders the ztp file of the switch, and checks the format required by the vendor
+func renderZtpFile(ztp *topohubv1beta1.SwitchZtp, data templateData) (string, error) {
+	tmpl, err := template.New(ztp.Name).Option("missingkey=error").Parse(ztp.Spec.Template)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template: %v", err)
+	}
+	content := buf.String()
+
+	switch ztp.Spec.Vendor {
+	case topohubv1beta1.SwitchVendorSonic:
+		if !json.Valid(buf.Bytes()) {
+			return "", fmt.Errorf("the rendered ztp.json is not a valid json")
+		}
+	case topohubv1beta1.SwitchVendorCumulus:
+		if !strings.Contains(content, cumulusZtpMarker) {
+			return "", fmt.Errorf("the rendered ztp.sh does not contain the marker %s", cumulusZtpMarker)
+		}
+	}
+	return content, nil
+}
+
+// ztpFilePath returns the http path of the rendered ztp file
+// Example:
+//   - Input: "00-11-22-aa-bb-cc", "ztp.json"
+//   - Returns: "/ztp/00-11-22-aa-bb-cc/ztp.json"
+func ztpFilePath(dirName, fileName string) string {
+	return "/" + path.Join(httpZtpDir, dirName, fileName)
+}
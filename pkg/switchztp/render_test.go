@@ -0,0 +1,84 @@
+package switchztp
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestRenderZtpFile tests the template variables and the format checks of the vendors
+func TestRenderZtpFile(t *testing.T) {
+	ztp := &topohubv1beta1.SwitchZtp{
+		ObjectMeta: metav1.ObjectMeta{Name: "leaf"},
+		Spec: topohubv1beta1.SwitchZtpSpec{
+			Subnet:   "net0",
+			Vendor:   topohubv1beta1.SwitchVendorSonic,
+			Template: `{"ztp": {"01-configdb-json": {"url": {"source": "{{ .HttpServer }}/ztp/{{ .Hostname }}.json"}}, "asn": "{{ .Variables.asn }}"}}`,
+		},
+	}
+	sw := topohubv1beta1.ZtpSwitch{MacAddr: "00:11:22:AA:BB:CC", Hostname: "leaf1", Variables: map[string]string{"asn": "65001"}}
+
+	content, err := renderZtpFile(ztp, newTemplateData(ztp, sw, "192.168.1.20", "http://192.168.1.2"))
+	if err != nil {
+		t.Fatalf("failed to render: %v", err)
+	}
+	if !strings.Contains(content, `"source": "http://192.168.1.2/ztp/leaf1.json"`) || !strings.Contains(content, `"asn": "65001"`) {
+		t.Errorf("unexpected content: %s", content)
+	}
+
+	// the sonic ztp file must be a json
+	ztp.Spec.Template = `{"ztp": {{ .Hostname }}}`
+	if _, err := renderZtpFile(ztp, newTemplateData(ztp, sw, "", "http://192.168.1.2")); err == nil {
+		t.Errorf("expected an error for the invalid json")
+	}
+
+	// the cumulus ztp script must contain the marker
+	ztp.Spec.Vendor = topohubv1beta1.SwitchVendorCumulus
+	ztp.Spec.Template = "#!/bin/bash\nhostnamectl set-hostname {{ .Hostname }}\n"
+	if _, err := renderZtpFile(ztp, newTemplateData(ztp, sw, "", "http://192.168.1.2")); err == nil {
+		t.Errorf("expected an error for the script without the marker")
+	}
+	ztp.Spec.Template = "#!/bin/bash\n# CUMULUS-AUTOPROVISIONING\nhostnamectl set-hostname {{ .Hostname }}\n"
+	if _, err := renderZtpFile(ztp, newTemplateData(ztp, sw, "", "http://192.168.1.2")); err != nil {
+		t.Errorf("failed to render the cumulus script: %v", err)
+	}
+
+	// the undefined variables are refused
+	ztp.Spec.Template = "# CUMULUS-AUTOPROVISIONING\n{{ .Variables.missing }}"
+	if _, err := renderZtpFile(ztp, newTemplateData(ztp, sw, "", "http://192.168.1.2")); err == nil {
+		t.Errorf("expected an error for the missing variable")
+	}
+}
+
+// TestParseSerialFromClientID tests the serial number is decoded from the client identifier
+func TestParseSerialFromClientID(t *testing.T) {
+	tests := []struct {
+		clientID string
+		expected string
+	}{
+		{"00:53:4e:31:32:33", "SN123"},
+		{"53:4e:31:32:33", "SN123"},
+		{"01:00:11:22:33:44:55", ""},
+		{"00:ff:fe", ""},
+		{"", ""},
+		{"invalid", ""},
+	}
+	for _, tt := range tests {
+		if got := ParseSerialFromClientID(tt.clientID); got != tt.expected {
+			t.Errorf("ParseSerialFromClientID(%q) = %q, expected %q", tt.clientID, got, tt.expected)
+		}
+	}
+
+	if got := DeviceDirName("00:11:22:AA:BB:CC", "SN123"); got != "00-11-22-aa-bb-cc" || !isDeviceDirName(got) {
+		t.Errorf("unexpected directory %s for the mac", got)
+	}
+	if got := DeviceDirName("", "SN123"); got != "serial-SN123" || !isDeviceDirName(got) {
+		t.Errorf("unexpected directory %s for the serial", got)
+	}
+	if isDeviceDirName("ztp.json") {
+		t.Errorf("the static file should not be a directory of switch")
+	}
+}
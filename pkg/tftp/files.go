@@ -0,0 +1,39 @@
+package tftp
+
+import (
+	"strings"
+	"sync"
+)
+
+// MemoryFiles holds the files generated for the mac addresses, which are served without writing them to the disk
+type MemoryFiles struct {
+	lock sync.RWMutex
+	// the key is the lowercase mac, and the value is the content of each file relative to the root
+	files map[string]map[string][]byte
+}
+
+func NewMemoryFiles() *MemoryFiles {
+	return &MemoryFiles{files: make(map[string]map[string][]byte)}
+}
+
+// Replace replaces all the files
+func (m *MemoryFiles) Replace(files map[string]map[string][]byte) {
+	normalized := make(map[string]map[string][]byte, len(files))
+	for mac, items := range files {
+		normalized[strings.ToLower(mac)] = items
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.files = normalized
+}
+
+// Lookup returns the file generated for the mac
+func (m *MemoryFiles) Lookup(mac, name string) ([]byte, bool) {
+	if m == nil || mac == "" {
+		return nil, false
+	}
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	content, ok := m.files[strings.ToLower(mac)][name]
+	return content, ok
+}
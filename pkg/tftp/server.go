@@ -0,0 +1,423 @@
+// 内置的只读 TFTP server，支持 blksize、windowsize、tsize 和 timeout 选项协商
+
+package tftp
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	opRRQ   = 1
+	opWRQ   = 2
+	opDATA  = 3
+	opACK   = 4
+	opERROR = 5
+	opOACK  = 6
+
+	errNotDefined   = 0
+	errFileNotFound = 1
+	errAccess       = 2
+	errIllegalOp    = 4
+
+	// the default block size of RFC 1350
+	defaultBlockSize = 512
+	// the max block size of RFC 2348
+	maxBlockSize     = 65464
+	defaultTimeout   = 2 * time.Second
+	maxRetries       = 5
+	maxPacketSize    = 65536
+	defaultWindowLen = 1
+)
+
+// FileProvider returns the files which are generated in memory, the files are preferred to the ones in the root
+type FileProvider interface {
+	Lookup(mac, name string) ([]byte, bool)
+}
+
+// Transfer is the result of a read request
+type Transfer struct {
+	ClientIP string
+	// the mac of the client, which is empty when it is not found
+	MacAddr string
+	// the file relative to the root
+	File       string
+	Bytes      int64
+	BlockSize  int
+	WindowSize int
+	// the file is generated in memory
+	InMemory bool
+	Duration time.Duration
+	Err      error
+}
+
+type Config struct {
+	// the address to listen, like 192.168.1.2:69
+	Address       string
+	Root          string
+	MaxBlockSize  int
+	MaxWindowSize int
+	Provider      FileProvider
+	// MacByIP returns the mac of the client for the files in the provider
+	MacByIP func(ip string) string
+	// OnTransfer is called when a transfer finishes
+	OnTransfer func(Transfer)
+	Log        *zap.SugaredLogger
+}
+
+// Server is a read-only tftp server
+type Server struct {
+	config Config
+	conn   *net.UDPConn
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewServer creates a tftp server
+func NewServer(config Config) *Server {
+	if config.MaxBlockSize <= 0 || config.MaxBlockSize > maxBlockSize {
+		config.MaxBlockSize = maxBlockSize
+	}
+	if config.MaxWindowSize <= 0 {
+		config.MaxWindowSize = defaultWindowLen
+	}
+	if config.Log == nil {
+		config.Log = zap.NewNop().Sugar()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Server{config: config, ctx: ctx, cancel: cancel}
+}
+
+// Start listens on the address and serves the requests in the background
+func (s *Server) Start() error {
+	addr, err := net.ResolveUDPAddr("udp4", s.config.Address)
+	if err != nil {
+		return fmt.Errorf("invalid address %s: %v", s.config.Address, err)
+	}
+	conn, err := net.ListenUDP("udp4", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", s.config.Address, err)
+	}
+	s.conn = conn
+	s.config.Log.Infof("tftp server listens on %s, root %s", conn.LocalAddr(), s.config.Root)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		buf := make([]byte, maxPacketSize)
+		for {
+			n, client, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				if s.ctx.Err() != nil {
+					return
+				}
+				s.config.Log.Warnf("failed to read the tftp request: %v", err)
+				continue
+			}
+			packet := append([]byte(nil), buf[:n]...)
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				s.handleRequest(client, packet)
+			}()
+		}
+	}()
+	return nil
+}
+
+// LocalAddr returns the listening address
+func (s *Server) LocalAddr() net.Addr {
+	if s.conn == nil {
+		return nil
+	}
+	return s.conn.LocalAddr()
+}
+
+// Stop closes the listener and waits for the running transfers
+func (s *Server) Stop() {
+	s.cancel()
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.wg.Wait()
+}
+
+// request is a parsed read request
+type request struct {
+	filename string
+	mode     string
+	// the options in the order of the request
+	optionNames []string
+	options     map[string]string
+}
+
+func parseRequest(packet []byte) (*request, error) {
+	fields := strings.Split(string(packet[2:]), "\x00")
+	// the packet ends with a zero byte, so the last field is empty
+	if len(fields) < 3 || fields[len(fields)-1] != "" {
+		return nil, fmt.Errorf("malformed request")
+	}
+	fields = fields[:len(fields)-1]
+	req := &request{filename: fields[0], mode: strings.ToLower(fields[1]), options: map[string]string{}}
+	if req.filename == "" {
+		return nil, fmt.Errorf("empty filename")
+	}
+	for i := 2; i+1 < len(fields); i += 2 {
+		name := strings.ToLower(fields[i])
+		req.optionNames = append(req.optionNames, name)
+		req.options[name] = fields[i+1]
+	}
+	return req, nil
+}
+
+// cleanName returns the path relative to the root. The boot files in the dhcp reply of dnsmasq
+// are the absolute paths in the tftp directory
+func (s *Server) cleanName(filename string) string {
+	name := strings.ReplaceAll(filename, "\\", "/")
+	root := filepath.ToSlash(filepath.Clean(s.config.Root))
+	if after, found := strings.CutPrefix(name, root+"/"); found {
+		name = after
+	}
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+// open returns the content of the file, the file generated in memory is preferred
+func (s *Server) open(mac, name string) (io.ReaderAt, int64, bool, func(), error) {
+	if s.config.Provider != nil {
+		if content, ok := s.config.Provider.Lookup(mac, name); ok {
+			return bytes.NewReader(content), int64(len(content)), true, func() {}, nil
+		}
+	}
+	f, err := os.Open(filepath.Join(s.config.Root, filepath.FromSlash(name)))
+	if err != nil {
+		return nil, 0, false, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		f.Close()
+		return nil, 0, false, nil, os.ErrNotExist
+	}
+	return f, info.Size(), false, func() { f.Close() }, nil
+}
+
+func (s *Server) handleRequest(client *net.UDPAddr, packet []byte) {
+	if len(packet) < 4 {
+		return
+	}
+	local := s.conn.LocalAddr().(*net.UDPAddr)
+	// each transfer uses a new port as its transfer id
+	conn, err := net.DialUDP("udp4", &net.UDPAddr{IP: local.IP}, client)
+	if err != nil {
+		s.config.Log.Warnf("failed to create the transfer to %s: %v", client, err)
+		return
+	}
+	defer conn.Close()
+
+	switch binary.BigEndian.Uint16(packet) {
+	case opRRQ:
+	case opWRQ:
+		sendError(conn, errAccess, "the tftp server is read only")
+		return
+	default:
+		sendError(conn, errIllegalOp, "illegal operation")
+		return
+	}
+	req, err := parseRequest(packet)
+	if err != nil {
+		sendError(conn, errNotDefined, err.Error())
+		return
+	}
+
+	clientIP := client.IP.String()
+	t := Transfer{
+		ClientIP:   clientIP,
+		File:       s.cleanName(req.filename),
+		BlockSize:  defaultBlockSize,
+		WindowSize: defaultWindowLen,
+	}
+	if s.config.MacByIP != nil {
+		t.MacAddr = s.config.MacByIP(clientIP)
+	}
+	start := time.Now()
+	t.Bytes, t.Err = s.transfer(conn, req, &t)
+	t.Duration = time.Since(start)
+	if s.config.OnTransfer != nil {
+		s.config.OnTransfer(t)
+	}
+}
+
+// transfer sends the file to the client and returns the bytes sent
+func (s *Server) transfer(conn *net.UDPConn, req *request, t *Transfer) (int64, error) {
+	reader, size, inMemory, closeFile, err := s.open(t.MacAddr, t.File)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			sendError(conn, errFileNotFound, "file not found")
+			return 0, fmt.Errorf("file not found")
+		}
+		sendError(conn, errAccess, "failed to open the file")
+		return 0, err
+	}
+	defer closeFile()
+	t.InMemory = inMemory
+
+	timeout := defaultTimeout
+	accepted := []string{}
+	for _, name := range req.optionNames {
+		value, err := strconv.Atoi(req.options[name])
+		if err != nil {
+			continue
+		}
+		switch name {
+		case "blksize":
+			if value < 8 {
+				continue
+			}
+			t.BlockSize = min(value, s.config.MaxBlockSize)
+			accepted = append(accepted, name, strconv.Itoa(t.BlockSize))
+		case "windowsize":
+			if value < 1 || value > 65535 {
+				continue
+			}
+			t.WindowSize = min(value, s.config.MaxWindowSize)
+			accepted = append(accepted, name, strconv.Itoa(t.WindowSize))
+		case "tsize":
+			accepted = append(accepted, name, strconv.FormatInt(size, 10))
+		case "timeout":
+			if value < 1 || value > 255 {
+				continue
+			}
+			timeout = time.Duration(value) * time.Second
+			accepted = append(accepted, name, strconv.Itoa(value))
+		}
+	}
+
+	if len(accepted) > 0 {
+		oack := []byte{0, opOACK}
+		for _, field := range accepted {
+			oack = append(append(oack, field...), 0)
+		}
+		if err := s.sendAndWaitAck(conn, oack, timeout); err != nil {
+			return 0, err
+		}
+	}
+
+	blockSize := int64(t.BlockSize)
+	// the last block is shorter than the block size, it is empty when the size is a multiple of the block size
+	blocks := size/blockSize + 1
+	buf := make([]byte, 4+t.BlockSize)
+	binary.BigEndian.PutUint16(buf, opDATA)
+	acked := int64(0)
+	retries := 0
+	for acked < blocks {
+		if s.ctx.Err() != nil {
+			return acked * blockSize, fmt.Errorf("the tftp server is stopped")
+		}
+		end := min(acked+int64(t.WindowSize), blocks)
+		for block := acked + 1; block <= end; block++ {
+			n, err := reader.ReadAt(buf[4:], (block-1)*blockSize)
+			if err != nil && err != io.EOF {
+				sendError(conn, errNotDefined, "failed to read the file")
+				return (block - 1) * blockSize, err
+			}
+			binary.BigEndian.PutUint16(buf[2:], uint16(block))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return (block - 1) * blockSize, err
+			}
+		}
+
+		// the window is resent from the next block of the acked one only when the ack of the window end times out,
+		// the duplicate acks are ignored to avoid the sorcerer's apprentice syndrome
+		next, err := waitAck(conn, timeout, acked, end)
+		if next > acked {
+			retries = 0
+			acked = next
+		}
+		if err != nil {
+			var netErr net.Error
+			if !errors.As(err, &netErr) || !netErr.Timeout() {
+				return acked * blockSize, err
+			}
+			retries++
+			if retries > maxRetries {
+				return acked * blockSize, fmt.Errorf("timeout waiting for the ack of block %d", acked+1)
+			}
+		}
+	}
+	return size, nil
+}
+
+// sendAndWaitAck sends the option acknowledgement and waits for the ack of block 0
+func (s *Server) sendAndWaitAck(conn *net.UDPConn, packet []byte, timeout time.Duration) error {
+	for retry := 0; retry <= maxRetries; retry++ {
+		if _, err := conn.Write(packet); err != nil {
+			return err
+		}
+		// the options are acknowledged by the ack of block 0
+		if _, err := waitAck(conn, timeout, -1, 0); err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("timeout waiting for the ack of the options")
+}
+
+// waitAck waits for the ack of the block end until the timeout, and returns the index of the last acked block
+// in (acked, end]. The acks of the blocks before the window end are recorded, and the duplicate acks are ignored.
+// The block number of the ack is 16 bits, so it is mapped to the block in the window
+func waitAck(conn *net.UDPConn, timeout time.Duration, acked, end int64) (int64, error) {
+	buf := make([]byte, maxPacketSize)
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return acked, err
+	}
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return acked, err
+		}
+		if n < 4 {
+			continue
+		}
+		switch binary.BigEndian.Uint16(buf) {
+		case opACK:
+			block := binary.BigEndian.Uint16(buf[2:])
+			for i := acked + 1; i <= end; i++ {
+				if uint16(i) == block {
+					acked = i
+					break
+				}
+			}
+			if acked == end {
+				return acked, nil
+			}
+		case opERROR:
+			return acked, fmt.Errorf("the client aborts the transfer: %s", strings.TrimRight(string(buf[4:n]), "\x00"))
+		}
+	}
+}
+
+func sendError(conn *net.UDPConn, code uint16, msg string) {
+	packet := make([]byte, 4, 5+len(msg))
+	binary.BigEndian.PutUint16(packet, opERROR)
+	binary.BigEndian.PutUint16(packet[2:], code)
+	packet = append(append(packet, msg...), 0)
+	_, _ = conn.Write(packet)
+}
@@ -0,0 +1,199 @@
+package tftp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// download reads the file by the windowed transfer, and returns the options acknowledged by the server
+func download(t *testing.T, server net.Addr, filename string, options ...string) ([]byte, string, uint16) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	rrq := []byte{0, opRRQ}
+	for _, field := range append([]string{filename, "octet"}, options...) {
+		rrq = append(append(rrq, field...), 0)
+	}
+	if _, err := conn.WriteTo(rrq, server); err != nil {
+		t.Fatal(err)
+	}
+
+	blockSize, windowSize := defaultBlockSize, 1
+	oack := ""
+	data := []byte{}
+	expected := uint16(1)
+	buf := make([]byte, maxPacketSize)
+	for {
+		_ = conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+		n, peer, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("failed to read: %v", err)
+		}
+		ack := func(block uint16) {
+			packet := []byte{0, opACK, 0, 0}
+			binary.BigEndian.PutUint16(packet[2:], block)
+			_, _ = conn.WriteTo(packet, peer)
+		}
+		switch binary.BigEndian.Uint16(buf) {
+		case opERROR:
+			return nil, "", binary.BigEndian.Uint16(buf[2:])
+		case opOACK:
+			fields := strings.Split(strings.TrimRight(string(buf[2:n]), "\x00"), "\x00")
+			for i := 0; i+1 < len(fields); i += 2 {
+				switch fields[i] {
+				case "blksize":
+					blockSize, _ = strconv.Atoi(fields[i+1])
+				case "windowsize":
+					windowSize, _ = strconv.Atoi(fields[i+1])
+				}
+			}
+			oack = strings.Join(fields, ",")
+			ack(0)
+		case opDATA:
+			block := binary.BigEndian.Uint16(buf[2:])
+			if block != expected {
+				continue
+			}
+			data = append(data, buf[4:n]...)
+			last := n-4 < blockSize
+			if last || int(block)%windowSize == 0 {
+				ack(block)
+			}
+			if last {
+				return data, oack, 0
+			}
+			expected++
+		}
+	}
+}
+
+type testProvider map[string]string
+
+func (p testProvider) Lookup(mac, name string) ([]byte, bool) {
+	content, ok := p[mac+"/"+name]
+	return []byte(content), ok
+}
+
+// TestServer tests the option negotiation, the windowed transfer, the files in memory and the errors
+func TestServer(t *testing.T) {
+	root := t.TempDir()
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	if err := os.MkdirAll(filepath.Join(root, "boot"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "boot", "core.efi"), content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	transfers := make(chan Transfer, 10)
+	s := NewServer(Config{
+		Address:       "127.0.0.1:0",
+		Root:          root,
+		MaxBlockSize:  1468,
+		MaxWindowSize: 4,
+		Provider:      testProvider{"00:11:22:33:44:55/boot/grub/grub.cfg": "set timeout=3\n"},
+		MacByIP:       func(ip string) string { return "00:11:22:33:44:55" },
+		OnTransfer:    func(t Transfer) { transfers <- t },
+	})
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	data, oack, code := download(t, s.LocalAddr(), root+"/boot/core.efi", "blksize", "8192", "windowsize", "16", "tsize", "0")
+	if code != 0 || !bytes.Equal(data, content) {
+		t.Fatalf("unexpected content of %d bytes, error %d", len(data), code)
+	}
+	if oack != "blksize,1468,windowsize,4,tsize,10000" {
+		t.Errorf("unexpected oack: %s", oack)
+	}
+	if tr := <-transfers; tr.Err != nil || tr.File != "boot/core.efi" || tr.Bytes != 10000 || tr.BlockSize != 1468 || tr.WindowSize != 4 {
+		t.Errorf("unexpected transfer: %+v", tr)
+	}
+
+	if data, _, code := download(t, s.LocalAddr(), "boot/grub/grub.cfg"); code != 0 || string(data) != "set timeout=3\n" {
+		t.Errorf("unexpected file in memory: %q, error %d", data, code)
+	}
+	if tr := <-transfers; !tr.InMemory || tr.MacAddr != "00:11:22:33:44:55" {
+		t.Errorf("unexpected transfer: %+v", tr)
+	}
+
+	if _, _, code := download(t, s.LocalAddr(), "../etc/passwd"); code != errFileNotFound {
+		t.Errorf("expected file not found, got %d", code)
+	}
+	if tr := <-transfers; tr.Err == nil || tr.File != "etc/passwd" {
+		t.Errorf("unexpected transfer: %+v", tr)
+	}
+}
+
+// TestDuplicateAck tests the duplicate ack does not resend the blocks, which are resent only on timeout
+func TestDuplicateAck(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "grubx64.efi"), bytes.Repeat([]byte("x"), 2*defaultBlockSize), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := NewServer(Config{Address: "127.0.0.1:0", Root: root, MaxBlockSize: defaultBlockSize, MaxWindowSize: 1})
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := conn.WriteTo(append([]byte{0, opRRQ}, "grubx64.efi\x00octet\x00"...), s.LocalAddr()); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, maxPacketSize)
+	read := func(timeout time.Duration) (uint16, net.Addr, error) {
+		_ = conn.SetReadDeadline(time.Now().Add(timeout))
+		n, peer, err := conn.ReadFrom(buf)
+		if err != nil {
+			return 0, nil, err
+		}
+		if n < 4 || binary.BigEndian.Uint16(buf) != opDATA {
+			t.Fatalf("unexpected packet: %v", buf[:n])
+		}
+		return binary.BigEndian.Uint16(buf[2:]), peer, nil
+	}
+	ack := func(block uint16, peer net.Addr) {
+		packet := []byte{0, opACK, 0, 0}
+		binary.BigEndian.PutUint16(packet[2:], block)
+		_, _ = conn.WriteTo(packet, peer)
+	}
+
+	block, peer, err := read(3 * time.Second)
+	if err != nil || block != 1 {
+		t.Fatalf("expected block 1, got %d: %v", block, err)
+	}
+	ack(1, peer)
+	if block, _, err := read(time.Second); err != nil || block != 2 {
+		t.Fatalf("expected block 2, got %d: %v", block, err)
+	}
+	// the delayed ack of block 1 arrives again
+	ack(1, peer)
+	if block, _, err := read(500 * time.Millisecond); err == nil {
+		t.Fatalf("expected the duplicate ack to be ignored, got block %d", block)
+	}
+	// block 2 is resent after the timeout
+	if block, _, err := read(3 * time.Second); err != nil || block != 2 {
+		t.Fatalf("expected block 2 to be resent, got %d: %v", block, err)
+	}
+	ack(2, peer)
+	if block, _, err := read(time.Second); err != nil || block != 3 {
+		t.Fatalf("expected block 3, got %d: %v", block, err)
+	}
+	ack(3, peer)
+}
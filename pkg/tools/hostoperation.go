@@ -0,0 +1,85 @@
+package tools
+
+import (
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// the actions which interrupt the workloads of the host
+var disruptiveActions = map[string]bool{
+	topohubv1beta1.BootCmdForceOff:         true,
+	topohubv1beta1.BootCmdGracefulShutdown: true,
+	topohubv1beta1.BootCmdForceRestart:     true,
+	topohubv1beta1.BootCmdGracefulRestart:  true,
+	topohubv1beta1.BootCmdResetPxeOnce:     true,
+}
+
+// IsDisruptiveAction checks if the action of the HostOperation powers off or reboots the host
+func IsDisruptiveAction(action string) bool {
+	return disruptiveActions[action]
+}
+
+// IsUnfinishedOperation checks if the HostOperation is pending or running
+func IsUnfinishedOperation(hostOp *topohubv1beta1.HostOperation) bool {
+	switch hostOp.Status.Status {
+	case "", topohubv1beta1.HostOperationStatusPending, topohubv1beta1.HostOperationStatusRunning:
+		return true
+	}
+	return false
+}
+
+// FindHostOfOperation returns the Host linked to the RedfishStatus or SSHStatus of the HostOperation, or nil when
+// the target is not linked to any Host
+func FindHostOfOperation(hosts []topohubv1beta1.Host, spec *topohubv1beta1.HostOperationSpec) *topohubv1beta1.Host {
+	for i := range hosts {
+		h := &hosts[i]
+		if spec.RedfishStatusName != "" && h.Status.BMC != nil && h.Status.BMC.Name == spec.RedfishStatusName {
+			return h
+		}
+		if spec.SSHStatusName != "" && h.Status.SSH != nil && h.Status.SSH.Name == spec.SSHStatusName {
+			return h
+		}
+	}
+	return nil
+}
+
+// HostOperationTarget returns the key of the RedfishStatus or SSHStatus operated by the HostOperation
+// Example:
+//   - Input: spec.redfishStatusName "192-168-1-10"
+//   - Returns: "redfishstatus/192-168-1-10"
+func HostOperationTarget(spec *topohubv1beta1.HostOperationSpec) string {
+	if spec.SSHStatusName != "" {
+		return "sshstatus/" + spec.SSHStatusName
+	}
+	return "redfishstatus/" + spec.RedfishStatusName
+}
+
+// HostOperationLockKey returns the key to serialize the operations of the same server. The operations by the redfish
+// and the ssh of a server are serialized by its Host, and the target is used when it is not linked to any Host
+// Example:
+//   - Input: Host "sn-cn7016", spec.sshStatusName "node1"
+//   - Returns: "host/sn-cn7016"
+func HostOperationLockKey(host *topohubv1beta1.Host, spec *topohubv1beta1.HostOperationSpec) string {
+	if host == nil {
+		return HostOperationTarget(spec)
+	}
+	return "host/" + host.Name
+}
+
+// HostTargets returns the keys of the RedfishStatus and SSHStatus of the server operated by the HostOperation, the
+// operations of these targets run one after another
+// Example:
+//   - Input: Host "sn-cn7016" linked to RedfishStatus "bmc1" and SSHStatus "node1", spec.sshStatusName "node1"
+//   - Returns: ["sshstatus/node1", "redfishstatus/bmc1"]
+func HostTargets(host *topohubv1beta1.Host, spec *topohubv1beta1.HostOperationSpec) []string {
+	targets := []string{HostOperationTarget(spec)}
+	if host == nil {
+		return targets
+	}
+	if host.Status.BMC != nil && host.Status.BMC.Name != spec.RedfishStatusName {
+		targets = append(targets, "redfishstatus/"+host.Status.BMC.Name)
+	}
+	if host.Status.SSH != nil && host.Status.SSH.Name != spec.SSHStatusName {
+		targets = append(targets, "sshstatus/"+host.Status.SSH.Name)
+	}
+	return targets
+}
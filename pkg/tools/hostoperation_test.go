@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"testing"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+)
+
+// TestHostOperationTarget tests the target is keyed by the name of the RedfishStatus or SSHStatus
+func TestHostOperationTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     topohubv1beta1.HostOperationSpec
+		expected string
+	}{
+		{name: "redfish", spec: topohubv1beta1.HostOperationSpec{RedfishStatusName: "192-168-1-10"}, expected: "redfishstatus/192-168-1-10"},
+		{name: "ssh", spec: topohubv1beta1.HostOperationSpec{SSHStatusName: "node1"}, expected: "sshstatus/node1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HostOperationTarget(&tt.spec); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestHostOperationLockKey tests the operations by the redfish and the ssh of a linked server share the lock
+func TestHostOperationLockKey(t *testing.T) {
+	host := &topohubv1beta1.Host{}
+	host.Name = "sn-cn7016"
+	host.Status.BMC = &topohubv1beta1.HostRecordRef{Name: "bmc1"}
+	host.Status.SSH = &topohubv1beta1.HostRecordRef{Name: "node1"}
+
+	redfishSpec := &topohubv1beta1.HostOperationSpec{RedfishStatusName: "bmc1"}
+	sshSpec := &topohubv1beta1.HostOperationSpec{SSHStatusName: "node1"}
+	if HostOperationLockKey(host, redfishSpec) != "host/sn-cn7016" || HostOperationLockKey(host, sshSpec) != "host/sn-cn7016" {
+		t.Errorf("expected the lock of the host, got %q and %q", HostOperationLockKey(host, redfishSpec), HostOperationLockKey(host, sshSpec))
+	}
+	if got := HostOperationLockKey(nil, sshSpec); got != "sshstatus/node1" {
+		t.Errorf("expected the lock of the target without the host, got %q", got)
+	}
+
+	if got := HostTargets(host, sshSpec); len(got) != 2 || got[0] != "sshstatus/node1" || got[1] != "redfishstatus/bmc1" {
+		t.Errorf("unexpected targets of the host: %v", got)
+	}
+	if got := HostTargets(nil, redfishSpec); len(got) != 1 || got[0] != "redfishstatus/bmc1" {
+		t.Errorf("unexpected targets without the host: %v", got)
+	}
+}
+
+// TestIsDisruptiveAction tests only the actions powering off or rebooting the host are disruptive
+func TestIsDisruptiveAction(t *testing.T) {
+	for _, action := range []string{topohubv1beta1.BootCmdForceOff, topohubv1beta1.BootCmdResetPxeOnce, topohubv1beta1.BootCmdGracefulRestart} {
+		if !IsDisruptiveAction(action) {
+			t.Errorf("expected action %s to be disruptive", action)
+		}
+	}
+	for _, action := range []string{topohubv1beta1.BootCmdOn, topohubv1beta1.BootCmdForceOn, topohubv1beta1.SSHCmdRunScript} {
+		if IsDisruptiveAction(action) {
+			t.Errorf("expected action %s not to be disruptive", action)
+		}
+	}
+}
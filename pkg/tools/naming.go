@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"regexp"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var invalidObjectNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+var invalidLabelValueChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// the serial numbers which are not set by the vendor
+var placeholderSerialNumbers = map[string]bool{
+	"":                       true,
+	"none":                   true,
+	"n/a":                    true,
+	"not specified":          true,
+	"not available":          true,
+	"default string":         true,
+	"to be filled by o.e.m.": true,
+	"system serial number":   true,
+	"0123456789":             true,
+}
+
+// SanitizeObjectName converts the value into a valid object name, it returns empty when it is not possible.
+// Example:
+//   - Input: "Node_01.Rack A"
+//   - Returns: "node-01.rack-a"
+func SanitizeObjectName(value string) string {
+	name := invalidObjectNameChars.ReplaceAllString(strings.ToLower(value), "-")
+	if len(name) > validation.DNS1123SubdomainMaxLength {
+		name = name[:validation.DNS1123SubdomainMaxLength]
+	}
+	name = strings.Trim(name, ".-")
+	if len(validation.IsDNS1123Subdomain(name)) > 0 {
+		return ""
+	}
+	return name
+}
+
+// SanitizeLabelValue converts the value into a valid label value, it returns empty when it is not possible.
+// Example:
+//   - Input: "Intel(R) Xeon(R) Gold 6348 CPU @ 2.60GHz"
+//   - Returns: "Intel-R-Xeon-R-Gold-6348-CPU-2.60GHz"
+func SanitizeLabelValue(value string) string {
+	value = invalidLabelValueChars.ReplaceAllString(strings.TrimSpace(value), "-")
+	if len(value) > validation.LabelValueMaxLength {
+		value = value[:validation.LabelValueMaxLength]
+	}
+	value = strings.Trim(value, "._-")
+	if len(validation.IsValidLabelValue(value)) > 0 {
+		return ""
+	}
+	return value
+}
+
+// NormalizeSerialNumber returns the trimmed serial number, or empty when it is a placeholder not set by the vendor
+func NormalizeSerialNumber(value string) string {
+	value = strings.TrimSpace(value)
+	if placeholderSerialNumbers[strings.ToLower(value)] {
+		return ""
+	}
+	return value
+}
+
+// NormalizeSystemUUID returns the lower case uuid, or empty when it is not set by the vendor, such as all zeros
+func NormalizeSystemUUID(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if strings.Trim(value, "0-") == "" || strings.Trim(value, "f-") == "" {
+		return ""
+	}
+	return value
+}
+
+// ParseNamingTemplate parses the go template which names the objects
+func ParseNamingTemplate(text string) (*template.Template, error) {
+	return template.New("name").Option("missingkey=error").Parse(text)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"net"
+	"path"
 	"regexp"
 	"strings"
 
@@ -228,6 +229,35 @@ func Int32PtrEqual(a, b *int32) bool {
 	return *a == *b
 }
 
+// StringPtrEqual compares two string pointers for equality
+// Example:
+//   - Input:
+//     a: pointer to "a"
+//     b: pointer to "a"
+//   - Returns: true (because values are equal)
+//   - Special cases: Returns true if both nil, false if only one is nil
+func StringPtrEqual(a, b *string) bool {
+	if a == nil && b == nil {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+	return *a == *b
+}
+
+// FormatHttpServer returns the url of the http server on the interface of the subnet
+// Example:
+//   - Input: "192.168.1.2", "8080"
+//   - Returns: "http://192.168.1.2:8080"
+//   - Special cases: the port is omitted when it is empty or 80
+func FormatHttpServer(selfIP, port string) string {
+	if port == "" || port == "80" {
+		return "http://" + selfIP
+	}
+	return fmt.Sprintf("http://%s:%s", selfIP, port)
+}
+
 // CountIPsInRange calculates the number of IP addresses in a given range
 // Example:
 //   - Input: "192.168.1.1-192.168.1.10,192.168.1.20"
@@ -392,6 +422,38 @@ func IsIPInRange(ip net.IP, ipRange string) bool {
 	return false
 }
 
+// ListIPsInRange returns all IP addresses in a given range, at most maxAmount addresses
+// Example:
+//   - Input: "192.168.1.1-192.168.1.3,192.168.1.20", 100
+//   - Returns: [192.168.1.1 192.168.1.2 192.168.1.3 192.168.1.20]
+//   - Error case: Returns error if range format is invalid or the amount exceeds maxAmount
+func ListIPsInRange(ipRange string, maxAmount uint64) ([]net.IP, error) {
+	total, err := CountIPsInRange(ipRange)
+	if err != nil {
+		return nil, err
+	}
+	if total > maxAmount {
+		return nil, fmt.Errorf("the amount %d of IP range %s exceeds %d", total, ipRange, maxAmount)
+	}
+
+	result := make([]net.IP, 0, total)
+	for _, r := range strings.Split(ipRange, ",") {
+		r = strings.TrimSpace(r)
+		if !strings.Contains(r, "-") {
+			result = append(result, net.ParseIP(r).To4())
+			continue
+		}
+		parts := strings.Split(r, "-")
+		startInt := ipToUint32(net.ParseIP(strings.TrimSpace(parts[0])))
+		endInt := ipToUint32(net.ParseIP(strings.TrimSpace(parts[1])))
+		for i := startInt; i <= endInt && i >= startInt; i++ {
+			result = append(result, net.IPv4(byte(i>>24), byte(i>>16), byte(i>>8), byte(i)).To4())
+		}
+	}
+
+	return result, nil
+}
+
 // ipToUint32 converts an IPv4 address to uint32
 // Example:
 //   - Input: net.ParseIP("192.168.1.1")
@@ -443,3 +505,22 @@ func ValidateHostInterfaceSubnet(parent netlink.Link, iface *topohubv1beta1.Inte
 
 	return nil
 }
+
+// IsRelativePath checks that the path is relative and does not escape from the directory, the ".." element is
+// rejected even when the cleaned path stays in the directory
+// Example:
+//   - Input: "ubuntu/vmlinuz"
+//   - Returns: true
+//   - Input: "../etc/passwd" or "/etc/passwd"
+//   - Returns: false
+func IsRelativePath(p string) bool {
+	if p == "" || path.IsAbs(p) {
+		return false
+	}
+	for _, item := range strings.Split(p, "/") {
+		if item == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,21 @@
+package tools
+
+import "testing"
+
+// TestIsRelativePath tests the paths escaping from the directory are rejected
+func TestIsRelativePath(t *testing.T) {
+	cases := map[string]bool{
+		"ubuntu/vmlinuz":      true,
+		"ubuntu/./initrd":     true,
+		"":                    false,
+		"/etc/passwd":         false,
+		"../etc/passwd":       false,
+		"ubuntu/../../passwd": false,
+		"ubuntu/..":           false,
+	}
+	for p, expected := range cases {
+		if got := IsRelativePath(p); got != expected {
+			t.Errorf("expected %v for %q, got %v", expected, p, got)
+		}
+	}
+}
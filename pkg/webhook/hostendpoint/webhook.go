@@ -6,6 +6,7 @@ import (
 	"net"
 
 	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -92,6 +93,11 @@ func (w *HostEndpointWebhook) ValidateCreate(ctx context.Context, obj runtime.Ob
 
 	w.log.Infof("Validating creation of HostEndpoint %s", hostEndpoint.Name)
 
+	// the annotation allows the later updates, so it is only set by the phone-home of topohub
+	if _, found := hostEndpoint.Annotations[topohubv1beta1.AnnotationPhoneHome]; found && !w.isController(ctx) {
+		return nil, fmt.Errorf("the annotation %s is only set by the phone-home of topohub", topohubv1beta1.AnnotationPhoneHome)
+	}
+
 	if err := w.validateHostEndpoint(ctx, hostEndpoint); err != nil {
 		w.log.Errorf("Failed to validate HostEndpoint %s: %v", hostEndpoint.Name, err)
 		return nil, err
@@ -109,10 +115,32 @@ func (w *HostEndpointWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj
 		return nil, err
 	}
 
+	// the HostEndpoint registered by the installer phone-home is updated by the next registration, which is
+	// requested by topohub itself. The annotation could be set by anyone, so it is not trusted alone
+	if oldHostEndpoint, ok := oldObj.(*topohubv1beta1.HostEndpoint); ok {
+		if _, found := oldHostEndpoint.Annotations[topohubv1beta1.AnnotationPhoneHome]; found && w.isController(ctx) {
+			w.log.Infof("Validating update of HostEndpoint %s registered by phone-home", hostEndpoint.Name)
+			if err := w.validateHostEndpoint(ctx, hostEndpoint); err != nil {
+				w.log.Errorf("Failed to validate HostEndpoint %s: %v", hostEndpoint.Name, err)
+				return nil, err
+			}
+			return nil, nil
+		}
+	}
+
 	w.log.Infof("Rejecting update of HostEndpoint %s: updates are not allowed", hostEndpoint.Name)
 	return nil, fmt.Errorf("updates to HostEndpoint resources are not allowed")
 }
 
+// isController checks if the admission request is sent by the service account of topohub
+func (w *HostEndpointWebhook) isController(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || w.config.ControllerUsername() == "" {
+		return false
+	}
+	return req.UserInfo.Username == w.config.ControllerUsername()
+}
+
 // ValidateDelete implements webhook.Validator
 func (w *HostEndpointWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	return nil, nil
@@ -163,7 +191,13 @@ func (w *HostEndpointWebhook) validateHostEndpoint(ctx context.Context, hostEndp
 		if _, ok := secret.Data["username"]; !ok {
 			return fmt.Errorf("secret must contain username key")
 		}
-		if _, ok := secret.Data["password"]; !ok {
+		_, hasPassword := secret.Data["password"]
+		_, hasKey := secret.Data["ssh-privatekey"]
+		isSSH := hostEndpoint.Spec.Type != nil && (*hostEndpoint.Spec.Type == topohubv1beta1.EndpointTypeSSH || *hostEndpoint.Spec.Type == topohubv1beta1.EndpointTypeSwitch)
+		if isSSH && !hasPassword && !hasKey {
+			return fmt.Errorf("secret must contain password or ssh-privatekey key")
+		}
+		if !isSSH && !hasPassword {
 			return fmt.Errorf("secret must contain password key")
 		}
 	}
@@ -180,5 +214,34 @@ func (w *HostEndpointWebhook) validateHostEndpoint(ctx context.Context, hostEndp
 		return fmt.Errorf("secretName and secretNamespace must be both set or both unset")
 	}
 
+	if hostEndpoint.Spec.SSHHostKey != nil && *hostEndpoint.Spec.SSHHostKey != "" {
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(*hostEndpoint.Spec.SSHHostKey)); err != nil {
+			return fmt.Errorf("invalid sshHostKey, it should be in the authorized_keys format: %v", err)
+		}
+	}
+
+	if jump := hostEndpoint.Spec.SSHJumpHost; jump != nil {
+		if hostEndpoint.Spec.Type == nil || (*hostEndpoint.Spec.Type != topohubv1beta1.EndpointTypeSSH && *hostEndpoint.Spec.Type != topohubv1beta1.EndpointTypeSwitch) {
+			return fmt.Errorf("sshJumpHost is only supported by the ssh and switch endpoints")
+		}
+		if net.ParseIP(jump.IPAddr) == nil {
+			return fmt.Errorf("invalid sshJumpHost.ipAddr %q", jump.IPAddr)
+		}
+		if jump.SSHHostKey != "" {
+			if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(jump.SSHHostKey)); err != nil {
+				return fmt.Errorf("invalid sshJumpHost.sshHostKey, it should be in the authorized_keys format: %v", err)
+			}
+		}
+		if jump.SecretName != "" {
+			namespace := jump.SecretNamespace
+			if namespace == "" && hostEndpoint.Spec.SecretNamespace != nil {
+				namespace = *hostEndpoint.Spec.SecretNamespace
+			}
+			if err := w.Client.Get(ctx, client.ObjectKey{Name: jump.SecretName, Namespace: namespace}, &corev1.Secret{}); err != nil {
+				return fmt.Errorf("secret %s/%s of the jump host not found", namespace, jump.SecretName)
+			}
+		}
+	}
+
 	return nil
 }
@@ -3,27 +3,39 @@ package hostoperation
 import (
 	"context"
 	"fmt"
+	"path"
+	"slices"
 
 	"go.uber.org/zap"
 
 	//"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/infrastructure-io/topohub/pkg/config"
 	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
 	"github.com/infrastructure-io/topohub/pkg/log"
+	"github.com/infrastructure-io/topohub/pkg/tools"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	defaultSSHTimeoutSeconds = 300
+	defaultFileMode          = "0644"
+)
+
 type HostOperationWebhook struct {
 	Client client.Client
+	config *config.AgentConfig
 	log    *zap.SugaredLogger
 }
 
-func (h *HostOperationWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+func (h *HostOperationWebhook) SetupWebhookWithManager(mgr ctrl.Manager, config config.AgentConfig) error {
 	h.Client = mgr.GetClient()
+	h.config = &config
 	h.log = log.Logger.Named("hostoperationWebhook")
 	log.Logger.Info("Setting up HostOperation webhook")
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -45,6 +57,15 @@ func (h *HostOperationWebhook) Default(ctx context.Context, obj runtime.Object)
 
 	h.log.Debugf("Processing Default webhook for HostOperation %s", hostOp.Name)
 
+	if hostOp.Spec.SSHStatusName != "" {
+		if hostOp.Spec.TimeoutSeconds == 0 {
+			hostOp.Spec.TimeoutSeconds = defaultSSHTimeoutSeconds
+		}
+		if hostOp.Spec.File != nil && hostOp.Spec.File.Mode == "" {
+			hostOp.Spec.File.Mode = defaultFileMode
+		}
+	}
+
 	h.log.Debugf("Successfully processed Default webhook for HostOperation %s", hostOp.Name)
 	return nil
 }
@@ -61,6 +82,27 @@ func (h *HostOperationWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 
 	h.log.Debugf("Processing ValidateCreate webhook for HostOperation %s", hostOp.Name)
 
+	if err := validateSpec(&hostOp.Spec); err != nil {
+		h.log.Error(err.Error())
+		return nil, err
+	}
+
+	if hostOp.Spec.SSHStatusName != "" {
+		// 验证 SSHStatusName 对应的 SSHStatus 是否存在且健康
+		var sshStatus topohubv1beta1.SSHStatus
+		if err := h.Client.Get(ctx, client.ObjectKey{Name: hostOp.Spec.SSHStatusName}, &sshStatus); err != nil {
+			err = fmt.Errorf("SSHStatus %s not found: %v", hostOp.Spec.SSHStatusName, err)
+			h.log.Error(err.Error())
+			return nil, err
+		}
+		if !sshStatus.Status.Healthy {
+			err := fmt.Errorf("SSHStatus %s is not healthy, so it is not allowed to create hostOperation %s", hostOp.Spec.SSHStatusName, hostOp.Name)
+			h.log.Error(err.Error())
+			return nil, err
+		}
+		return h.validateMaintenance(ctx, hostOp)
+	}
+
 	// 验证 RedfishStatusName 对应的 RedfishStatus 是否存在且健康
 	var redfishStatus topohubv1beta1.RedfishStatus
 	if err := h.Client.Get(ctx, client.ObjectKey{Name: hostOp.Spec.RedfishStatusName}, &redfishStatus); err != nil {
@@ -75,8 +117,101 @@ func (h *HostOperationWebhook) ValidateCreate(ctx context.Context, obj runtime.O
 		return nil, err
 	}
 
+	return h.validateMaintenance(ctx, hostOp)
+}
+
+// validateMaintenance rejects the disruptive action on the Host which is not in maintenance unless it is forced,
+// and warns about the other pending operations of the same server. The maintenance is recorded on the Host, so the
+// disruptive action on the target which is not linked to any Host is also rejected unless it is forced
+func (h *HostOperationWebhook) validateMaintenance(ctx context.Context, hostOp *topohubv1beta1.HostOperation) (admission.Warnings, error) {
+	hostList := &topohubv1beta1.HostList{}
+	if err := h.Client.List(ctx, hostList); err != nil {
+		err = fmt.Errorf("failed to list hosts: %v", err)
+		h.log.Error(err.Error())
+		return nil, err
+	}
+
+	var warnings admission.Warnings
+	host := tools.FindHostOfOperation(hostList.Items, &hostOp.Spec)
+	if tools.IsDisruptiveAction(hostOp.Spec.Action) {
+		switch {
+		case host == nil && !hostOp.Spec.Force:
+			err := fmt.Errorf("%s is not linked to any Host to set the maintenance, so action %s is not allowed unless spec.force is set", hostOp.Spec.RedfishStatusName+hostOp.Spec.SSHStatusName, hostOp.Spec.Action)
+			h.log.Error(err.Error())
+			return nil, err
+		case host == nil:
+			name := hostOp.Spec.RedfishStatusName + hostOp.Spec.SSHStatusName
+			h.log.Warnf("HostOperation %s forces action %s on %s which is not linked to any Host", hostOp.Name, hostOp.Spec.Action, name)
+			warnings = append(warnings, fmt.Sprintf("%s is not linked to any Host, action %s is forced", name, hostOp.Spec.Action))
+		case host.Spec.Maintenance == nil && !hostOp.Spec.Force:
+			err := fmt.Errorf("host %s is not in maintenance, so action %s is not allowed unless spec.force is set", host.Name, hostOp.Spec.Action)
+			h.log.Error(err.Error())
+			return nil, err
+		case host.Spec.Maintenance == nil:
+			h.log.Warnf("HostOperation %s forces action %s on host %s which is not in maintenance", hostOp.Name, hostOp.Spec.Action, host.Name)
+			warnings = append(warnings, fmt.Sprintf("host %s is not in maintenance, action %s is forced", host.Name, hostOp.Spec.Action))
+		}
+	}
+
+	hostOpList := &topohubv1beta1.HostOperationList{}
+	if err := h.Client.List(ctx, hostOpList); err != nil {
+		err = fmt.Errorf("failed to list hostOperations: %v", err)
+		h.log.Error(err.Error())
+		return nil, err
+	}
+	targets := tools.HostTargets(host, &hostOp.Spec)
+	for i := range hostOpList.Items {
+		item := &hostOpList.Items[i]
+		if item.Name == hostOp.Name || !tools.IsUnfinishedOperation(item) {
+			continue
+		}
+		if slices.Contains(targets, tools.HostOperationTarget(&item.Spec)) {
+			warnings = append(warnings, fmt.Sprintf("hostOperation %s with action %s is pending or running on the same host, they run one after another", item.Name, item.Spec.Action))
+		}
+	}
+
 	h.log.Debugf("Successfully validated HostOperation %s creation", hostOp.Name)
-	return nil, nil
+	return warnings, nil
+}
+
+// validateSpec checks the target and the arguments of the action
+func validateSpec(spec *topohubv1beta1.HostOperationSpec) error {
+	if (spec.RedfishStatusName == "") == (spec.SSHStatusName == "") {
+		return fmt.Errorf("exactly one of redfishStatusName and sshStatusName is required")
+	}
+	if spec.RedfishStatusName != "" {
+		if spec.Action == topohubv1beta1.SSHCmdRunScript || spec.Action == topohubv1beta1.SSHCmdPushFile {
+			return fmt.Errorf("action %s is only supported by the ssh hosts", spec.Action)
+		}
+		return nil
+	}
+
+	switch spec.Action {
+	case topohubv1beta1.BootCmdGracefulShutdown, topohubv1beta1.BootCmdGracefulRestart:
+	case topohubv1beta1.SSHCmdRunScript:
+		if spec.Script == nil || spec.Script.Name == "" {
+			return fmt.Errorf("script.name is required by action %s", spec.Action)
+		}
+		if !tools.IsRelativePath(spec.Script.Name) {
+			return fmt.Errorf("script.name %s must be a path under the tools directory", spec.Script.Name)
+		}
+		if spec.Script.Sha256 == "" {
+			return fmt.Errorf("script.sha256 is required to approve the script %s", spec.Script.Name)
+		}
+	case topohubv1beta1.SSHCmdPushFile:
+		if spec.File == nil || spec.File.Source == "" || spec.File.Destination == "" {
+			return fmt.Errorf("file.source and file.destination are required by action %s", spec.Action)
+		}
+		if !tools.IsRelativePath(spec.File.Source) {
+			return fmt.Errorf("file.source %s must be a path under the http directory", spec.File.Source)
+		}
+		if !path.IsAbs(spec.File.Destination) {
+			return fmt.Errorf("file.destination %s must be an absolute path", spec.File.Destination)
+		}
+	default:
+		return fmt.Errorf("action %s is not supported by the ssh hosts", spec.Action)
+	}
+	return nil
 }
 
 func (h *HostOperationWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
@@ -86,10 +221,42 @@ func (h *HostOperationWebhook) ValidateUpdate(ctx context.Context, oldObj, newOb
 		h.log.Error(err.Error())
 		return nil, err
 	}
+	newHostOp, ok := newObj.(*topohubv1beta1.HostOperation)
+	if !ok {
+		err := fmt.Errorf("expected a HostOperation but got a %T", newObj)
+		h.log.Error(err.Error())
+		return nil, err
+	}
+
+	// topohub moves the HostOperations to the new name when it renames the RedfishStatus
+	if h.isController(ctx) && isRedfishStatusMoved(&hostOp.Spec, &newHostOp.Spec) {
+		h.log.Infof("Allowing update of HostOperation %s: moved from redfishstatus %s to %s", hostOp.Name, hostOp.Spec.RedfishStatusName, newHostOp.Spec.RedfishStatusName)
+		return nil, nil
+	}
+
 	h.log.Debugf("Rejecting update of HostOperation %s: updates are not allowed", hostOp.Name)
 	return nil, fmt.Errorf("updates to HostOperation resources are not allowed")
 }
 
+// isRedfishStatusMoved checks if only the redfishStatusName of the spec is changed
+func isRedfishStatusMoved(oldSpec, newSpec *topohubv1beta1.HostOperationSpec) bool {
+	if oldSpec.RedfishStatusName == "" || newSpec.RedfishStatusName == "" {
+		return false
+	}
+	moved := newSpec.DeepCopy()
+	moved.RedfishStatusName = oldSpec.RedfishStatusName
+	return equality.Semantic.DeepEqual(oldSpec, moved)
+}
+
+// isController checks if the admission request is sent by the service account of topohub
+func (h *HostOperationWebhook) isController(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || h.config.ControllerUsername() == "" {
+		return false
+	}
+	return req.UserInfo.Username == h.config.ControllerUsername()
+}
+
 func (h *HostOperationWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	hostOp, ok := obj.(*topohubv1beta1.HostOperation)
 	if !ok {
@@ -0,0 +1,84 @@
+package provisionprofile
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	topohubv1beta1 "github.com/infrastructure-io/topohub/pkg/k8s/apis/topohub.infrastructure.io/v1beta1"
+	"github.com/infrastructure-io/topohub/pkg/log"
+	"github.com/infrastructure-io/topohub/pkg/tools"
+)
+
+// +kubebuilder:webhook:path=/validate-topohub-infrastructure-io-v1beta1-provisionprofile,mutating=false,failurePolicy=fail,sideEffects=None,groups=topohub.infrastructure.io,resources=provisionprofiles,verbs=create;update,versions=v1beta1,name=vprovisionprofile.kb.io,admissionReviewVersions=v1
+
+// ProvisionProfileWebhook validates ProvisionProfile resources
+type ProvisionProfileWebhook struct {
+	log *zap.SugaredLogger
+}
+
+// SetupWebhookWithManager sets up the webhook with the Manager
+func (w *ProvisionProfileWebhook) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	w.log = log.Logger.Named("provisionprofileWebhook")
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&topohubv1beta1.ProvisionProfile{}).
+		WithValidator(w).
+		Complete()
+}
+
+// ValidateCreate implements webhook.Validator
+func (w *ProvisionProfileWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	profile, ok := obj.(*topohubv1beta1.ProvisionProfile)
+	if !ok {
+		err := fmt.Errorf("object is not a ProvisionProfile")
+		w.log.Error(err.Error())
+		return nil, err
+	}
+
+	w.log.Debugf("Validating creation of ProvisionProfile %s", profile.Name)
+	if err := validateSpec(&profile.Spec); err != nil {
+		w.log.Errorf("Failed to validate ProvisionProfile %s: %v", profile.Name, err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator
+func (w *ProvisionProfileWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	profile, ok := newObj.(*topohubv1beta1.ProvisionProfile)
+	if !ok {
+		err := fmt.Errorf("object is not a ProvisionProfile")
+		w.log.Error(err.Error())
+		return nil, err
+	}
+
+	w.log.Debugf("Validating update of ProvisionProfile %s", profile.Name)
+	if err := validateSpec(&profile.Spec); err != nil {
+		w.log.Errorf("Failed to validate ProvisionProfile %s: %v", profile.Name, err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator
+func (w *ProvisionProfileWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateSpec checks the files of the profile are under the http/iso directory
+func validateSpec(spec *topohubv1beta1.ProvisionProfileSpec) error {
+	if !tools.IsRelativePath(spec.Kernel) {
+		return fmt.Errorf("kernel %s must be a relative path under the http/iso directory", spec.Kernel)
+	}
+	if !tools.IsRelativePath(spec.Initrd) {
+		return fmt.Errorf("initrd %s must be a relative path under the http/iso directory", spec.Initrd)
+	}
+	if spec.Iso != nil && !tools.IsRelativePath(*spec.Iso) {
+		return fmt.Errorf("iso %s must be a relative path under the http/iso directory", *spec.Iso)
+	}
+	return nil
+}
@@ -120,8 +120,16 @@ func (w *SubnetWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runti
 		return nil, fmt.Errorf("invalid subnet format: %v", err)
 	}
 
+	var warnings admission.Warnings
 	if err := tools.ValidateIPRangeExpansion(oldSubnet.Spec.IPv4Subnet.IPRange, newSubnet.Spec.IPv4Subnet.IPRange, ipNet); err != nil {
-		return nil, err
+		if newSubnet.Annotations[topohubv1beta1.AnnotationAllowIPRangeShrink] != "true" {
+			return nil, fmt.Errorf("%v, set the annotation %s=true to shrink the ip range", err, topohubv1beta1.AnnotationAllowIPRangeShrink)
+		}
+		w.log.Warnf("ip range of subnet %s is shrunk from %s to %s", newSubnet.Name, oldSubnet.Spec.IPv4Subnet.IPRange, newSubnet.Spec.IPv4Subnet.IPRange)
+		warnings, err = w.outOfRangeLeaseWarnings(ctx, newSubnet)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// 3. 验证 interface name 不允许修改
@@ -145,7 +153,23 @@ func (w *SubnetWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runti
 		return nil, err
 	}
 
-	return nil, nil
+	return warnings, nil
+}
+
+// outOfRangeLeaseWarnings reports the dhcp leases which fall outside the ip range of the subnet
+func (w *SubnetWebhook) outOfRangeLeaseWarnings(ctx context.Context, subnet *topohubv1beta1.Subnet) (admission.Warnings, error) {
+	leaseList := &topohubv1beta1.DhcpLeaseList{}
+	if err := w.Client.List(ctx, leaseList, client.MatchingLabels{topohubv1beta1.LabelSubnetName: subnet.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list dhcp leases: %v", err)
+	}
+
+	var warnings admission.Warnings
+	for _, item := range leaseList.Items {
+		if !tools.IsIPInRange(net.ParseIP(item.Spec.IpAddr), subnet.Spec.IPv4Subnet.IPRange) {
+			warnings = append(warnings, fmt.Sprintf("the lease of ip %s for mac %s is out of the new ip range %s", item.Spec.IpAddr, item.Spec.MacAddr, subnet.Spec.IPv4Subnet.IPRange))
+		}
+	}
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.Validator
@@ -190,6 +214,19 @@ func (w *SubnetWebhook) validateSubnet(ctx context.Context, subnet *topohubv1bet
 		return fmt.Errorf("invalid interface configuration: %v", err)
 	}
 
+	// Validate the naming of the redfishstatus
+	if subnet.Spec.Feature != nil && subnet.Spec.Feature.SyncRedfishstatus.Naming != nil {
+		naming := subnet.Spec.Feature.SyncRedfishstatus.Naming
+		if naming.Strategy == topohubv1beta1.NamingStrategyTemplate {
+			if naming.Template == "" {
+				return fmt.Errorf("naming template is required by the template strategy")
+			}
+			if _, err := tools.ParseNamingTemplate(naming.Template); err != nil {
+				return fmt.Errorf("invalid naming template: %v", err)
+			}
+		}
+	}
+
 	return nil
 }
 